@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"strings"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// The defaults applied by the mutating webhooks, so the stored objects show the configuration that runs
+const (
+	// DefaultEventApiVersion is the event API version of the event publishers
+	DefaultEventApiVersion = "2.0"
+	// DefaultEventStorageType is the storage type of the event publishers
+	DefaultEventStorageType = EventStorageEmptyDir
+	// DefaultSchedulingPolicy is the scheduling policy of the linuxptp processes of a profile
+	DefaultSchedulingPolicy = "SCHED_OTHER"
+
+	DefaultHoldOverTimeout    int64 = 5
+	DefaultMaxOffsetThreshold int64 = 100
+	DefaultMinOffsetThreshold int64 = -100
+	// DefaultProcessDowntime is the acceptable downtime in seconds of the linuxptp processes
+	DefaultProcessDowntime = 5
+	// DefaultGpsProcessDowntime is the acceptable downtime in seconds of the gpsd and gpspipe processes
+	DefaultGpsProcessDowntime = 1
+)
+
+// profileReferenceSettings are the PtpSettings referencing profiles by name, as a comma separated list
+var profileReferenceSettings = []string{"controllingProfile", "haProfiles"}
+
+// SetDefaults sets the event configs and the enabled plugins that are not set to their defaults. The plugins of
+// the daemon pools are not defaulted, pools without plugins inherit the PtpOperatorConfig plugins.
+func (r *PtpOperatorConfig) SetDefaults() {
+	setEventConfigDefaults(r.Spec.EventConfig)
+	for i := range r.Spec.DaemonPools {
+		setEventConfigDefaults(r.Spec.DaemonPools[i].EventConfig)
+	}
+	if r.Spec.EnabledPlugins == nil {
+		plugins := make(map[string]*apiextensions.JSON, len(DefaultPlugins))
+		for _, name := range DefaultPlugins {
+			plugins[name] = &apiextensions.JSON{Raw: []byte("{}")}
+		}
+		r.Spec.EnabledPlugins = &plugins
+	}
+}
+
+func setEventConfigDefaults(cfg *PtpEventConfig) {
+	if cfg == nil {
+		return
+	}
+	if cfg.ApiVersion == "" {
+		cfg.ApiVersion = DefaultEventApiVersion
+	}
+	if cfg.StorageType == "" {
+		cfg.StorageType = DefaultEventStorageType
+	}
+}
+
+// SetDefaults sets the scheduling policy and the clock thresholds of the profiles that are not set to their
+// defaults, and qualifies the profile references of their ptpSettings, typed settings and high availability sources
+// with the name of the PtpConfig holding the referenced profile, searched in the PtpConfig and the ptpConfigs of its
+// namespace. References to unknown profiles are left as is.
+func (r *PtpConfig) SetDefaults(ptpConfigs []PtpConfig) {
+	configs := withPtpConfig(r, ptpConfigs)
+
+	for i := range r.Spec.Profile {
+		profile := &r.Spec.Profile[i]
+		if profile.PtpSchedulingPolicy == nil {
+			policy := DefaultSchedulingPolicy
+			profile.PtpSchedulingPolicy = &policy
+		}
+		if profile.PtpClockThreshold == nil {
+			profile.PtpClockThreshold = &PtpClockThreshold{
+				HoldOverTimeout:    DefaultHoldOverTimeout,
+				MaxOffsetThreshold: DefaultMaxOffsetThreshold,
+				MinOffsetThreshold: DefaultMinOffsetThreshold,
+			}
+		}
+		if profile.PtpClockThreshold.ProcessDowntimeThresholds == nil {
+			profile.PtpClockThreshold.ProcessDowntimeThresholds = &ProcessDowntimeThresholds{}
+		}
+		profile.PtpClockThreshold.ProcessDowntimeThresholds.setDefaults()
+
+		for _, setting := range profileReferenceSettings {
+			value := profile.PtpSettings[setting]
+			if value == "" {
+				continue
+			}
+			references := strings.Split(value, ",")
+			for j, reference := range references {
+				references[j], _ = ResolveProfileReference(strings.TrimSpace(reference), r.Namespace, configs)
+			}
+			profile.PtpSettings[setting] = strings.Join(references, ",")
+		}
+		if settings := profile.Settings; settings != nil {
+			if settings.ControllingProfile != "" {
+				settings.ControllingProfile, _ = ResolveProfileReference(settings.ControllingProfile, r.Namespace, configs)
+			}
+			for j := range settings.HAProfiles {
+				settings.HAProfiles[j], _ = ResolveProfileReference(settings.HAProfiles[j], r.Namespace, configs)
+			}
+		}
+		if ha := profile.HighAvailability; ha != nil {
+			for j := range ha.Sources {
+				ha.Sources[j].Profile, _ = ResolveProfileReference(ha.Sources[j].Profile, r.Namespace, configs)
+			}
+		}
+	}
+}
+
+func (t *ProcessDowntimeThresholds) setDefaults() {
+	for _, threshold := range []**int{&t.Ptp4l, &t.Phc2sys, &t.Ts2phc, &t.Synce4l, &t.Chronyd} {
+		if *threshold == nil {
+			downtime := DefaultProcessDowntime
+			*threshold = &downtime
+		}
+	}
+	for _, threshold := range []**int{&t.Gpsd, &t.Gpspipe} {
+		if *threshold == nil {
+			downtime := DefaultGpsProcessDowntime
+			*threshold = &downtime
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPtpOperatorConfigSetDefaults(t *testing.T) {
+	poolPlugins := map[string]*apiextensions.JSON{"e810": nil}
+	cfg := &PtpOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "openshift-ptp"},
+		Spec: PtpOperatorConfigSpec{
+			EventConfig: &PtpEventConfig{EnableEventPublisher: true},
+			DaemonPools: []PtpDaemonPool{
+				{Name: "gm", NodeSelector: map[string]string{"role": "gm"},
+					EventConfig: &PtpEventConfig{EnableEventPublisher: true, StorageType: EventStorageHostPath}},
+				{Name: "oc", NodeSelector: map[string]string{"role": "oc"}, EnabledPlugins: &poolPlugins},
+			},
+		},
+	}
+	cfg.SetDefaults()
+
+	assert.Equal(t, &PtpEventConfig{EnableEventPublisher: true, ApiVersion: "2.0", StorageType: EventStorageEmptyDir},
+		cfg.Spec.EventConfig)
+	assert.Equal(t, &PtpEventConfig{EnableEventPublisher: true, ApiVersion: "2.0", StorageType: EventStorageHostPath},
+		cfg.Spec.DaemonPools[0].EventConfig)
+	// pools without event config or plugins keep inheriting them
+	assert.Nil(t, cfg.Spec.DaemonPools[1].EventConfig)
+	assert.Nil(t, cfg.Spec.DaemonPools[0].EnabledPlugins)
+	assert.Equal(t, &poolPlugins, cfg.Spec.DaemonPools[1].EnabledPlugins)
+
+	if assert.NotNil(t, cfg.Spec.EnabledPlugins) {
+		assert.Len(t, *cfg.Spec.EnabledPlugins, len(DefaultPlugins))
+	}
+	assert.Equal(t, DefaultPlugins, cfg.EnabledPluginNames())
+	// the defaults are valid
+	assert.NoError(t, cfg.validate())
+
+	// explicitly disabled plugins stay disabled
+	noPlugins := map[string]*apiextensions.JSON{}
+	cfg = &PtpOperatorConfig{Spec: PtpOperatorConfigSpec{EnabledPlugins: &noPlugins}}
+	cfg.SetDefaults()
+	assert.Empty(t, *cfg.Spec.EnabledPlugins)
+	assert.Nil(t, cfg.Spec.EventConfig)
+}
+
+func TestPtpConfigSetDefaults(t *testing.T) {
+	cfg := &PtpConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "tbc", Namespace: "openshift-ptp"},
+		Spec: PtpConfigSpec{Profile: []PtpProfile{
+			{Name: strPtr("tr"), PtpSettings: map[string]string{"controllingProfile": "tt"}},
+			{Name: strPtr("tt"), PtpSchedulingPolicy: strPtr("SCHED_FIFO"), PtpSchedulingPriority: int64Ptr(10),
+				PtpClockThreshold: &PtpClockThreshold{HoldOverTimeout: 60, MaxOffsetThreshold: 50, MinOffsetThreshold: -50,
+					ProcessDowntimeThresholds: &ProcessDowntimeThresholds{Ptp4l: intPtr(0)}}},
+			{Name: strPtr("ha"), PtpSettings: map[string]string{"haProfiles": "ha1, other_ha2,unknown"}},
+			{Name: strPtr("typed"), Settings: &PtpProfileSettings{ControllingProfile: "tr", HAProfiles: []string{"ha2", "unknown"}}},
+		}},
+	}
+	others := []PtpConfig{
+		makeProfileConfig("other", "ha1", "ha2"),
+		// the stored version of the PtpConfig does not hide its current profiles
+		makeProfileConfig("tbc", "old"),
+	}
+	cfg.SetDefaults(others)
+
+	tr := cfg.Spec.Profile[0]
+	assert.Equal(t, "SCHED_OTHER", *tr.PtpSchedulingPolicy)
+	assert.Nil(t, tr.PtpSchedulingPriority)
+	assert.Equal(t, int64(5), tr.PtpClockThreshold.HoldOverTimeout)
+	assert.Equal(t, int64(100), tr.PtpClockThreshold.MaxOffsetThreshold)
+	assert.Equal(t, int64(-100), tr.PtpClockThreshold.MinOffsetThreshold)
+	assert.Equal(t, &ProcessDowntimeThresholds{Ptp4l: intPtr(5), Phc2sys: intPtr(5), Ts2phc: intPtr(5),
+		Synce4l: intPtr(5), Chronyd: intPtr(5), Gpsd: intPtr(1), Gpspipe: intPtr(1)},
+		tr.PtpClockThreshold.ProcessDowntimeThresholds)
+	assert.Equal(t, "tbc_tt", tr.PtpSettings["controllingProfile"])
+
+	tt := cfg.Spec.Profile[1]
+	assert.Equal(t, "SCHED_FIFO", *tt.PtpSchedulingPolicy)
+	assert.Equal(t, int64(60), tt.PtpClockThreshold.HoldOverTimeout)
+	assert.Equal(t, 0, *tt.PtpClockThreshold.ProcessDowntimeThresholds.Ptp4l)
+	assert.Equal(t, 5, *tt.PtpClockThreshold.ProcessDowntimeThresholds.Phc2sys)
+
+	assert.Equal(t, "other_ha1,other_ha2,unknown", cfg.Spec.Profile[2].PtpSettings["haProfiles"])
+	assert.Equal(t, &PtpProfileSettings{ControllingProfile: "tbc_tr", HAProfiles: []string{"other_ha2", "unknown"}},
+		cfg.Spec.Profile[3].Settings)
+
+	// defaulting is idempotent
+	defaulted := cfg.DeepCopy()
+	cfg.SetDefaults(others)
+	assert.Equal(t, defaulted, cfg)
+}
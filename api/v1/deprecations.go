@@ -0,0 +1,136 @@
+package v1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// renamedPtp4lOptions are the ptp4l options renamed by linuxptp, with their new name. The old names are still
+// accepted by linuxptp as aliases.
+var renamedPtp4lOptions = map[string]string{
+	"masterOnly": "serverOnly",
+	"slaveOnly":  "clientOnly",
+}
+
+// legacyPtp4lOptions are the ptp4l options replaced by linuxptp, with their replacement
+var legacyPtp4lOptions = map[string]string{
+	"pi_offset_const":   "step_threshold",
+	"pi_f_offset_const": "first_step_threshold",
+	"pi_max_frequency":  "max_frequency",
+}
+
+// deprecatedPtpSettings are the ptpSettings replaced by typed settings of the same name
+var deprecatedPtpSettings = []string{"upstreamPort", "leadingInterface"}
+
+// Deprecations returns the deprecated settings of the PtpConfig profiles, which are still accepted but should be
+// changed before an upgrade
+func (r *PtpConfig) Deprecations() []string {
+	var warnings []string
+	for _, profile := range r.Spec.Profile {
+		name := ""
+		if profile.Name != nil {
+			name = *profile.Name
+		}
+		if strings.Contains(name, ProfileNameSeparator) {
+			warnings = append(warnings, fmt.Sprintf("profile %s: the name contains '%s', which separates the PtpConfig "+
+				"and profile names of qualified profile references, rename the profile without '%s'",
+				name, ProfileNameSeparator, ProfileNameSeparator))
+		}
+
+		conf := &Ptp4lConf{}
+		if err := conf.PopulatePtp4lConf(profile.Ptp4lConf, nil); err == nil {
+			warnings = append(warnings, ptp4lConfDeprecations(name, conf)...)
+		}
+		if profile.Ptp4lOpts != nil {
+			for _, opt := range strings.Fields(*profile.Ptp4lOpts) {
+				if !strings.HasPrefix(opt, "--") {
+					continue
+				}
+				if warning := ptp4lOptionDeprecation(strings.TrimPrefix(opt, "--")); warning != "" {
+					warnings = append(warnings, fmt.Sprintf("profile %s: ptp4lOpts %s", name, warning))
+				}
+			}
+		}
+
+		for _, setting := range deprecatedPtpSettings {
+			if _, ok := profile.PtpSettings[setting]; ok {
+				warnings = append(warnings, fmt.Sprintf("profile %s: ptpSettings %s is deprecated, use settings.%s",
+					name, setting, setting))
+			}
+		}
+	}
+	return warnings
+}
+
+// ptp4lConfDeprecations returns the deprecated options of the ptp4lConf sections, in the order of the sections
+func ptp4lConfDeprecations(profile string, conf *Ptp4lConf) []string {
+	sections := make([]string, 0, len(conf.sections))
+	for section := range conf.sections {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+
+	var warnings []string
+	for _, section := range sections {
+		options := make([]string, 0, len(conf.sections[section].options))
+		for option := range conf.sections[section].options {
+			options = append(options, option)
+		}
+		sort.Strings(options)
+		for _, option := range options {
+			if warning := ptp4lOptionDeprecation(option); warning != "" {
+				warnings = append(warnings, fmt.Sprintf("profile %s: ptp4lConf %s %s", profile, section, warning))
+			}
+		}
+	}
+	return warnings
+}
+
+func ptp4lOptionDeprecation(option string) string {
+	if name, ok := renamedPtp4lOptions[option]; ok {
+		return fmt.Sprintf("option %s was renamed %s by linuxptp, use %s", option, name, name)
+	}
+	if replacement, ok := legacyPtp4lOptions[option]; ok {
+		return fmt.Sprintf("option %s is no longer supported by linuxptp, use %s", option, replacement)
+	}
+	return ""
+}
+
+// Deprecations returns the deprecated settings of the PtpOperatorConfig, which are still accepted but should be
+// changed before an upgrade
+func (r *PtpOperatorConfig) Deprecations() []string {
+	var warnings []string
+	warnings = append(warnings, eventConfigDeprecations("ptpEventConfig", r.Spec.EventConfig)...)
+	for i, pool := range r.Spec.DaemonPools {
+		warnings = append(warnings, eventConfigDeprecations(fmt.Sprintf("daemonPools[%d].ptpEventConfig", i), pool.EventConfig)...)
+	}
+	return warnings
+}
+
+// StoredDeprecations returns the deprecated settings of a stored PtpOperatorConfig. Unlike the objects admitted by
+// the webhooks, whose plugins are defaulted, objects stored before the defaulting webhook may rely on the implicit
+// default plugins.
+func (r *PtpOperatorConfig) StoredDeprecations() []string {
+	warnings := r.Deprecations()
+	if r.Spec.EnabledPlugins == nil {
+		warnings = append(warnings, fmt.Sprintf("plugins is not set, the default plugins %s are enabled, set the "+
+			"plugins explicitly", strings.Join(DefaultPlugins, ", ")))
+	}
+	return warnings
+}
+
+// eventConfigDeprecations returns the references to the event API 1.0, which is only rejected for enabled event
+// publishers
+func eventConfigDeprecations(field string, cfg *PtpEventConfig) []string {
+	if cfg == nil || cfg.ApiVersion == "" || !isV1Api(cfg.ApiVersion) {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s.apiVersion %s: the event API 1.0 has reached end of life, use %s",
+		field, cfg.ApiVersion, DefaultEventApiVersion)}
+}
+
+// isV1Api returns whether the version is a 1.x event API version
+func isV1Api(version string) bool {
+	return version == "1" || strings.HasPrefix(version, "1.")
+}
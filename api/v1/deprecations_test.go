@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPtpConfigDeprecations(t *testing.T) {
+	cfg := &PtpConfig{Spec: PtpConfigSpec{Profile: []PtpProfile{
+		{
+			Name:      strPtr("bc"),
+			Ptp4lOpts: strPtr("-2 --slaveOnly 1 --summary_interval -4"),
+			Ptp4lConf: strPtr("[ens1f0]\nmasterOnly 0\n[ens1f1]\nmasterOnly 1\n[global]\npi_offset_const 0.0001\ndomainNumber 24"),
+			PtpSettings: map[string]string{
+				"upstreamPort":     "ens1f0",
+				"leadingInterface": "ens1f0",
+				"logReduce":        "true",
+			},
+		},
+		{Name: strPtr("t_gm"), Ptp4lConf: strPtr("[global]\nserverOnly 1")},
+		{Name: strPtr("oc"), Ptp4lConf: strPtr("option not in section")},
+	}}}
+
+	assert.Equal(t, []string{
+		"profile bc: ptp4lConf [ens1f0] option masterOnly was renamed serverOnly by linuxptp, use serverOnly",
+		"profile bc: ptp4lConf [ens1f1] option masterOnly was renamed serverOnly by linuxptp, use serverOnly",
+		"profile bc: ptp4lConf [global] option pi_offset_const is no longer supported by linuxptp, use step_threshold",
+		"profile bc: ptp4lOpts option slaveOnly was renamed clientOnly by linuxptp, use clientOnly",
+		"profile bc: ptpSettings upstreamPort is deprecated, use settings.upstreamPort",
+		"profile bc: ptpSettings leadingInterface is deprecated, use settings.leadingInterface",
+		"profile t_gm: the name contains '_', which separates the PtpConfig and profile names of qualified " +
+			"profile references, rename the profile without '_'",
+	}, cfg.Deprecations())
+
+	cfg = &PtpConfig{Spec: PtpConfigSpec{Profile: []PtpProfile{
+		{Name: strPtr("gm"), Ptp4lConf: strPtr("[ens1f0]\nserverOnly 1\n[global]\nstep_threshold 2.0")},
+	}}}
+	assert.Empty(t, cfg.Deprecations())
+}
+
+func TestPtpOperatorConfigDeprecations(t *testing.T) {
+	cfg := &PtpOperatorConfig{Spec: PtpOperatorConfigSpec{
+		EventConfig: &PtpEventConfig{ApiVersion: "1.0"},
+		DaemonPools: []PtpDaemonPool{
+			{Name: "gm", EventConfig: &PtpEventConfig{ApiVersion: "2.0"}},
+			{Name: "oc", EventConfig: &PtpEventConfig{ApiVersion: "1.0"}},
+		},
+	}}
+	assert.Equal(t, []string{
+		"ptpEventConfig.apiVersion 1.0: the event API 1.0 has reached end of life, use 2.0",
+		"daemonPools[1].ptpEventConfig.apiVersion 1.0: the event API 1.0 has reached end of life, use 2.0",
+	}, cfg.Deprecations())
+
+	// the plugins are defaulted, not deprecated
+	cfg = &PtpOperatorConfig{Spec: PtpOperatorConfigSpec{}}
+	assert.Empty(t, cfg.Deprecations())
+	// the defaults are not deprecated
+	cfg = &PtpOperatorConfig{Spec: PtpOperatorConfigSpec{EventConfig: &PtpEventConfig{}}}
+	cfg.SetDefaults()
+	assert.Empty(t, cfg.Deprecations())
+}
+
+func TestPtpOperatorConfigStoredDeprecations(t *testing.T) {
+	cfg := &PtpOperatorConfig{Spec: PtpOperatorConfigSpec{EventConfig: &PtpEventConfig{ApiVersion: "1.0"}}}
+	assert.Equal(t, []string{
+		"ptpEventConfig.apiVersion 1.0: the event API 1.0 has reached end of life, use 2.0",
+		"plugins is not set, the default plugins e810, e825, e830, ntpfailover are enabled, set the plugins explicitly",
+	}, cfg.StoredDeprecations())
+
+	cfg = &PtpOperatorConfig{Spec: PtpOperatorConfigSpec{}}
+	cfg.SetDefaults()
+	assert.Empty(t, cfg.StoredDeprecations())
+}
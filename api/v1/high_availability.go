@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The high availability ptpSettings rendered for the linuxptp daemon with haProfiles. The selection criteria are
+// comma separated lists aligned with haProfiles, an empty item leaving the criterion of the source unset.
+const (
+	haHysteresisSetting    = "haHysteresis"
+	haMaxClockClassSetting = "haMaxClockClass"
+	haMaxOffsetSetting     = "haMaxOffset"
+	haPortStatesSetting    = "haPortStates"
+
+	// portStateSeparator separates the port states of a source in haPortStates
+	portStateSeparator = "|"
+)
+
+// highAvailabilitySettings are the ptpSettings rendered from the high availability policy
+var highAvailabilitySettings = []string{"haProfiles", haHysteresisSetting, haMaxClockClassSetting, haMaxOffsetSetting,
+	haPortStatesSetting}
+
+// SourceProfiles returns the source profiles in order of preference
+func (h *PhcHighAvailability) SourceProfiles() []string {
+	profiles := make([]string, 0, len(h.Sources))
+	for _, source := range h.Sources {
+		profiles = append(profiles, source.Profile)
+	}
+	return profiles
+}
+
+// render returns the ptpSettings of the high availability policy: the sources in haProfiles and their selection
+// criteria and hysteresis, which the linuxptp daemon applies when it selects the source of phc2sys
+func (h *PhcHighAvailability) render() map[string]string {
+	settings := map[string]string{"haProfiles": strings.Join(h.SourceProfiles(), ",")}
+	if h.Hysteresis != nil {
+		settings[haHysteresisSetting] = h.Hysteresis.Duration.String()
+	}
+	maxClockClasses := make([]string, len(h.Sources))
+	maxOffsets := make([]string, len(h.Sources))
+	portStates := make([]string, len(h.Sources))
+	for i, source := range h.Sources {
+		if source.MaxClockClass != nil {
+			maxClockClasses[i] = strconv.FormatInt(*source.MaxClockClass, 10)
+		}
+		if source.MaxOffset != nil {
+			maxOffsets[i] = strconv.FormatInt(*source.MaxOffset, 10)
+		}
+		states := []string{string(PtpPortStateTimeReceiver)}
+		if len(source.PortStates) > 0 {
+			states = make([]string, len(source.PortStates))
+			for j, state := range source.PortStates {
+				states[j] = string(state)
+			}
+		}
+		portStates[i] = strings.Join(states, portStateSeparator)
+	}
+	if strings.Join(maxClockClasses, "") != "" {
+		settings[haMaxClockClassSetting] = strings.Join(maxClockClasses, ",")
+	}
+	if strings.Join(maxOffsets, "") != "" {
+		settings[haMaxOffsetSetting] = strings.Join(maxOffsets, ",")
+	}
+	settings[haPortStatesSetting] = strings.Join(portStates, ",")
+	return settings
+}
+
+// validateHighAvailability checks the high availability profile runs phc2sys and its sources are other profiles
+// that do not. The source profiles are looked up in ptpConfigs, they are not checked when ptpConfigs is nil.
+func (r *PtpConfig) validateHighAvailability(profile *PtpProfile, ptpConfigs []PtpConfig) error {
+	ha := profile.HighAvailability
+	if ha == nil {
+		return nil
+	}
+	if profile.Phc2sysOpts == nil || strings.TrimSpace(*profile.Phc2sysOpts) == "" {
+		return fmt.Errorf("profile %s: highAvailability requires phc2sysOpts to run phc2sys", *profile.Name)
+	}
+	if ha.Hysteresis != nil && ha.Hysteresis.Duration < 0 {
+		return fmt.Errorf("profile %s: highAvailability.hysteresis must not be negative", *profile.Name)
+	}
+	if ptpConfigs == nil {
+		return nil
+	}
+
+	self := QualifyProfileName(r.Name, *profile.Name)
+	for _, source := range ha.Sources {
+		qualified, ok := ResolveProfileReference(source.Profile, r.Namespace, ptpConfigs)
+		if !ok {
+			// reported with the other profile references
+			continue
+		}
+		if qualified == self {
+			return fmt.Errorf("profile %s: highAvailability source '%s' must be another profile", *profile.Name, source.Profile)
+		}
+		if sourceProfile := findProfile(qualified, r.Namespace, ptpConfigs); sourceProfile != nil &&
+			sourceProfile.Phc2sysOpts != nil && strings.TrimSpace(*sourceProfile.Phc2sysOpts) != "" {
+			return fmt.Errorf("profile %s: highAvailability source '%s' must not run phc2sys, its phc2sysOpts must be empty",
+				*profile.Name, source.Profile)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func makeHighAvailabilityConfig(sources ...string) *PtpConfig {
+	ha := &PhcHighAvailability{Hysteresis: &metav1.Duration{Duration: 10 * time.Second}}
+	for _, source := range sources {
+		ha.Sources = append(ha.Sources, PhcHighAvailabilitySource{Profile: source, MaxClockClass: int64Ptr(7)})
+	}
+	cfg := &PtpConfig{Spec: PtpConfigSpec{Profile: []PtpProfile{
+		{Name: strPtr("bc1"), Ptp4lConf: strPtr("[ens1f0]\nserverOnly 0"), Phc2sysOpts: strPtr("")},
+		{Name: strPtr("bc2"), Ptp4lConf: strPtr("[ens2f0]\nserverOnly 0")},
+		{Name: strPtr("ha"), Phc2sysOpts: strPtr("-a -r"), HighAvailability: ha},
+	}}}
+	cfg.Name = "dual-nic"
+	return cfg
+}
+
+func TestRenderHighAvailability(t *testing.T) {
+	cfg := makeHighAvailabilityConfig("bc1", "dual-nic_bc2")
+	settings, err := RenderPtpSettings(&cfg.Spec.Profile[2])
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"haProfiles":      "bc1,dual-nic_bc2",
+		"haHysteresis":    "10s",
+		"haMaxClockClass": "7,7",
+		"haPortStates":    "TimeReceiver,TimeReceiver",
+	}, settings)
+
+	cfg.Spec.Profile[2].HighAvailability.Hysteresis = nil
+	cfg.Spec.Profile[2].HighAvailability.Sources[0].MaxClockClass = nil
+	cfg.Spec.Profile[2].HighAvailability.Sources[1].MaxOffset = int64Ptr(100)
+	cfg.Spec.Profile[2].HighAvailability.Sources[1].PortStates = []PtpPortState{PtpPortStateTimeReceiver, PtpPortStateUncalibrated}
+	settings, err = RenderPtpSettings(&cfg.Spec.Profile[2])
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"haProfiles":      "bc1,dual-nic_bc2",
+		"haMaxClockClass": ",7",
+		"haMaxOffset":     ",100",
+		"haPortStates":    "TimeReceiver,TimeReceiver|Uncalibrated",
+	}, settings)
+	assert.NoError(t, validatePtpSettings(settings, nil))
+
+	cfg.Spec.Profile[2].PtpSettings = map[string]string{"haProfiles": "bc1,bc2"}
+	_, err = RenderPtpSettings(&cfg.Spec.Profile[2])
+	assert.EqualError(t, err, "highAvailability and ptpSettings haProfiles must not both be set")
+
+	cfg.Spec.Profile[2].PtpSettings = map[string]string{"haPortStates": "Passive"}
+	_, err = RenderPtpSettings(&cfg.Spec.Profile[2])
+	assert.EqualError(t, err, "highAvailability and ptpSettings haPortStates must not both be set")
+
+	cfg.Spec.Profile[2].PtpSettings = nil
+	cfg.Spec.Profile[2].Settings = &PtpProfileSettings{HAProfiles: []string{"bc1"}}
+	_, err = RenderPtpSettings(&cfg.Spec.Profile[2])
+	assert.EqualError(t, err, "highAvailability and settings.haProfiles must not both be set")
+}
+
+func TestValidateHighAvailability(t *testing.T) {
+	assert.NoError(t, makeHighAvailabilityConfig("bc1", "bc2").validate())
+
+	tests := []struct {
+		name   string
+		modify func(*PtpConfig)
+		errMsg string
+	}{
+		{"unknown source", func(cfg *PtpConfig) { cfg.Spec.Profile[2].HighAvailability.Sources[1].Profile = "bc3" },
+			"profile 'bc3' referenced in ptpSettings is not a profile of any PtpConfig"},
+		{"no phc2sys", func(cfg *PtpConfig) { cfg.Spec.Profile[2].Phc2sysOpts = strPtr(" ") },
+			"profile ha: highAvailability requires phc2sysOpts to run phc2sys"},
+		{"negative hysteresis", func(cfg *PtpConfig) {
+			cfg.Spec.Profile[2].HighAvailability.Hysteresis = &metav1.Duration{Duration: -time.Second}
+		}, "profile ha: highAvailability.hysteresis must not be negative"},
+		{"source is the profile", func(cfg *PtpConfig) { cfg.Spec.Profile[2].HighAvailability.Sources[1].Profile = "dual-nic_ha" },
+			"profile ha: highAvailability source 'dual-nic_ha' must be another profile"},
+		{"source runs phc2sys", func(cfg *PtpConfig) { cfg.Spec.Profile[1].Phc2sysOpts = strPtr("-a -r") },
+			"profile ha: highAvailability source 'bc2' must not run phc2sys, its phc2sysOpts must be empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := makeHighAvailabilityConfig("bc1", "bc2")
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.validate(), tt.errMsg)
+		})
+	}
+}
+
+func TestPtpConfigSetDefaultsHighAvailability(t *testing.T) {
+	cfg := makeHighAvailabilityConfig("bc1", "other_bc3", "unknown")
+	cfg.SetDefaults([]PtpConfig{makeProfileConfig("other", "bc3")})
+	assert.Equal(t, []string{"dual-nic_bc1", "other_bc3", "unknown"}, cfg.Spec.Profile[2].HighAvailability.SourceProfiles())
+}
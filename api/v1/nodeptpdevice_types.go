@@ -227,6 +227,50 @@ type NodePtpDeviceStatus struct {
 	// This includes the base board manufacturer, product name, version, and serial number.
 	// +optional
 	BaseBoardInfo *BaseBoardInfo `json:"baseBoardInfo,omitempty"`
+
+	// HighAvailability reports the source selected by phc2sys for each high availability profile of the node.
+	// It is written by the linuxptp daemon, which selects the source from the haProfiles, haMaxClockClass,
+	// haMaxOffset, haPortStates and haHysteresis ptpSettings of the profile.
+	// +optional
+	HighAvailability []PhcHighAvailabilityState `json:"highAvailability,omitempty"`
+
+	// GrandmasterSettings are the GRANDMASTER_SETTINGS_NP of the ptp4l instances of the node, reported by the
+	// linuxptp daemon for the profiles acting as grandmaster.
+	// +optional
+	GrandmasterSettings []GrandmasterSettings `json:"grandmasterSettings,omitempty"`
+}
+
+// GrandmasterSettings holds the leap fields a ptp4l instance announces as grandmaster
+type GrandmasterSettings struct {
+	// Profile is the name of the PtpConfig profile of the ptp4l instance.
+	// +required
+	Profile string `json:"profile"`
+
+	// CurrentUTCOffset is the announced TAI-UTC offset in seconds.
+	CurrentUTCOffset int `json:"currentUtcOffset"`
+
+	// Leap61 is true when a leap second insertion is announced.
+	// +optional
+	Leap61 bool `json:"leap61,omitempty"`
+
+	// Leap59 is true when a leap second deletion is announced.
+	// +optional
+	Leap59 bool `json:"leap59,omitempty"`
+}
+
+// PhcHighAvailabilityState is the source selected by phc2sys for a high availability profile
+type PhcHighAvailabilityState struct {
+	// Profile is the qualified name of the high availability profile
+	Profile string `json:"profile"`
+
+	// ActiveSource is the qualified name of the selected source profile, empty when no source meets its selection
+	// criteria
+	// +optional
+	ActiveSource string `json:"activeSource,omitempty"`
+
+	// LastSwitchover is the time phc2sys switched over to the active source
+	// +optional
+	LastSwitchover *metav1.Time `json:"lastSwitchover,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,252 @@
+package v1
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
+)
+
+// pluginSchemaFiles holds the JSON schema of the configuration of each plugin known by the linuxptp daemon,
+// in a file named after the plugin
+//
+//go:embed pluginschemas/*.json
+var pluginSchemaFiles embed.FS
+
+// pluginSchemas maps the plugins known by the linuxptp daemon to the JSON schema of their configuration
+var pluginSchemas = loadPluginSchemas()
+
+// DefaultPlugins are the plugins enabled when the PtpOperatorConfig does not set plugins
+var DefaultPlugins = []string{"e810", "e825", "e830", "ntpfailover"}
+
+func loadPluginSchemas() map[string]*apiextensions.JSONSchemaProps {
+	files, err := pluginSchemaFiles.ReadDir("pluginschemas")
+	if err != nil {
+		panic(err)
+	}
+	schemas := make(map[string]*apiextensions.JSONSchemaProps, len(files))
+	for _, file := range files {
+		data, err := pluginSchemaFiles.ReadFile(path.Join("pluginschemas", file.Name()))
+		if err != nil {
+			panic(err)
+		}
+		schema := &apiextensions.JSONSchemaProps{}
+		if err := json.Unmarshal(data, schema); err != nil {
+			panic(fmt.Sprintf("invalid plugin schema %s: %v", file.Name(), err))
+		}
+		schemas[strings.TrimSuffix(file.Name(), ".json")] = schema
+	}
+	return schemas
+}
+
+// KnownPlugins returns the sorted names of the plugins known by the linuxptp daemon
+func KnownPlugins() []string {
+	plugins := make([]string, 0, len(pluginSchemas))
+	for name := range pluginSchemas {
+		plugins = append(plugins, name)
+	}
+	sort.Strings(plugins)
+	return plugins
+}
+
+// ValidatePluginConfig checks the plugin is known and its configuration matches the plugin schema
+func ValidatePluginConfig(name string, config *apiextensions.JSON) error {
+	schema, ok := pluginSchemas[name]
+	if !ok {
+		return fmt.Errorf("plugin '%s' is unknown; must be one of ['%s']", name, strings.Join(KnownPlugins(), "', '"))
+	}
+	if config == nil || len(config.Raw) == 0 {
+		return nil
+	}
+	var value interface{}
+	if err := json.Unmarshal(config.Raw, &value); err != nil {
+		return fmt.Errorf("plugin '%s' configuration is invalid: %w", name, err)
+	}
+	if value == nil {
+		return nil
+	}
+	return validateSchemaValue(name, schema, value)
+}
+
+// validateSchemaValue checks a JSON value against the subset of JSON schema used by the plugin schemas: type,
+// properties, required, additionalProperties, items, minimum, maximum and pattern
+func validateSchemaValue(field string, schema *apiextensions.JSONSchemaProps, value interface{}) error {
+	switch schema.Type {
+	case "":
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an object", field)
+		}
+		for _, key := range schema.Required {
+			if _, ok := object[key]; !ok {
+				return fmt.Errorf("%s.%s is required", field, key)
+			}
+		}
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			propSchema, ok := schema.Properties[key]
+			switch {
+			case ok:
+			case schema.AdditionalProperties == nil:
+				continue
+			case schema.AdditionalProperties.Schema != nil:
+				propSchema = *schema.AdditionalProperties.Schema
+			case !schema.AdditionalProperties.Allows:
+				return fmt.Errorf("%s.%s is not a known field", field, key)
+			default:
+				continue
+			}
+			if err := validateSchemaValue(field+"."+key, &propSchema, object[key]); err != nil {
+				return err
+			}
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s must be an array", field)
+		}
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return nil
+		}
+		for i, item := range array {
+			if err := validateSchemaValue(fmt.Sprintf("%s[%d]", field, i), schema.Items.Schema, item); err != nil {
+				return err
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string", field)
+		}
+		if schema.Pattern != "" && !regexp.MustCompile(schema.Pattern).MatchString(s) {
+			return fmt.Errorf("%s='%s' must match '%s'", field, s, schema.Pattern)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s must be a boolean", field)
+		}
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok || (schema.Type == "integer" && n != math.Trunc(n)) {
+			return fmt.Errorf("%s must be an %s", field, schema.Type)
+		}
+		if schema.Minimum != nil && n < *schema.Minimum {
+			return fmt.Errorf("%s=%v must be at least %v", field, n, *schema.Minimum)
+		}
+		if schema.Maximum != nil && n > *schema.Maximum {
+			return fmt.Errorf("%s=%v must be at most %v", field, n, *schema.Maximum)
+		}
+	default:
+		return fmt.Errorf("%s schema type '%s' is not supported", field, schema.Type)
+	}
+	return nil
+}
+
+// EnabledPluginNames returns the sorted names of the plugins enabled on the nodes of any daemon pool
+func (r *PtpOperatorConfig) EnabledPluginNames() []string {
+	enabled := map[string]bool{}
+	addPlugins := func(plugins *map[string]*apiextensions.JSON) {
+		if plugins == nil {
+			for _, name := range DefaultPlugins {
+				enabled[name] = true
+			}
+			return
+		}
+		for name := range *plugins {
+			enabled[name] = true
+		}
+	}
+	addPlugins(r.Spec.EnabledPlugins)
+	for _, pool := range r.Spec.DaemonPools {
+		if pool.EnabledPlugins != nil {
+			addPlugins(pool.EnabledPlugins)
+		}
+	}
+	plugins := make([]string, 0, len(enabled))
+	for name := range enabled {
+		plugins = append(plugins, name)
+	}
+	sort.Strings(plugins)
+	return plugins
+}
+
+// validatePlugins checks the configuration of the plugins enabled cluster-wide and in each daemon pool
+func (r *PtpOperatorConfig) validatePlugins() error {
+	validate := func(field string, plugins *map[string]*apiextensions.JSON) error {
+		if plugins == nil {
+			return nil
+		}
+		for name, config := range *plugins {
+			if err := ValidatePluginConfig(name, config); err != nil {
+				return fmt.Errorf("%s: %w", field, err)
+			}
+		}
+		return nil
+	}
+	if err := validate("plugins", r.Spec.EnabledPlugins); err != nil {
+		return err
+	}
+	for i, pool := range r.Spec.DaemonPools {
+		if err := validate(fmt.Sprintf("daemonPools[%d].plugins", i), pool.EnabledPlugins); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateProfilePlugins checks the configuration of the plugins of the profile, and that they are enabled
+// cluster-wide. The enablement is not checked when enabled is nil.
+func validateProfilePlugins(profile *PtpProfile, enabled []string) error {
+	pluginNames := make([]string, 0, len(profile.Plugins))
+	for name := range profile.Plugins {
+		pluginNames = append(pluginNames, name)
+	}
+	sort.Strings(pluginNames)
+	profileName := ""
+	if profile.Name != nil {
+		profileName = *profile.Name
+	}
+	for _, name := range pluginNames {
+		if err := ValidatePluginConfig(name, profile.Plugins[name]); err != nil {
+			return fmt.Errorf("profile %s: %w", profileName, err)
+		}
+		if enabled != nil && !slices.Contains(enabled, name) {
+			return fmt.Errorf("profile %s: plugin '%s' is not enabled in PtpOperatorConfig plugins", profileName, name)
+		}
+	}
+	return nil
+}
+
+// getEnabledPlugins returns the plugins enabled cluster-wide by the default PtpOperatorConfig, or nil when it
+// cannot be read
+func getEnabledPlugins() []string {
+	if webhookClient == nil {
+		return nil
+	}
+	cfg := &PtpOperatorConfig{}
+	err := webhookClient.Get(context.Background(), types.NamespacedName{
+		Namespace: names.Namespace,
+		Name:      names.DefaultOperatorConfigName,
+	}, cfg)
+	if err != nil {
+		ptpconfiglog.Info("failed to get PtpOperatorConfig, plugin enablement not validated", "error", err.Error())
+		return nil
+	}
+	return cfg.EnabledPluginNames()
+}
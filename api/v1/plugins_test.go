@@ -0,0 +1,118 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func pluginJSON(raw string) *apiextensions.JSON {
+	return &apiextensions.JSON{Raw: []byte(raw)}
+}
+
+func TestKnownPlugins(t *testing.T) {
+	assert.Equal(t, []string{"e810", "e825", "e830", "ntpfailover", "reference"}, KnownPlugins())
+	for _, name := range DefaultPlugins {
+		assert.Contains(t, KnownPlugins(), name)
+	}
+}
+
+func TestValidatePluginConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		plugin string
+		config *apiextensions.JSON
+		errMsg string
+	}{
+		{name: "no configuration", plugin: "e810"},
+		{name: "null configuration", plugin: "e810", config: pluginJSON("null")},
+		{
+			name:   "e810 grandmaster",
+			plugin: "e810",
+			config: pluginJSON(`{
+				"enableDefaultConfig": false,
+				"settings": {"LocalMaxHoldoverOffSet": 1500, "LocalHoldoverTimeout": 14400, "MaxInSpecOffset": 100},
+				"pins": {"ens2f0": {"U.FL2": "0 2", "U.FL1": "0 1", "SMA2": "0 2", "SMA1": "0 1"}},
+				"ublxCmds": [{"args": ["-P", "29.20", "-e", "GPS"], "reportOutput": false}]
+			}`),
+		},
+		{
+			name:   "e810 boundary clock interconnections",
+			plugin: "e810",
+			config: pluginJSON(`{"interconnections": [{"id": "ens2f0", "part": "E810-XXVDA4T", "gnssInput": false,
+				"phaseOutputConnectors": ["SMA1"], "upstreamPort": "ens2f1"}]}`),
+		},
+		{name: "reference accepts anything", plugin: "reference", config: pluginJSON(`"sample"`)},
+		{name: "ntpfailover", plugin: "ntpfailover", config: pluginJSON(`{"gnssFailover": true}`)},
+		{name: "unknown plugin", plugin: "e999", errMsg: "plugin 'e999' is unknown"},
+		{name: "invalid JSON", plugin: "e810", config: pluginJSON(`{`), errMsg: "configuration is invalid"},
+		{name: "not an object", plugin: "e810", config: pluginJSON(`true`), errMsg: "e810 must be an object"},
+		{name: "unknown field", plugin: "e810", config: pluginJSON(`{"enableDefaultConfg": true}`), errMsg: "e810.enableDefaultConfg is not a known field"},
+		{name: "wrong type", plugin: "e825", config: pluginJSON(`{"enableDefaultConfig": "yes"}`), errMsg: "e825.enableDefaultConfig must be a boolean"},
+		{name: "negative setting", plugin: "e810", config: pluginJSON(`{"settings": {"MaxInSpecOffset": -1}}`), errMsg: "e810.settings.MaxInSpecOffset=-1 must be at least 0"},
+		{name: "fractional setting", plugin: "e810", config: pluginJSON(`{"settings": {"MaxInSpecOffset": 1.5}}`), errMsg: "must be an integer"},
+		{name: "invalid pin", plugin: "e810", config: pluginJSON(`{"pins": {"ens2f0": {"SMA1": "out"}}}`), errMsg: "e810.pins.ens2f0.SMA1='out' must match"},
+		{name: "missing ublx args", plugin: "e810", config: pluginJSON(`{"ublxCmds": [{"reportOutput": true}]}`), errMsg: "e810.ublxCmds[0].args is required"},
+		{name: "no ublx on e830", plugin: "e830", config: pluginJSON(`{"ublxCmds": []}`), errMsg: "e830.ublxCmds is not a known field"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePluginConfig(tt.plugin, tt.config)
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestEnabledPluginNames(t *testing.T) {
+	cfg := &PtpOperatorConfig{}
+	assert.Equal(t, DefaultPlugins, cfg.EnabledPluginNames())
+
+	plugins := map[string]*apiextensions.JSON{"e810": nil}
+	gmPlugins := map[string]*apiextensions.JSON{"reference": nil}
+	cfg.Spec.EnabledPlugins = &plugins
+	cfg.Spec.DaemonPools = []PtpDaemonPool{{Name: "gm", EnabledPlugins: &gmPlugins}, {Name: "oc"}}
+	assert.Equal(t, []string{"e810", "reference"}, cfg.EnabledPluginNames())
+
+	none := map[string]*apiextensions.JSON{}
+	cfg.Spec.EnabledPlugins = &none
+	cfg.Spec.DaemonPools = nil
+	assert.Empty(t, cfg.EnabledPluginNames())
+}
+
+func TestValidateProfilePlugins(t *testing.T) {
+	name := "gm"
+	profile := &PtpProfile{Name: &name, Plugins: map[string]*apiextensions.JSON{
+		"e810": pluginJSON(`{"enableDefaultConfig": true}`),
+	}}
+	assert.NoError(t, validateProfilePlugins(profile, nil))
+	assert.NoError(t, validateProfilePlugins(profile, []string{"e810"}))
+
+	err := validateProfilePlugins(profile, []string{"e825"})
+	if assert.Error(t, err) {
+		assert.Equal(t, "profile gm: plugin 'e810' is not enabled in PtpOperatorConfig plugins", err.Error())
+	}
+
+	profile.Plugins["e810"] = pluginJSON(`{"enableDefaultConfig": 1}`)
+	err = validateProfilePlugins(profile, nil)
+	if assert.Error(t, err) {
+		assert.Equal(t, "profile gm: e810.enableDefaultConfig must be a boolean", err.Error())
+	}
+}
+
+func TestValidatePlugins(t *testing.T) {
+	plugins := map[string]*apiextensions.JSON{"e810": pluginJSON(`{"enableDefaultConfig": true}`)}
+	cfg := &PtpOperatorConfig{Spec: PtpOperatorConfigSpec{EnabledPlugins: &plugins}}
+	assert.NoError(t, cfg.validatePlugins())
+
+	poolPlugins := map[string]*apiextensions.JSON{"e999": nil}
+	cfg.Spec.DaemonPools = []PtpDaemonPool{{Name: "gm", EnabledPlugins: &poolPlugins}}
+	err := cfg.validatePlugins()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "daemonPools[0].plugins: plugin 'e999' is unknown")
+	}
+}
@@ -0,0 +1,74 @@
+package v1
+
+import "github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
+
+// ProfileNameSeparator separates the PtpConfig name from the profile name in qualified profile names
+const ProfileNameSeparator = "_"
+
+// NamespaceSeparator separates the namespace from the qualified profile name in the profile names delivered to the
+// nodes for the PtpConfigs outside of the operator namespace
+const NamespaceSeparator = "/"
+
+// QualifyProfileName creates a namespace-unique profile name by prepending the PtpConfig name. Profile references
+// are qualified names, they resolve to the profiles of the PtpConfigs of the same namespace.
+func QualifyProfileName(ptpConfigName, profileName string) string {
+	return ptpConfigName + ProfileNameSeparator + profileName
+}
+
+// NodeProfileName returns the node-unique name of a qualified profile name of a namespace, the name delivered to the
+// nodes. The profiles of the operator namespace keep their qualified name.
+func NodeProfileName(namespace, qualified string) string {
+	if namespace == "" || namespace == names.Namespace {
+		return qualified
+	}
+	return namespace + NamespaceSeparator + qualified
+}
+
+// ResolveProfileReference returns the qualified name of a profile referenced by a PtpConfig of the namespace. A
+// reference already qualified with the name of the PtpConfig holding the profile is returned as is, otherwise the
+// profile is searched by name. Only the PtpConfigs of the namespace are searched. It returns false when the profile
+// is not found.
+func ResolveProfileReference(value, namespace string, ptpConfigs []PtpConfig) (string, bool) {
+	if findProfile(value, namespace, ptpConfigs) != nil {
+		return value, true
+	}
+	for _, cfg := range ptpConfigs {
+		if cfg.Namespace != namespace {
+			continue
+		}
+		for _, p := range cfg.Spec.Profile {
+			if p.Name != nil && *p.Name == value {
+				return QualifyProfileName(cfg.Name, value), true
+			}
+		}
+	}
+	return value, false
+}
+
+// withPtpConfig returns the PtpConfigs with the PtpConfig replacing its stored version
+func withPtpConfig(r *PtpConfig, ptpConfigs []PtpConfig) []PtpConfig {
+	configs := []PtpConfig{*r}
+	for _, cfg := range ptpConfigs {
+		if cfg.Name != r.Name || cfg.Namespace != r.Namespace {
+			configs = append(configs, cfg)
+		}
+	}
+	return configs
+}
+
+// findProfile returns the profile of a qualified profile name of the namespace, nil when no PtpConfig of the
+// namespace holds it
+func findProfile(qualified, namespace string, ptpConfigs []PtpConfig) *PtpProfile {
+	for i := range ptpConfigs {
+		if ptpConfigs[i].Namespace != namespace {
+			continue
+		}
+		for j := range ptpConfigs[i].Spec.Profile {
+			profile := &ptpConfigs[i].Spec.Profile[j]
+			if profile.Name != nil && QualifyProfileName(ptpConfigs[i].Name, *profile.Name) == qualified {
+				return profile
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func makeProfileConfig(name string, profiles ...string) PtpConfig {
+	cfg := PtpConfig{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openshift-ptp"}}
+	for _, profile := range profiles {
+		cfg.Spec.Profile = append(cfg.Spec.Profile, PtpProfile{Name: strPtr(profile)})
+	}
+	return cfg
+}
+
+func TestQualifyProfileName(t *testing.T) {
+	tests := []struct {
+		crName, profileName, expected string
+	}{
+		{"tbc", "maestro", "tbc_maestro"},
+		{"T-BC", "01-tbc-tr", "T-BC_01-tbc-tr"},
+		{"config-alpha", "my-profile", "config-alpha_my-profile"},
+	}
+	for _, tt := range tests {
+		result := QualifyProfileName(tt.crName, tt.profileName)
+		assert.Equal(t, tt.expected, result, "QualifyProfileName(%s, %s)", tt.crName, tt.profileName)
+	}
+}
+
+func TestNodeProfileName(t *testing.T) {
+	assert.Equal(t, "tbc_maestro", NodeProfileName("openshift-ptp", "tbc_maestro"))
+	assert.Equal(t, "tbc_maestro", NodeProfileName("", "tbc_maestro"))
+	assert.Equal(t, "tenant-a/tbc_maestro", NodeProfileName("tenant-a", "tbc_maestro"))
+}
+
+func TestFindProfile(t *testing.T) {
+	configs := []PtpConfig{
+		makeProfileConfig("alpha", "maestro"),
+		makeProfileConfig("beta", "maestro"),
+		makeProfileConfig("empty"),
+	}
+
+	assert.NotNil(t, findProfile("alpha_maestro", "openshift-ptp", configs), "profile exists in alpha")
+	assert.NotNil(t, findProfile("beta_maestro", "openshift-ptp", configs), "profile exists in beta")
+	assert.Nil(t, findProfile("alpha_nonexistent", "openshift-ptp", configs), "profile does not exist")
+	assert.Nil(t, findProfile("gamma_maestro", "openshift-ptp", configs), "CR does not exist")
+	assert.Nil(t, findProfile("empty_anything", "openshift-ptp", configs), "CR has no profile")
+	assert.Nil(t, findProfile("alpha_maestro", "tenant-a", configs), "CR of another namespace")
+}
+
+func TestResolveProfileReference(t *testing.T) {
+	configs := []PtpConfig{
+		makeProfileConfig("alpha", "maestro"),
+		makeProfileConfig("tbc", "tbc_tr_profile"),
+	}
+	tests := []struct {
+		reference string
+		expected  string
+		found     bool
+	}{
+		{reference: "alpha_maestro", expected: "alpha_maestro", found: true},
+		{reference: "maestro", expected: "alpha_maestro", found: true},
+		{reference: "tbc_tr_profile", expected: "tbc_tbc_tr_profile", found: true},
+		{reference: "nonexistent", expected: "nonexistent"},
+	}
+	for _, tt := range tests {
+		qualified, found := ResolveProfileReference(tt.reference, "openshift-ptp", configs)
+		assert.Equal(t, tt.expected, qualified, tt.reference)
+		assert.Equal(t, tt.found, found, tt.reference)
+	}
+}
+
+func TestResolveProfileReference_Namespaces(t *testing.T) {
+	tenantA := makeProfileConfig("tbc", "tr")
+	tenantA.Namespace = "tenant-a"
+	tenantB := makeProfileConfig("tbc", "tr", "tt")
+	tenantB.Namespace = "tenant-b"
+	configs := []PtpConfig{tenantA, tenantB}
+
+	qualified, found := ResolveProfileReference("tr", "tenant-a", configs)
+	assert.True(t, found)
+	assert.Equal(t, "tbc_tr", qualified)
+	assert.Same(t, &configs[0].Spec.Profile[0], findProfile(qualified, "tenant-a", configs),
+		"the reference resolves to the profile of the same namespace")
+
+	_, found = ResolveProfileReference("tt", "tenant-a", configs)
+	assert.False(t, found, "the profiles of another namespace must not be resolved")
+	_, found = ResolveProfileReference("tbc_tt", "tenant-a", configs)
+	assert.False(t, found, "qualified references to the profiles of another namespace must not be resolved")
+	_, found = ResolveProfileReference("tr", "openshift-ptp", configs)
+	assert.False(t, found)
+
+	assert.NotEqual(t, NodeProfileName("tenant-a", "tbc_tr"), NodeProfileName("tenant-b", "tbc_tr"),
+		"same-named PtpConfigs of different namespaces deliver different profile names")
+}
@@ -0,0 +1,226 @@
+package v1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
+)
+
+// Secret annotations of the operator-managed key rotation. The rotation interval enables the rotation of the Secret
+// keys, the active key ID records the key the operator renders into the profiles in place of their activeKeyID.
+const (
+	KeyRotationIntervalAnnotation = "ptp.openshift.io/key-rotation-interval"
+	ActiveKeyIDAnnotation         = "ptp.openshift.io/active-key-id"
+)
+
+// authOptions are the ptp4l options rendered from the authentication block
+var authOptions = []string{"sa_file", "spp", "active_key_id"}
+
+// SaFilePath returns the path of the security association file in the linuxptp-daemon container
+func (a *PtpAuthentication) SaFilePath() string {
+	return PTP_SEC_FOLDER + a.SecretRef.Name + "/" + a.Key
+}
+
+// withManagedActiveKey returns the profile with the active key of its Secret when the operator rotates the keys of
+// the Secret, the activeKeyID of the spec is then replaced by the operator and may no longer be in the Secret
+func withManagedActiveKey(profile *PtpProfile, namespace string, secret *corev1.Secret) *PtpProfile {
+	if profile.Authentication == nil || namespace != names.Namespace {
+		return profile
+	}
+	if _, rotated := secret.Annotations[KeyRotationIntervalAnnotation]; !rotated {
+		return profile
+	}
+	keyID, err := strconv.ParseInt(secret.Annotations[ActiveKeyIDAnnotation], 10, 64)
+	if err != nil {
+		return profile
+	}
+	managed := profile.DeepCopy()
+	managed.Authentication.ActiveKeyID = keyID
+	for i := range managed.Authentication.Ports {
+		managed.Authentication.Ports[i].ActiveKeyID = nil
+	}
+	return managed
+}
+
+// portSettings returns the spp and active key ID of a port, applying the per port overrides
+func (a *PtpAuthentication) portSettings(iface string) (int64, int64) {
+	spp, keyID := a.SPP, a.ActiveKeyID
+	for _, port := range a.Ports {
+		if port.Interface != iface {
+			continue
+		}
+		if port.SPP != nil {
+			spp = *port.SPP
+		}
+		if port.ActiveKeyID != nil {
+			keyID = *port.ActiveKeyID
+		}
+	}
+	return spp, keyID
+}
+
+// validate checks the authentication block is complete and its ports exist in the profile
+func (a *PtpAuthentication) validate(profile *PtpProfile) error {
+	if a.SecretRef.Name == "" {
+		return fmt.Errorf("secretRef.name must be set")
+	}
+	if errs := validation.IsDNS1123Subdomain(a.SecretRef.Name); len(errs) > 0 {
+		return fmt.Errorf("secretRef.name '%s' is invalid: %s", a.SecretRef.Name, strings.Join(errs, ", "))
+	}
+	if a.Key == "" {
+		return fmt.Errorf("key must be set")
+	}
+	if errs := validation.IsConfigMapKey(a.Key); len(errs) > 0 {
+		return fmt.Errorf("key '%s' is invalid: %s", a.Key, strings.Join(errs, ", "))
+	}
+	if err := validateAuthSettings("spp", a.SPP, a.ActiveKeyID); err != nil {
+		return err
+	}
+	switch a.Algorithm {
+	case "", "AES128", "AES256", "SHA256-128", "SHA256":
+	default:
+		return fmt.Errorf("algorithm '%s' is not supported", a.Algorithm)
+	}
+
+	ports := profilePorts(profile)
+	seen := make(map[string]bool)
+	for _, port := range a.Ports {
+		if seen[port.Interface] {
+			return fmt.Errorf("port %s is configured more than once", port.Interface)
+		}
+		seen[port.Interface] = true
+		if !ports[port.Interface] {
+			return fmt.Errorf("port %s is not an interface of the profile", port.Interface)
+		}
+		spp, keyID := a.portSettings(port.Interface)
+		if err := validateAuthSettings(fmt.Sprintf("port %s spp", port.Interface), spp, keyID); err != nil {
+			return err
+		}
+	}
+
+	conf := &Ptp4lConf{}
+	conf.PopulatePtp4lConf(profile.Ptp4lConf, profile.Ptp4lOpts)
+	for sectionName, section := range conf.sections {
+		for _, option := range authOptions {
+			if _, exists := section.options[option]; exists {
+				return fmt.Errorf("%s must not be set in ptp4lConf section %s when authentication is configured", option, sectionName)
+			}
+		}
+	}
+	return nil
+}
+
+func validateAuthSettings(sppName string, spp, keyID int64) error {
+	if spp < 0 || spp > 255 {
+		return fmt.Errorf("%s must be between 0 and 255, got %d", sppName, spp)
+	}
+	if keyID < 1 || keyID > 4294967295 {
+		return fmt.Errorf("activeKeyID must be between 1 and 4294967295, got %d", keyID)
+	}
+	return nil
+}
+
+// profilePorts returns the interfaces of the profile: the ptp4lConf interface sections and the profile interface
+func profilePorts(profile *PtpProfile) map[string]bool {
+	ports := make(map[string]bool)
+	if profile.Interface != nil && *profile.Interface != "" {
+		ports[*profile.Interface] = true
+	}
+	if profile.Ptp4lConf == nil {
+		return ports
+	}
+	for _, line := range strings.Split(*profile.Ptp4lConf, "\n") {
+		if name, ok := parseSectionName(line); ok && isPortSection(name) {
+			ports[name] = true
+		}
+	}
+	return ports
+}
+
+// parseSectionName returns the name of a ptp4lConf section header line
+func parseSectionName(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "[") {
+		return "", false
+	}
+	end := strings.Index(line, "]")
+	if end < 0 {
+		return "", false
+	}
+	return strings.TrimSpace(line[1:end]), true
+}
+
+// isPortSection checks if a ptp4lConf section configures a port
+func isPortSection(name string) bool {
+	return name != "global" && name != "unicast_master_table"
+}
+
+// RenderPtp4lAuthentication returns the profile ptp4lConf with the authentication options rendered:
+// sa_file and spp -1 in [global] to keep the UDS sockets unauthenticated, and spp and active_key_id in
+// every port section. A section is added for the profile interface if ptp4lConf has none.
+func RenderPtp4lAuthentication(profile *PtpProfile) (string, error) {
+	var conf string
+	if profile.Ptp4lConf != nil {
+		conf = *profile.Ptp4lConf
+	}
+	auth := profile.Authentication
+	if auth == nil {
+		return conf, nil
+	}
+	if err := auth.validate(profile); err != nil {
+		return "", fmt.Errorf("invalid authentication: %w", err)
+	}
+
+	globalOptions := []string{"sa_file " + auth.SaFilePath(), "spp -1"}
+	portOptions := func(iface string) []string {
+		spp, keyID := auth.portSettings(iface)
+		return []string{fmt.Sprintf("spp %d", spp), fmt.Sprintf("active_key_id %d", keyID)}
+	}
+
+	var rendered []string
+	hasGlobal := false
+	sections := make(map[string]bool)
+	for _, line := range strings.Split(conf, "\n") {
+		rendered = append(rendered, line)
+		name, ok := parseSectionName(line)
+		if !ok {
+			continue
+		}
+		sections[name] = true
+		if name == "global" {
+			hasGlobal = true
+			rendered = append(rendered, globalOptions...)
+		} else if isPortSection(name) {
+			rendered = append(rendered, portOptions(name)...)
+		}
+	}
+	if !hasGlobal {
+		rendered = append(append([]string{"[global]"}, globalOptions...), rendered...)
+	}
+	if profile.Interface != nil && *profile.Interface != "" && !sections[*profile.Interface] {
+		rendered = append(rendered, "["+*profile.Interface+"]")
+		rendered = append(rendered, portOptions(*profile.Interface)...)
+	}
+	return strings.Join(rendered, "\n"), nil
+}
+
+// ProfileSaFilePath returns the security association file of the profile, either rendered from
+// the authentication block or set with sa_file in the [global] section of ptp4lConf
+func ProfileSaFilePath(profile *PtpProfile) string {
+	if profile.Authentication != nil {
+		return profile.Authentication.SaFilePath()
+	}
+	if profile.Ptp4lConf == nil {
+		return ""
+	}
+	conf := &Ptp4lConf{}
+	if err := conf.PopulatePtp4lConf(profile.Ptp4lConf, profile.Ptp4lOpts); err != nil {
+		return ""
+	}
+	return conf.GetOption("[global]", "sa_file")
+}
@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func strPtr(s string) *string { return &s }
+func int64Ptr(i int64) *int64 { return &i }
+func intPtr(i int) *int       { return &i }
+
+func makeAuthProfile(ptp4lConf string, auth *PtpAuthentication) *PtpProfile {
+	return &PtpProfile{Name: strPtr("tbc"), Ptp4lConf: strPtr(ptp4lConf), Authentication: auth}
+}
+
+func makeAuthentication() *PtpAuthentication {
+	return &PtpAuthentication{
+		SecretRef:   corev1.LocalObjectReference{Name: "ptp-security"},
+		Key:         "sa_file.conf",
+		SPP:         1,
+		ActiveKeyID: 2,
+	}
+}
+
+func TestRenderPtp4lAuthentication(t *testing.T) {
+	auth := makeAuthentication()
+	auth.Ports = []PtpPortAuthentication{{Interface: "ens2f0", SPP: int64Ptr(3)}}
+	profile := makeAuthProfile("[ens1f0]\nmasterOnly 0\n[ens2f0]\nmasterOnly 1\n[global]\ndomainNumber 24", auth)
+
+	rendered, err := RenderPtp4lAuthentication(profile)
+	assert.NoError(t, err)
+	assert.Equal(t, "[ens1f0]\nspp 1\nactive_key_id 2\nmasterOnly 0\n"+
+		"[ens2f0]\nspp 3\nactive_key_id 2\nmasterOnly 1\n"+
+		"[global]\nsa_file /etc/ptp-secret-mount/ptp-security/sa_file.conf\nspp -1\ndomainNumber 24", rendered)
+
+	// the rendered configuration passes the manual configuration checks
+	conf := &Ptp4lConf{}
+	assert.NoError(t, conf.PopulatePtp4lConf(&rendered, nil))
+	assert.NoError(t, validateSppPerSection(conf))
+	assert.Equal(t, "/etc/ptp-secret-mount/ptp-security/sa_file.conf", ProfileSaFilePath(profile))
+}
+
+func TestRenderPtp4lAuthentication_OrdinaryClock(t *testing.T) {
+	profile := makeAuthProfile("[global]\nslaveOnly 1\n[unicast_master_table]\ntable_id 1", makeAuthentication())
+	profile.Interface = strPtr("ens1f0")
+
+	rendered, err := RenderPtp4lAuthentication(profile)
+	assert.NoError(t, err)
+	assert.Equal(t, "[global]\nsa_file /etc/ptp-secret-mount/ptp-security/sa_file.conf\nspp -1\nslaveOnly 1\n"+
+		"[unicast_master_table]\ntable_id 1\n"+
+		"[ens1f0]\nspp 1\nactive_key_id 2", rendered)
+}
+
+func TestRenderPtp4lAuthentication_NoGlobalSection(t *testing.T) {
+	rendered, err := RenderPtp4lAuthentication(makeAuthProfile("[ens1f0]", makeAuthentication()))
+	assert.NoError(t, err)
+	assert.Equal(t, "[global]\nsa_file /etc/ptp-secret-mount/ptp-security/sa_file.conf\nspp -1\n[ens1f0]\nspp 1\nactive_key_id 2", rendered)
+}
+
+func TestRenderPtp4lAuthentication_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		ptp4lConf string
+		modify    func(*PtpAuthentication)
+		errMsg    string
+	}{
+		{"missing secret", "[ens1f0]", func(a *PtpAuthentication) { a.SecretRef.Name = "" }, "secretRef.name must be set"},
+		{"invalid secret name", "[ens1f0]", func(a *PtpAuthentication) { a.SecretRef.Name = "PTP_Secret" }, "secretRef.name 'PTP_Secret' is invalid"},
+		{"missing key", "[ens1f0]", func(a *PtpAuthentication) { a.Key = "" }, "key must be set"},
+		{"invalid key", "[ens1f0]", func(a *PtpAuthentication) { a.Key = "sa/file" }, "key 'sa/file' is invalid"},
+		{"spp out of range", "[ens1f0]", func(a *PtpAuthentication) { a.SPP = 256 }, "spp must be between 0 and 255"},
+		{"missing active key", "[ens1f0]", func(a *PtpAuthentication) { a.ActiveKeyID = 0 }, "activeKeyID must be between"},
+		{"unknown algorithm", "[ens1f0]", func(a *PtpAuthentication) { a.Algorithm = "MD5" }, "algorithm 'MD5' is not supported"},
+		{"unknown port", "[ens1f0]", func(a *PtpAuthentication) {
+			a.Ports = []PtpPortAuthentication{{Interface: "ens9f0", SPP: int64Ptr(2)}}
+		}, "port ens9f0 is not an interface of the profile"},
+		{"duplicate port", "[ens1f0]", func(a *PtpAuthentication) {
+			a.Ports = []PtpPortAuthentication{{Interface: "ens1f0"}, {Interface: "ens1f0"}}
+		}, "port ens1f0 is configured more than once"},
+		{"port spp out of range", "[ens1f0]", func(a *PtpAuthentication) {
+			a.Ports = []PtpPortAuthentication{{Interface: "ens1f0", SPP: int64Ptr(-1)}}
+		}, "port ens1f0 spp must be between 0 and 255"},
+		{"sa_file in ptp4lConf", "[global]\nsa_file /etc/ptp-secret-mount/a/b\n[ens1f0]", func(a *PtpAuthentication) {}, "sa_file must not be set in ptp4lConf section [global]"},
+		{"spp in ptp4lConf", "[ens1f0]\nspp 1", func(a *PtpAuthentication) {}, "spp must not be set in ptp4lConf section [ens1f0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := makeAuthentication()
+			tt.modify(auth)
+			_, err := RenderPtp4lAuthentication(makeAuthProfile(tt.ptp4lConf, auth))
+			assert.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
+
+func TestValidateAuthentication(t *testing.T) {
+	cfg := &PtpConfig{Spec: PtpConfigSpec{Profile: []PtpProfile{
+		*makeAuthProfile("[global]\n[ens1f0]\nmasterOnly 0", makeAuthentication()),
+	}}}
+	// the secret can't be looked up without a webhook client
+	assert.ErrorContains(t, cfg.validate(), "has invalid secret name 'ptp-security'")
+
+	cfg.Spec.Profile[0].Authentication.SPP = 300
+	assert.ErrorContains(t, cfg.validate(), "invalid authentication: spp must be between 0 and 255")
+}
+
+func TestWithManagedActiveKey(t *testing.T) {
+	// the operator retired key 2 and activated key 3
+	rotatedSaFile := "[security_association]\nspp 1\n3 SHA256-128 HEX:000102030405060708090a0b0c0d0e0f\n"
+	rotated := &corev1.Secret{}
+	rotated.Annotations = map[string]string{KeyRotationIntervalAnnotation: "24h", ActiveKeyIDAnnotation: "3"}
+	auth := makeAuthentication()
+	auth.Ports = []PtpPortAuthentication{{Interface: "ens1f0", ActiveKeyID: int64Ptr(2)}}
+	profile := makeAuthProfile("[ens1f0]\nmasterOnly 0\n[global]\ndomainNumber 24", auth)
+
+	assert.ErrorContains(t, ValidateProfileSaFile(profile, rotatedSaFile), "active key ID 2 is not defined")
+
+	managed := withManagedActiveKey(profile, "openshift-ptp", rotated)
+	assert.Equal(t, int64(3), managed.Authentication.ActiveKeyID)
+	assert.Nil(t, managed.Authentication.Ports[0].ActiveKeyID)
+	assert.NoError(t, ValidateProfileSaFile(managed, rotatedSaFile))
+	assert.Equal(t, int64(2), profile.Authentication.ActiveKeyID, "the profile must not be modified")
+
+	// the keys are only rotated in the operator namespace, and for the annotated Secrets
+	assert.Same(t, profile, withManagedActiveKey(profile, "tenant", rotated))
+	assert.Same(t, profile, withManagedActiveKey(profile, "openshift-ptp", &corev1.Secret{}))
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -40,6 +41,12 @@ type PtpConfigStatus struct {
 	MatchList []NodeMatchList `json:"matchList,omitempty"`
 	// Conditions contains the conditions for the PtpConfig
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Authentication reports the active security association key of each node and profile
+	// +optional
+	Authentication []NodeAuthenticationStatus `json:"authentication,omitempty"`
+	// HighAvailability reports the sources and the active source of each node and high availability profile
+	// +optional
+	HighAvailability []NodeHighAvailabilityStatus `json:"highAvailability,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -84,6 +91,182 @@ type PtpProfile struct {
 	PtpClockThreshold     *PtpClockThreshold             `json:"ptpClockThreshold,omitempty"`
 	PtpSettings           map[string]string              `json:"ptpSettings,omitempty"`
 	Plugins               map[string]*apiextensions.JSON `json:"plugins,omitempty"`
+	// Settings are the typed ptpSettings of the profile, rendered into ptpSettings by the operator.
+	// A setting must not be set both here and in ptpSettings.
+	// +optional
+	Settings *PtpProfileSettings `json:"settings,omitempty"`
+	// HighAvailability makes phc2sys synchronize the system clock to one of the source profiles, selected by their
+	// order and selection criteria. The source profiles are rendered into the haProfiles setting, which must not be
+	// set with it.
+	// +optional
+	HighAvailability *PhcHighAvailability `json:"highAvailability,omitempty"`
+	// Authentication enables IEEE 1588 Annex P authentication (AUTHENTICATION TLV) on the profile ports.
+	// The operator renders sa_file, spp and active_key_id into ptp4lConf and mounts the secret,
+	// so these options must not be set in ptp4lConf.
+	// +optional
+	Authentication *PtpAuthentication `json:"authentication,omitempty"`
+}
+
+// PtpAuthentication configures the security association used to authenticate PTP messages
+type PtpAuthentication struct {
+	// SecretRef is the Secret in the namespace of the PtpConfig holding the security association file
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+	// Key is the Secret key holding the security association file (sa_file) contents
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+	// SPP is the security parameter pointer used by all the profile ports, unless overridden per port
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	SPP int64 `json:"spp"`
+	// ActiveKeyID is the key ID used to generate the integrity check value of transmitted messages.
+	// It is ignored, as are the per port active key IDs, when the Secret is annotated for key rotation
+	// with ptp.openshift.io/key-rotation-interval: the operator then generates the keys and switches the active key.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4294967295
+	ActiveKeyID int64 `json:"activeKeyID"`
+	// Algorithm is the MAC algorithm of the active key in the security association file
+	// +kubebuilder:validation:Enum=AES128;AES256;SHA256-128;SHA256
+	// +optional
+	Algorithm string `json:"algorithm,omitempty"`
+	// Ports overrides the security parameter pointer and active key ID of individual ports
+	// +optional
+	// +listType=map
+	// +listMapKey=interface
+	Ports []PtpPortAuthentication `json:"ports,omitempty"`
+}
+
+// PtpPortAuthentication overrides the authentication settings of a single port
+type PtpPortAuthentication struct {
+	// Interface is the port interface name, which must be a section of ptp4lConf
+	Interface string `json:"interface"`
+	// SPP is the security parameter pointer of the port
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	// +optional
+	SPP *int64 `json:"spp,omitempty"`
+	// ActiveKeyID is the active key ID of the port
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4294967295
+	// +optional
+	ActiveKeyID *int64 `json:"activeKeyID,omitempty"`
+}
+
+// PtpPortState is a PTP port state of a source profile, named after IEEE 1588g
+type PtpPortState string
+
+const (
+	// PtpPortStateTimeReceiver is the SLAVE port state of linuxptp
+	PtpPortStateTimeReceiver PtpPortState = "TimeReceiver"
+	// PtpPortStateTimeTransmitter is the MASTER port state of linuxptp
+	PtpPortStateTimeTransmitter PtpPortState = "TimeTransmitter"
+	PtpPortStatePassive         PtpPortState = "Passive"
+	PtpPortStateUncalibrated    PtpPortState = "Uncalibrated"
+)
+
+// ptpPortStates are the port states of the high availability selection criteria
+var ptpPortStates = []PtpPortState{PtpPortStateTimeReceiver, PtpPortStateTimeTransmitter, PtpPortStatePassive,
+	PtpPortStateUncalibrated}
+
+// PhcHighAvailability selects the source profile phc2sys synchronizes the system clock to
+type PhcHighAvailability struct {
+	// Sources are the source profiles, in order of preference. phc2sys synchronizes the system clock to the first
+	// source meeting its selection criteria. The source profiles must run on the nodes of the profile.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=profile
+	Sources []PhcHighAvailabilitySource `json:"sources"`
+	// Hysteresis is the time a source must meet, or fail, its selection criteria before phc2sys switches over to,
+	// or away from, it
+	// +optional
+	Hysteresis *metav1.Duration `json:"hysteresis,omitempty"`
+}
+
+// PhcHighAvailabilitySource is a source profile and its selection criteria
+type PhcHighAvailabilitySource struct {
+	// Profile is the source profile, as a profile name or a <PtpConfig name>_<profile name> qualified name.
+	// It must name an existing profile.
+	// +kubebuilder:validation:MinLength=1
+	Profile string `json:"profile"`
+	// MaxClockClass is the highest clock class of the source meeting the selection criteria
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	// +optional
+	MaxClockClass *int64 `json:"maxClockClass,omitempty"`
+	// MaxOffset is the highest absolute offset in nanoseconds of the source meeting the selection criteria
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxOffset *int64 `json:"maxOffset,omitempty"`
+	// PortStates are the port states of the source meeting the selection criteria, TimeReceiver when not set
+	// +kubebuilder:validation:items:Enum=TimeReceiver;TimeTransmitter;Passive;Uncalibrated
+	// +listType=set
+	// +optional
+	PortStates []PtpPortState `json:"portStates,omitempty"`
+}
+
+// LogReduceMode selects how the linuxptp daemon reduces the logs of the linuxptp processes
+type LogReduceMode string
+
+const (
+	// LogReduceOff logs every line of the linuxptp processes
+	LogReduceOff LogReduceMode = "Off"
+	// LogReduceBasic drops the offset lines of the linuxptp processes
+	LogReduceBasic LogReduceMode = "Basic"
+	// LogReduceEnhanced summarizes the offset lines of the linuxptp processes once per interval, and logs the lines
+	// whose offset exceeds the threshold
+	LogReduceEnhanced LogReduceMode = "Enhanced"
+)
+
+// PtpProfileSettings are the typed settings of a profile
+type PtpProfileSettings struct {
+	// ClockType is the type of clock configured by the profile
+	// +kubebuilder:validation:Enum=T-GM;T-BC
+	// +optional
+	ClockType string `json:"clockType,omitempty"`
+	// ControllingProfile is the profile controlling this T-BC profile, as a profile name or a
+	// <PtpConfig name>_<profile name> qualified name. It must name an existing profile.
+	// +optional
+	ControllingProfile string `json:"controllingProfile,omitempty"`
+	// HAProfiles are the profiles phc2sys selects its time source from, as profile names or qualified names.
+	// They must name existing profiles.
+	// +listType=set
+	// +optional
+	HAProfiles []string `json:"haProfiles,omitempty"`
+	// UpstreamPort is the port receiving time from the upstream clock. It must be an interface of the profile.
+	// +optional
+	UpstreamPort string `json:"upstreamPort,omitempty"`
+	// LeadingInterface is the interface leading the other interfaces of the profile. It must be an interface of
+	// the profile.
+	// +optional
+	LeadingInterface string `json:"leadingInterface,omitempty"`
+	// InSyncConditionThreshold is the offset in nanoseconds below which the clock is counted as in sync
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InSyncConditionThreshold *int64 `json:"inSyncConditionThreshold,omitempty"`
+	// InSyncConditionTimes is the number of consecutive offsets below the threshold for the clock to be in sync
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InSyncConditionTimes *int64 `json:"inSyncConditionTimes,omitempty"`
+	// LogReduce reduces the logs of the linuxptp processes
+	// +optional
+	LogReduce *PtpLogReduce `json:"logReduce,omitempty"`
+	// StdoutFilters are regular expressions of the linuxptp process output lines dropped from the daemon logs
+	// +optional
+	StdoutFilters []string `json:"stdoutFilters,omitempty"`
+}
+
+// PtpLogReduce configures the log reduction of the linuxptp processes
+type PtpLogReduce struct {
+	// Mode is the log reduction mode
+	// +kubebuilder:validation:Enum=Off;Basic;Enhanced
+	Mode LogReduceMode `json:"mode"`
+	// Interval is the interval of the offset summaries of the Enhanced mode
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Threshold is the offset in nanoseconds above which the Enhanced mode logs the offset lines. It requires the
+	// interval.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Threshold *int64 `json:"threshold,omitempty"`
 }
 
 type PtpClockThreshold struct {
@@ -156,6 +339,33 @@ type NodeMatchList struct {
 	Profile  *string `json:"profile"`
 }
 
+// NodeAuthenticationStatus reports the security association key used by a profile on a node
+type NodeAuthenticationStatus struct {
+	NodeName    string `json:"nodeName"`
+	Profile     string `json:"profile"`
+	SecretName  string `json:"secretName"`
+	ActiveKeyID int64  `json:"activeKeyID"`
+}
+
+// NodeHighAvailabilityStatus reports the sources of a high availability profile on a node
+type NodeHighAvailabilityStatus struct {
+	NodeName string `json:"nodeName"`
+	// Profile is the qualified name of the high availability profile
+	Profile string `json:"profile"`
+	// Sources are the qualified names of the source profiles, in order of preference
+	Sources []string `json:"sources"`
+	// MissingSources are the source profiles not recommended to the node
+	// +optional
+	MissingSources []string `json:"missingSources,omitempty"`
+	// ActiveSource is the source profile phc2sys synchronizes the system clock to, as reported by the linuxptp
+	// daemon in the NodePtpDevice status of the node. It is empty when no source is selected or not reported.
+	// +optional
+	ActiveSource string `json:"activeSource,omitempty"`
+	// LastSwitchover is the time phc2sys switched over to the active source
+	// +optional
+	LastSwitchover *metav1.Time `json:"lastSwitchover,omitempty"`
+}
+
 func init() {
 	SchemeBuilder.Register(&PtpConfig{}, &PtpConfigList{})
 }
@@ -21,10 +21,8 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
-	"slices"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
@@ -51,17 +49,20 @@ var ptpconfiglog = logf.Log.WithName("ptpconfig-resource")
 var profileRegEx = regexp.MustCompile(`^([\w\-_]+)(,\s*([\w\-_]+))*$`)
 var clockTypes = []string{"T-GM", "T-BC"}
 
-// webhookClient is used by the webhook to query existing PtpConfigs
-var webhookClient client.Client
+// webhookClient is used by the webhooks to read the secrets referenced by PtpConfigs and the PtpConfigs holding
+// referenced profiles. It reads from the API server, the cache only holds the secrets of the operator namespace.
+var webhookClient client.Reader
 
 func (r *PtpConfig) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	// Store the client for use in validation
-	webhookClient = mgr.GetClient()
+	// Store the client for use in defaulting and validation
+	webhookClient = mgr.GetAPIReader()
 	return ctrl.NewWebhookManagedBy(mgr, r).
+		WithCustomDefaulter(&ptpConfigDefaulter{}).
 		WithCustomValidator(&ptpConfigValidator{}).
 		Complete()
 }
 
+//+kubebuilder:webhook:path=/mutate-ptp-openshift-io-v1-ptpconfig,mutating=true,failurePolicy=fail,sideEffects=None,groups=ptp.openshift.io,resources=ptpconfigs,verbs=create;update,versions=v1,name=mptpconfig.kb.io,admissionReviewVersions=v1
 //+kubebuilder:webhook:path=/validate-ptp-openshift-io-v1-ptpconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=ptp.openshift.io,resources=ptpconfigs,verbs=create;update,versions=v1,name=vptpconfig.kb.io,admissionReviewVersions=v1
 
 type Ptp4lConfSection struct {
@@ -125,10 +126,32 @@ func (output *Ptp4lConf) PopulatePtp4lConf(config *string, ptp4lopts *string) er
 func (r *PtpConfig) validate() error {
 	profiles := r.Spec.Profile
 
+	// plugins referenced by the profiles must be enabled cluster-wide
+	var enabledPlugins []string
+	for _, profile := range profiles {
+		if len(profile.Plugins) > 0 {
+			enabledPlugins = getEnabledPlugins()
+			break
+		}
+	}
+
+	// profile references must resolve to the profiles of the PtpConfig or of the stored PtpConfigs
+	ptpConfigs, checkReferences := r.getReferencedPtpConfigs()
+
 	for _, profile := range profiles {
 		conf := &Ptp4lConf{}
 		conf.PopulatePtp4lConf(profile.Ptp4lConf, profile.Ptp4lOpts)
 
+		// Validate the authentication block and the ptp4lConf rendered from it
+		if profile.Authentication != nil {
+			renderedConf, err := RenderPtp4lAuthentication(&profile)
+			if err != nil {
+				return err
+			}
+			conf = &Ptp4lConf{}
+			conf.PopulatePtp4lConf(&renderedConf, profile.Ptp4lOpts)
+		}
+
 		// Validate that interface field only set in ordinary clock
 		if profile.Interface != nil && *profile.Interface != "" {
 			for section := range conf.sections {
@@ -147,75 +170,31 @@ func (r *PtpConfig) validate() error {
 			return fmt.Errorf("failed to validate spp settings per section: %w", err)
 		}
 
+		if err := validateProfilePlugins(&profile, enabledPlugins); err != nil {
+			return err
+		}
+
 		if profile.PtpSchedulingPolicy != nil && *profile.PtpSchedulingPolicy == "SCHED_FIFO" {
 			if profile.PtpSchedulingPriority == nil {
 				return errors.New("PtpSchedulingPriority must be set for SCHED_FIFO PtpSchedulingPolicy")
 			}
 		}
 
-		if profile.PtpSettings != nil {
-			for k, v := range profile.PtpSettings {
-				switch {
-				case k == "stdoutFilter":
-					_, err := regexp.Compile(v)
-					if err != nil {
-						return errors.New("stdoutFilter='" + v + "' is invalid; " + err.Error())
-					}
-				case k == "logReduce":
-					logReduceMode := "false"
-					logReduceSettings := strings.Fields(v)
-					if len(logReduceSettings) >= 1 {
-						logReduceMode = strings.ToLower(logReduceSettings[0])
-					}
-					if logReduceMode != "true" && logReduceMode != "false" && logReduceMode != "basic" && logReduceMode != "enhanced" {
-						return errors.New("logReduce mode '" + logReduceMode + "' is invalid; mode must be in 'true', 'false, 'basic', or 'enhanced'")
-					}
-					if logReduceMode == "enhanced" {
-						if len(logReduceSettings) >= 2 {
-							if _, err := time.ParseDuration(logReduceSettings[1]); err != nil {
-								return errors.New("logReduce time " + logReduceSettings[1] + "' is invalid; must be a valid time duration (e.g. '30s')")
-							}
-						}
-						if len(logReduceSettings) >= 3 {
-							if threshold, err := strconv.Atoi(logReduceSettings[2]); err != nil || threshold < 0 {
-								return errors.New("logReduce threshold " + logReduceSettings[2] + "' is invalid; must be a non-negative integer")
-							}
-						}
-					}
-				case k == "haProfiles":
-					if !profileRegEx.MatchString(v) {
-						return errors.New("haProfiles='" + v + "' is invalid; must be comma seperated profile names")
-					}
-				case k == "clockType":
-					if !slices.Contains(clockTypes, v) {
-						return errors.New("clockType='" + v + "' is invalid; must be one of ['" + strings.Join(clockTypes, "', '") + "']")
-					}
-				case k == "inSyncConditionTimes":
-					// Validate inSyncConditionTimes is an unsigned integer
-					if _, err := strconv.ParseUint(v, 10, 32); err != nil {
-						return errors.New("inSyncConditionTimes='" + v + "' is invalid; must be an unsigned integer")
-					}
-				case k == "inSyncConditionThreshold":
-					// Validate inSyncConditionThreshold is an unsigned integer
-					if _, err := strconv.ParseUint(v, 10, 32); err != nil {
-						return errors.New("inSyncConditionThreshold='" + v + "' is invalid; must be an unsigned integer")
-					}
+		if err := r.validateHighAvailability(&profile, ptpConfigs); err != nil {
+			return err
+		}
 
-				case strings.Contains(k, "clockId"):
-					// Allow explicit clockId
-					if _, err := strconv.ParseUint(v, 10, 64); err != nil {
-						if _, err := strconv.ParseUint(v, 16, 64); err != nil {
-							return errors.New("clockId='" + v + "' is invalid; must be an unsigned integer")
-						}
-					}
-				case k == "controllingProfile":
-					// Allow controllingProfile setting - no specific validation required for string
-				case k == "upstreamPort":
-					// Temporary allow upstreamPort setting - no specific validation required for string
-				case k == "leadingInterface":
-					// Temporary allow leadingInterface setting - no specific validation required for string
-				default:
-					return errors.New("profile.PtpSettings '" + k + "' is not a configurable setting")
+		settings, err := RenderPtpSettings(&profile)
+		if err != nil {
+			return err
+		}
+		if err := validatePtpSettings(settings, profileInterfaces(&profile, conf)); err != nil {
+			return err
+		}
+		if checkReferences {
+			for _, reference := range profileReferences(settings) {
+				if _, ok := ResolveProfileReference(reference, r.Namespace, ptpConfigs); !ok {
+					return errors.New("profile '" + reference + "' referenced in ptpSettings is not a profile of any PtpConfig")
 				}
 			}
 		}
@@ -231,7 +210,7 @@ func (r *PtpConfig) validate() error {
 			continue
 		}
 
-		if err := validateSaFile(saFilePath); err != nil {
+		if err := validateSaFile(r.Namespace, saFilePath); err != nil {
 			return fmt.Errorf("failed to validate sa file: %w", err)
 		}
 
@@ -239,7 +218,7 @@ func (r *PtpConfig) validate() error {
 		// [global] has spp -1 which disables authentication globally in UDS sockets and other interfaces.
 		secretName := GetSecretNameFromSaFilePath(saFilePath)
 		secretKey := GetSecretKeyFromSaFilePath(saFilePath)
-		if err := validateInterfaceSppInSecret(conf, secretName, secretKey); err != nil {
+		if err := validateInterfaceSppInSecret(&profile, conf, r.Namespace, secretName, secretKey); err != nil {
 			return fmt.Errorf("failed to validate interface spp in secret: %w", err)
 		}
 
@@ -247,15 +226,15 @@ func (r *PtpConfig) validate() error {
 	return nil
 }
 
-// checking if the secret exists in the openshift-ptp namespace
-func getSecret(secretName string) *corev1.Secret {
+// checking if the secret exists in the namespace of the PtpConfig
+func getSecret(namespace, secretName string) *corev1.Secret {
 	if webhookClient == nil {
 		ptpconfiglog.Info("webhook client not initialized, skipping secret existence validation")
 		return nil
 	}
 	secret := &corev1.Secret{}
 	err := webhookClient.Get(context.Background(), types.NamespacedName{
-		Namespace: "openshift-ptp",
+		Namespace: namespace,
 		Name:      secretName,
 	}, secret)
 	if err != nil {
@@ -264,6 +243,21 @@ func getSecret(secretName string) *corev1.Secret {
 	return secret
 }
 
+// getReferencedPtpConfigs returns the PtpConfigs the profile references are resolved in: the PtpConfig and the
+// stored PtpConfigs of its namespace. It returns false when the stored PtpConfigs cannot be listed, the references are then not
+// validated.
+func (r *PtpConfig) getReferencedPtpConfigs() ([]PtpConfig, bool) {
+	if webhookClient == nil {
+		return withPtpConfig(r, nil), true
+	}
+	ptpConfigList := &PtpConfigList{}
+	if err := webhookClient.List(context.Background(), ptpConfigList, client.InNamespace(r.Namespace)); err != nil {
+		ptpconfiglog.Info("failed to list PtpConfigs, profile references not validated", "error", err.Error())
+		return nil, false
+	}
+	return withPtpConfig(r, ptpConfigList.Items), true
+}
+
 // GetSecretNameFromSaFilePath extracts the secret name from the sa_file path
 func GetSecretNameFromSaFilePath(sa_file string) string {
 	path := strings.TrimPrefix(sa_file, PTP_SEC_FOLDER)
@@ -321,8 +315,8 @@ func getSaFileFromPtp4lConf(conf *Ptp4lConf) (string, error) {
 
 // validateSaFile checks that the sa_file path is valid with prefix PTP_SEC_FOLDER
 // next directory must be a valid secret name, e.g. PTP_SEC_FOLDER/secret_name/secret_key
-// check that secret_key exists in the secret_name secret
-func validateSaFile(saFilePath string) error {
+// check that secret_key exists in the secret_name secret of the PtpConfig namespace
+func validateSaFile(namespace, saFilePath string) error {
 	if !strings.HasPrefix(saFilePath, PTP_SEC_FOLDER) {
 		return fmt.Errorf("sa_file path '%s' is invalid; must start with '%s'", saFilePath, PTP_SEC_FOLDER)
 	}
@@ -335,9 +329,9 @@ func validateSaFile(saFilePath string) error {
 	if secretName == "" {
 		return fmt.Errorf("sa_file path '%s' is invalid; must contain a secret name", saFilePath)
 	}
-	secret := getSecret(secretName)
+	secret := getSecret(namespace, secretName)
 	if secret == nil {
-		return fmt.Errorf("sa_file path '%s' has invalid secret name '%s'", saFilePath, secretName)
+		return fmt.Errorf("sa_file path '%s' has invalid secret name '%s' in namespace '%s'", saFilePath, secretName, namespace)
 	}
 	keyCandidate := path[index+1:]
 	return validateKeyInSecret(secret, keyCandidate)
@@ -351,50 +345,13 @@ func validateKeyInSecret(secret *corev1.Secret, keyCandidate string) error {
 	return nil
 }
 
-// validateSppInSecret checks that the spp value exists in a specific key of the secret
-func validateSppInSecretKey(sppValue string, secretName string, secretKey string) error {
-	secret := getSecret(secretName)
-	value, exists := secret.Data[secretKey]
-	if !exists {
-		return fmt.Errorf("key '%s' not found in secret '%s'", secretKey, secret.Name)
-	}
-
-	content := string(value)
-	lines := strings.Split(content, "\n")
-
-	// Look for lines starting with "spp <number>"
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-
-		// Skip empty lines and comments
-		if line == "" || strings.HasPrefix(line, "#") {
-			continue
-		}
-
-		// Check if line starts with "spp "
-		if strings.HasPrefix(strings.ToLower(line), "spp ") {
-			parts := strings.Fields(line)
-			if len(parts) >= 2 {
-				secretSppValue := parts[1]
-
-				// Check if this matches the PtpConfig's spp
-				if secretSppValue == sppValue {
-					ptpconfiglog.Info("validated spp match", "spp", sppValue, "secret", secret.Name, "key", secretKey)
-					return nil // Validation passed ✅
-				}
-			}
-		}
-	}
-
-	return fmt.Errorf("spp '%s' not found in key '%s' of secret '%s'", sppValue, secretKey, secret.Name)
-}
-
-// validateInterfaceSppInSecret validates that spp values in interface sections (not global) exist in the secret
+// validateInterfaceSppInSecret validates that every interface section (not global) has spp configured, and that the
+// secret key holds a valid security association file defining the spp and active key ID of each interface.
 // [global] section has spp -1 which doesn't need validation against the secret
-func validateInterfaceSppInSecret(conf *Ptp4lConf, secretName string, secretKey string) error {
+func validateInterfaceSppInSecret(profile *PtpProfile, conf *Ptp4lConf, namespace, secretName string, secretKey string) error {
 	for sectionName, section := range conf.sections {
 		// Skip global section - it has spp -1 which is not in the secret
-		if sectionName == "[global]" {
+		if name, _ := parseSectionName(sectionName); !isPortSection(name) {
 			continue
 		}
 
@@ -403,12 +360,39 @@ func validateInterfaceSppInSecret(conf *Ptp4lConf, secretName string, secretKey
 		if !hasSpp || sppValue == "" {
 			return fmt.Errorf("interface section %s must have spp configured when auth is enabled)", sectionName)
 		}
+	}
 
-		// Validate that this spp exists in the secret
-		if err := validateSppInSecretKey(sppValue, secretName, secretKey); err != nil {
-			return fmt.Errorf("interface %s: %w", sectionName, err)
+	secret := getSecret(namespace, secretName)
+	if secret == nil {
+		return fmt.Errorf("secret '%s' not found in namespace '%s'", secretName, namespace)
+	}
+	value, exists := secret.Data[secretKey]
+	if !exists {
+		return fmt.Errorf("key '%s' not found in secret '%s'", secretKey, secretName)
+	}
+	if err := ValidateProfileSaFile(withManagedActiveKey(profile, namespace, secret), string(value)); err != nil {
+		return fmt.Errorf("invalid security association file in key '%s' of secret '%s': %w", secretKey, secretName, err)
+	}
+	ptpconfiglog.Info("validated security association file", "secret", secretName, "key", secretKey)
+	return nil
+}
+
+type ptpConfigDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &ptpConfigDefaulter{}
+
+func (d *ptpConfigDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	r := obj.(*PtpConfig)
+	ptpconfiglog.Info("default", "name", r.Name)
+	var ptpConfigs []PtpConfig
+	if webhookClient != nil {
+		ptpConfigList := &PtpConfigList{}
+		if err := webhookClient.List(ctx, ptpConfigList, client.InNamespace(r.Namespace)); err != nil {
+			ptpconfiglog.Info("failed to list PtpConfigs, only profile references to the PtpConfig are qualified", "error", err.Error())
 		}
+		ptpConfigs = ptpConfigList.Items
 	}
+	r.SetDefaults(ptpConfigs)
 	return nil
 }
 
@@ -419,21 +403,23 @@ var _ webhook.CustomValidator = &ptpConfigValidator{}
 func (v *ptpConfigValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	r := obj.(*PtpConfig)
 	ptpconfiglog.Info("validate create", "name", r.Name)
+	warnings := admission.Warnings(r.Deprecations())
 	if err := r.validate(); err != nil {
-		return admission.Warnings{}, err
+		return warnings, err
 	}
 
-	return admission.Warnings{}, nil
+	return warnings, nil
 }
 
 func (v *ptpConfigValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	r := newObj.(*PtpConfig)
 	ptpconfiglog.Info("validate update", "name", r.Name)
+	warnings := admission.Warnings(r.Deprecations())
 	if err := r.validate(); err != nil {
-		return admission.Warnings{}, err
+		return warnings, err
 	}
 
-	return admission.Warnings{}, nil
+	return warnings, nil
 }
 
 func (v *ptpConfigValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -445,7 +431,12 @@ func (v *ptpConfigValidator) ValidateDelete(ctx context.Context, obj runtime.Obj
 func getInterfaces(input *Ptp4lConf, mode PtpRole) (interfaces []string) {
 	for index, section := range input.sections {
 		sectionName := strings.TrimSpace(strings.ReplaceAll(strings.ReplaceAll(index, "[", ""), "]", ""))
-		if strings.TrimSpace(section.options["masterOnly"]) == strconv.Itoa(int(mode)) {
+		// serverOnly is the linuxptp name of masterOnly
+		serverOnly, ok := section.options["serverOnly"]
+		if !ok {
+			serverOnly = section.options["masterOnly"]
+		}
+		if strings.TrimSpace(serverOnly) == strconv.Itoa(int(mode)) {
 			interfaces = append(interfaces, strings.TrimSpace(strings.ReplaceAll(strings.ReplaceAll(sectionName, "[", ""), "]", "")))
 		}
 	}
@@ -0,0 +1,139 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PtpEventResource is an O-RAN event resource published by the event publisher of each node
+type PtpEventResource string
+
+const (
+	// PtpEventResourceSyncState is the overall synchronization state of the node
+	PtpEventResourceSyncState PtpEventResource = "sync-state"
+	// PtpEventResourceOsClockSyncState is the synchronization state of the node OS clock
+	PtpEventResourceOsClockSyncState PtpEventResource = "os-clock-sync-state"
+	// PtpEventResourceLockState is the PTP lock state of the node
+	PtpEventResourceLockState PtpEventResource = "lock-state"
+	// PtpEventResourceClockClass is the PTP clock class of the node
+	PtpEventResourceClockClass PtpEventResource = "clock-class"
+	// PtpEventResourceGnssStatus is the GNSS synchronization state of the node
+	PtpEventResourceGnssStatus PtpEventResource = "gnss-status"
+)
+
+// PtpEventSubscriptionSpec defines the desired state of PtpEventSubscription
+type PtpEventSubscriptionSpec struct {
+	// EndpointURI is the URL of the consumer the events are delivered to
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +required
+	EndpointURI string `json:"endpointUri"`
+
+	// Resources are the event resources subscribed to on each node
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	// +required
+	Resources []PtpEventResource `json:"resources"`
+
+	// NodeSelector selects the nodes whose event publisher the resources are subscribed to. The nodes must run an
+	// event publisher. If empty, every node running an event publisher is selected.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// EventSubscriptionState is the state of the subscriptions of a node
+type EventSubscriptionState string
+
+const (
+	// EventSubscriptionSubscribed means every resource is subscribed to on the node
+	EventSubscriptionSubscribed EventSubscriptionState = "Subscribed"
+	// EventSubscriptionFailed means the event publisher of the node could not be reached or rejected a subscription
+	EventSubscriptionFailed EventSubscriptionState = "Failed"
+)
+
+// EventResourceSubscription is the subscription of an event resource in the event publisher of a node
+type EventResourceSubscription struct {
+	// Resource is the subscribed event resource
+	Resource PtpEventResource `json:"resource"`
+
+	// ResourceAddress is the O-RAN resource address of the resource on the node
+	ResourceAddress string `json:"resourceAddress"`
+
+	// SubscriptionID is the identifier of the subscription in the event publisher of the node
+	// +optional
+	SubscriptionID string `json:"subscriptionId,omitempty"`
+}
+
+// NodeEventSubscription reports the subscriptions of a node
+type NodeEventSubscription struct {
+	// Node is the name of the node
+	Node string `json:"node"`
+
+	// State is Subscribed when every resource is subscribed to on the node, Failed otherwise
+	State EventSubscriptionState `json:"state"`
+
+	// Message explains a Failed state
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Subscriptions are the subscriptions created in the event publisher of the node
+	// +optional
+	Subscriptions []EventResourceSubscription `json:"subscriptions,omitempty"`
+}
+
+// PtpEventSubscriptionStatus defines the observed state of PtpEventSubscription
+type PtpEventSubscriptionStatus struct {
+	// Nodes reports the subscriptions of each selected node
+	// +listType=map
+	// +listMapKey=node
+	// +optional
+	Nodes []NodeEventSubscription `json:"nodes,omitempty"`
+
+	// Conditions report whether the resources are subscribed to on every selected node:
+	// Ready: True when every selected node is Subscribed.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.spec.endpointUri`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+
+// PtpEventSubscription subscribes a consumer to the events of the event publisher of the selected nodes
+type PtpEventSubscription struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PtpEventSubscriptionSpec   `json:"spec,omitempty"`
+	Status PtpEventSubscriptionStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// PtpEventSubscriptionList contains a list of PtpEventSubscription
+type PtpEventSubscriptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PtpEventSubscription `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PtpEventSubscription{}, &PtpEventSubscriptionList{})
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // +kubebuilder:object:root=true
@@ -52,8 +54,160 @@ type PtpOperatorConfigSpec struct {
 	// EnabledPlugins is a map of plugin names to their configuration settings.
 	// Each entry in the map specifies the configuration for a specific plugin.
 	// This field is optional and can be omitted if no plugins are enabled.
+	// Plugin names must be known by the linuxptp daemon (e810, e825, e830, ntpfailover, reference) and their
+	// configuration must match the plugin schema. PtpConfig profiles may only configure enabled plugins.
+	// When not set, the e810, e825, e830 and ntpfailover plugins are enabled; when empty, no plugin is enabled.
+	// The plugins enabled cluster-wide are reported in status.plugins.
 	// +optional
 	EnabledPlugins *map[string]*apiextensions.JSON `json:"plugins,omitempty"`
+
+	// PluginSelection selects the enabled plugins loaded by the linuxptp daemon of each node.
+	// All, the default, loads every enabled plugin on every node.
+	// Hardware loads the NIC plugins (e810, e825, e830) only on the nodes whose NodePtpDevice reports a matching NIC.
+	// The other plugins, and every plugin on the nodes whose hardware is not reported yet, are loaded as with All.
+	// The plugins loaded on each node are reported in status.nodePlugins.
+	// +kubebuilder:validation:Enum=All;Hardware
+	// +optional
+	PluginSelection PluginSelection `json:"pluginSelection,omitempty"`
+
+	// LeapSeconds configures the leap second file the operator maintains in leap-configmap for each node.
+	// The file bundled with the operator is used unless a more recent valid file is provided.
+	// +optional
+	LeapSeconds *PtpLeapSecondsConfig `json:"leapSeconds,omitempty"`
+
+	// PtpConfigNamespaces lists the namespaces, other than the operator namespace, whose PtpConfigs and
+	// PtpEventSubscriptions are reconciled, and the nodes their profiles may be recommended to and their subscriptions
+	// may target. PtpConfigs and PtpEventSubscriptions of any other namespace are ignored.
+	// Secrets referenced by the profiles of a PtpConfig are resolved in the namespace of the PtpConfig; the
+	// operator service account must be granted read access to them, for example by binding the
+	// ptp-operator-tenant-secrets ClusterRole in the namespace.
+	// +listType=map
+	// +listMapKey=namespace
+	// +optional
+	PtpConfigNamespaces []PtpConfigNamespacePolicy `json:"ptpConfigNamespaces,omitempty"`
+
+	// DaemonSet customizes the linuxptp-daemon DaemonSet rendered by the operator.
+	// The customizations are applied on every reconciliation, changes made directly to the DaemonSet are reverted.
+	// +optional
+	DaemonSet *PtpDaemonSetConfig `json:"daemonSet,omitempty"`
+
+	// DaemonPools split the nodes selected by DaemonNodeSelector into pools, each running its own linuxptp daemon
+	// DaemonSet named linuxptp-daemon-<pool name>. A node belongs to the first pool whose node selector matches its
+	// labels, the DaemonSets of the pools are scheduled by node affinity and never overlap. Nodes matching no pool run
+	// the linuxptp-daemon DaemonSet.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	DaemonPools []PtpDaemonPool `json:"daemonPools,omitempty"`
+}
+
+// DaemonPoolLabel is the label set by the operator to the pool name on the DaemonSet and the pods of a daemon pool
+const DaemonPoolLabel = "ptp.openshift.io/daemon-pool"
+
+// PtpDaemonPool is a group of nodes running a linuxptp daemon DaemonSet with its own settings.
+// Settings that are not set are inherited from the PtpOperatorConfig spec; settings that are set replace them.
+type PtpDaemonPool struct {
+	// Name of the pool, used to name and label its DaemonSet and pods.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=47
+	// +required
+	Name string `json:"name"`
+
+	// NodeSelector selects the nodes of the pool, among the nodes selected by DaemonNodeSelector.
+	// +kubebuilder:validation:MinProperties=1
+	// +required
+	NodeSelector map[string]string `json:"nodeSelector"`
+
+	// Image is the linuxptp daemon image run by the pool.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// EnabledPlugins replaces the plugins enabled on the nodes of the pool.
+	// +optional
+	EnabledPlugins *map[string]*apiextensions.JSON `json:"plugins,omitempty"`
+
+	// EventConfig replaces the event sidecar configuration of the nodes of the pool.
+	// +optional
+	EventConfig *PtpEventConfig `json:"ptpEventConfig,omitempty"`
+
+	// DaemonSet replaces the DaemonSet customizations of the pool DaemonSet.
+	// +optional
+	DaemonSet *PtpDaemonSetConfig `json:"daemonSet,omitempty"`
+}
+
+// PtpDaemonSetConfig customizes the linuxptp-daemon DaemonSet
+type PtpDaemonSetConfig struct {
+	// Tolerations replaces the tolerations of the linuxptp daemon pods.
+	// If empty, the pods tolerate every taint.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// PriorityClassName of the linuxptp daemon pods. Defaults to system-node-critical.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Annotations added to the linuxptp daemon pods.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// LogLevel is the verbosity of the linuxptp daemon logs. Defaults to 10.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	LogLevel *int32 `json:"logLevel,omitempty"`
+
+	// MaxUnavailable is the maximum number, or percentage, of linuxptp daemon pods that can be unavailable
+	// during a rolling update. Defaults to 33%.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// Volumes added to the linuxptp daemon pods, for example to expose GNSS devices of the host.
+	// They are mounted in the containers listed in Containers.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// Containers customizes the containers of the linuxptp daemon pods.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Containers []PtpDaemonContainerConfig `json:"containers,omitempty"`
+}
+
+// PtpDaemonContainerConfig customizes a container of the linuxptp daemon pods
+type PtpDaemonContainerConfig struct {
+	// Name of the container.
+	// +kubebuilder:validation:Enum=linuxptp-daemon-container;cloud-event-proxy;kube-rbac-proxy
+	// +required
+	Name string `json:"name"`
+
+	// Resources replaces the resource requests and limits of the container.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Env variables added to the container. A variable set by the operator is replaced.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// VolumeMounts of the volumes added to the pods.
+	// +optional
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+}
+
+// PtpConfigNamespacePolicy allows the PtpConfigs of a namespace to target a set of nodes
+type PtpConfigNamespacePolicy struct {
+	// Namespace is the namespace the PtpConfigs are authored in.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +required
+	Namespace string `json:"namespace"`
+
+	// NodeSelector restricts the nodes the profiles of the namespace PtpConfigs may be recommended to.
+	// Recommendations matching other nodes are ignored.
+	// If empty, the profiles may be recommended to every node.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 }
 
 // PtpEventConfig defines the desired state of event framework
@@ -69,10 +223,18 @@ type PtpEventConfig struct {
 	// +optional
 	TransportHost string `json:"transportHost,omitempty"`
 
-	// StorageType is the type of storage to store subscription data
+	// StorageType is the type of storage the event publisher stores the subscriptions in:
+	// emptyDir: the subscriptions are lost when the daemon pod restarts. This is the default.
+	// hostPath: a directory of the node, see storage.hostPath.
+	// persistentVolumeClaim: a sub directory named after the node of the ReadWriteMany claim storage.claimName.
+	// configMap: a ConfigMap of the operator namespace named after the node.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Storage Type",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	// +optional
-	StorageType string `json:"storageType,omitempty"`
+	StorageType EventStorageType `json:"storageType,omitempty"`
+
+	// Storage configures the storage of the storage type
+	// +optional
+	Storage *PtpEventStorage `json:"storage,omitempty"`
 
 	// ApiVersion is used to determine which API is used for the event service
 	// 1.0: default version. event service is mapped to internal REST-API.
@@ -82,8 +244,199 @@ type PtpEventConfig struct {
 	ApiVersion string `json:"apiVersion,omitempty"`
 }
 
+// EventStorageType is the type of storage the event publisher stores the subscriptions in
+type EventStorageType string
+
+const (
+	// EventStorageEmptyDir stores the subscriptions in a volume deleted with the daemon pod
+	EventStorageEmptyDir EventStorageType = "emptyDir"
+	// EventStorageHostPath stores the subscriptions in a directory of the node
+	EventStorageHostPath EventStorageType = "hostPath"
+	// EventStoragePersistentVolumeClaim stores the subscriptions of each node in a sub directory of a claim shared
+	// by the nodes
+	EventStoragePersistentVolumeClaim EventStorageType = "persistentVolumeClaim"
+	// EventStorageConfigMap stores the subscriptions of each node in a ConfigMap of the operator namespace
+	EventStorageConfigMap EventStorageType = "configMap"
+)
+
+// EventStorageTypes are the supported event storage types
+var EventStorageTypes = []EventStorageType{
+	EventStorageEmptyDir, EventStorageHostPath, EventStoragePersistentVolumeClaim, EventStorageConfigMap,
+}
+
+// PtpEventStorage configures where the event publisher stores the subscriptions
+type PtpEventStorage struct {
+	// HostPath is the absolute path of the node directory of the hostPath storage type. Defaults to
+	// /var/lib/ptp-events.
+	// +optional
+	HostPath string `json:"hostPath,omitempty"`
+
+	// ClaimName is the name of a ReadWriteMany PersistentVolumeClaim of the operator namespace, required by the
+	// persistentVolumeClaim storage type. The subscriptions of each node are stored in a sub directory named after
+	// the node.
+	// +optional
+	ClaimName string `json:"claimName,omitempty"`
+}
+
+// PtpLeapSecondsConfig configures the leap second file distributed to the nodes
+type PtpLeapSecondsConfig struct {
+	// ConfigMapName is the name of a ConfigMap of the operator namespace holding an IERS leap-seconds.list file.
+	// The file is distributed to the nodes when its hash is valid, it has not expired and it is more recent than the
+	// file bundled with the operator.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// Key is the ConfigMap key holding the file. Defaults to leap-seconds.list.
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// ExpiryWarning is how long before the distributed file expires the LeapSecondsExpiring condition is raised.
+	// Defaults to 720h.
+	// +optional
+	ExpiryWarning *metav1.Duration `json:"expiryWarning,omitempty"`
+
+	// Events schedule or suppress leap seconds in the leap second file of every node, for grandmasters to announce
+	// them with the leap61 or leap59 flag during the 12 hours preceding the leap second. Events are applied until
+	// they are removed, remove them once the leap second file distributed to the nodes includes them.
+	// +listType=map
+	// +listMapKey=time
+	// +optional
+	Events []PtpLeapEvent `json:"events,omitempty"`
+}
+
+// LeapEventAction is what is done with a leap second
+type LeapEventAction string
+
+const (
+	// LeapEventInsert inserts a leap second, the last minute before the event has 61 seconds (leap61)
+	LeapEventInsert LeapEventAction = "Insert"
+	// LeapEventDelete deletes a leap second, the last minute before the event has 59 seconds (leap59)
+	LeapEventDelete LeapEventAction = "Delete"
+	// LeapEventSuppress removes a leap second not yet in effect from the leap second file of the nodes
+	LeapEventSuppress LeapEventAction = "Suppress"
+)
+
+// PtpLeapEvent is a leap second scheduled or suppressed cluster-wide
+type PtpLeapEvent struct {
+	// Time is when the leap second takes effect and the TAI-UTC offset changes, 00:00:00 UTC of the first day of a
+	// month.
+	// +required
+	Time metav1.Time `json:"time"`
+
+	// Action is Insert or Delete to schedule a leap second, changing the TAI-UTC offset by 1 or -1 second, or
+	// Suppress to remove a leap second from the leap second file of the nodes.
+	// +kubebuilder:validation:Enum=Insert;Delete;Suppress
+	// +required
+	Action LeapEventAction `json:"action"`
+}
+
+// PluginSelection selects the plugins loaded by the linuxptp daemon of each node
+type PluginSelection string
+
+const (
+	// PluginSelectionAll loads every enabled plugin on every node
+	PluginSelectionAll PluginSelection = "All"
+	// PluginSelectionHardware loads the NIC plugins only on the nodes with a matching NIC
+	PluginSelectionHardware PluginSelection = "Hardware"
+)
+
 // PtpOperatorConfigStatus defines the observed state of PtpOperatorConfig
 type PtpOperatorConfigStatus struct {
+	// Plugins are the plugins enabled cluster-wide by spec.plugins, or the default plugins when it is not set.
+	// +optional
+	Plugins []string `json:"plugins,omitempty"`
+
+	// PluginsDefaulted is true when spec.plugins is not set and the default plugins are enabled.
+	// +optional
+	PluginsDefaulted bool `json:"pluginsDefaulted,omitempty"`
+
+	// NodePlugins are the plugins loaded by the linuxptp daemon of each node selected by DaemonNodeSelector.
+	// +listType=map
+	// +listMapKey=node
+	// +optional
+	NodePlugins []NodePlugins `json:"nodePlugins,omitempty"`
+
+	// LeapSeconds reports the leap second file distributed to the nodes.
+	// +optional
+	LeapSeconds *LeapSecondsStatus `json:"leapSeconds,omitempty"`
+
+	// Conditions of the PtpOperatorConfig.
+	// LeapSecondsExpiring is True when the distributed leap second file expires within spec.leapSeconds.expiryWarning.
+	// LeapSecondsSourceValid is False when the file of spec.leapSeconds.configMapName cannot be distributed.
+	// LeapEventsApplied is False when a leap event of spec.leapSeconds.events conflicts with the leap second file.
+	// EventTransportValid is False when the transportHost of an event config is not a supported transport on the event
+	// publisher port; the default transport host is used instead.
+	// EventPublisherAvailable is False when a per-node event publisher Service has no ready endpoint.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// LeapSecondsStatus describes the leap second file distributed to the nodes
+type LeapSecondsStatus struct {
+	// Source of the file, Bundled or ConfigMap.
+	Source string `json:"source"`
+
+	// UTCOffset is the current TAI-UTC offset in seconds.
+	UTCOffset int `json:"utcOffset"`
+
+	// LastUpdate is the time of the last update of the file by the IERS.
+	LastUpdate metav1.Time `json:"lastUpdate"`
+
+	// Expires is the time after which the file must not be used.
+	Expires metav1.Time `json:"expires"`
+
+	// Nodes report the leap second file of each node selected by DaemonNodeSelector.
+	// +listType=map
+	// +listMapKey=node
+	// +optional
+	Nodes []NodeLeapSeconds `json:"nodes,omitempty"`
+}
+
+// NodeLeapSeconds reports the leap second file of a node, and what its ptp4l instances announce
+type NodeLeapSeconds struct {
+	// Node is the name of the node.
+	// +required
+	Node string `json:"node"`
+
+	// Acknowledged is true when the leap second file of the node carries the leap events of spec.leapSeconds.events
+	// and the GRANDMASTER_SETTINGS_NP of every ptp4l instance of the node, reported in its NodePtpDevice status,
+	// announce what the file announces. It is false while the node is being updated or reports no grandmaster.
+	Acknowledged bool `json:"acknowledged"`
+
+	// CurrentUTCOffset is the currentUtcOffset of the GRANDMASTER_SETTINGS_NP of the ptp4l instances of the node, 0
+	// when the node reports no grandmaster.
+	CurrentUTCOffset int `json:"currentUtcOffset"`
+
+	// Leap61 is true when the GRANDMASTER_SETTINGS_NP of the ptp4l instances of the node announce a leap second
+	// insertion.
+	// +optional
+	Leap61 bool `json:"leap61,omitempty"`
+
+	// Leap59 is true when the GRANDMASTER_SETTINGS_NP of the ptp4l instances of the node announce a leap second
+	// deletion.
+	// +optional
+	Leap59 bool `json:"leap59,omitempty"`
+
+	// NextLeapSecond is when the next leap second of the leap second file of the node takes effect.
+	// +optional
+	NextLeapSecond *metav1.Time `json:"nextLeapSecond,omitempty"`
+}
+
+// NodePlugins are the plugins loaded by the linuxptp daemon of a node
+type NodePlugins struct {
+	// Node is the name of the node.
+	// +required
+	Node string `json:"node"`
+
+	// DaemonPool is the daemon pool of the node, empty when the node belongs to no pool.
+	// +optional
+	DaemonPool string `json:"daemonPool,omitempty"`
+
+	// Plugins are the plugins loaded on the node.
+	// +optional
+	Plugins []string `json:"plugins,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -19,9 +19,16 @@ package v1
 import (
 	"context"
 	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
 
 	semver "github.com/Masterminds/semver/v3"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -34,10 +41,13 @@ var ptpoperatorconfiglog = logf.Log.WithName("ptpoperatorconfig-resource")
 
 func (r *PtpOperatorConfig) SetupWebhookWithManager(mgr ctrl.Manager, _ client.Client) error {
 	return ctrl.NewWebhookManagedBy(mgr, r).
+		WithCustomDefaulter(&ptpOperatorConfigDefaulter{}).
 		WithCustomValidator(&ptpOperatorConfigValidator{}).
 		Complete()
 }
 
+//+kubebuilder:webhook:path=/mutate-ptp-openshift-io-v1-ptpoperatorconfig,mutating=true,failurePolicy=fail,sideEffects=None,groups=ptp.openshift.io,resources=ptpoperatorconfigs,verbs=create;update,versions=v1,name=mptpoperatorconfig.kb.io,admissionReviewVersions=v1
+
 //+kubebuilder:webhook:path=/validate-ptp-openshift-io-v1-ptpoperatorconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=ptp.openshift.io,resources=ptpoperatorconfigs,verbs=create;update,versions=v1,name=vptpoperatorconfig.kb.io,admissionReviewVersions=v1
 
 func (r *PtpOperatorConfig) validate() error {
@@ -45,14 +55,43 @@ func (r *PtpOperatorConfig) validate() error {
 		return errors.New("PtpOperatorConfig name must be 'default'. Only one 'default' PtpOperatorConfig configuration is allowed")
 	}
 
-	if r.Spec.EventConfig != nil && r.Spec.EventConfig.EnableEventPublisher {
-		if r.Spec.EventConfig.ApiVersion != "" {
-			if !isValidVersion(r.Spec.EventConfig.ApiVersion) {
-				return errors.New("ptpEventConfig.apiVersion=" +
-					r.Spec.EventConfig.ApiVersion +
+	if err := validateEventConfig("ptpEventConfig", r.Spec.EventConfig); err != nil {
+		return err
+	}
+
+	if err := r.validatePtpConfigNamespaces(); err != nil {
+		return err
+	}
+
+	if err := validateDaemonSetConfig("daemonSet", r.Spec.DaemonSet); err != nil {
+		return err
+	}
+
+	if err := r.validateDaemonPools(); err != nil {
+		return err
+	}
+
+	if err := r.validatePlugins(); err != nil {
+		return err
+	}
+
+	if err := r.validateLeapSeconds(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateEventConfig checks the event API version of an enabled event publisher
+func validateEventConfig(field string, cfg *PtpEventConfig) error {
+	if cfg != nil && cfg.EnableEventPublisher {
+		if cfg.ApiVersion != "" {
+			if !isValidVersion(cfg.ApiVersion) {
+				return errors.New(field + ".apiVersion=" +
+					cfg.ApiVersion +
 					" is not a valid version. Valid version is \"2.0\".")
 			}
-			if r.Spec.EventConfig.ApiVersion == "1.0" {
+			if cfg.ApiVersion == "1.0" {
 				return errors.New("v1 is no longer supported and has reached End " +
 					"of Life (EOL). PTP event functionality is now available only in " +
 					"v2. Consumers using v1 will no longer be able to communicate " +
@@ -60,8 +99,227 @@ func (r *PtpOperatorConfig) validate() error {
 					"the documentation to make the necessary changes.")
 			}
 		}
+		if err := validateEventStorage(field, cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withLegacyStorageTypes returns the config with the unsupported storage types kept unchanged from the old config
+// cleared, so configs created before the storage types were validated can still be updated, and a warning for each.
+// The operator uses the default storage type for them.
+func (r *PtpOperatorConfig) withLegacyStorageTypes(old *PtpOperatorConfig) (*PtpOperatorConfig, []string) {
+	oldEventConfigs := map[string]*PtpEventConfig{"ptpEventConfig": old.Spec.EventConfig}
+	for _, pool := range old.Spec.DaemonPools {
+		oldEventConfigs["daemonPools "+pool.Name] = pool.EventConfig
+	}
+	legacy := func(key string, cfg *PtpEventConfig) bool {
+		oldCfg := oldEventConfigs[key]
+		return cfg != nil && cfg.StorageType != "" && !slices.Contains(EventStorageTypes, cfg.StorageType) &&
+			oldCfg != nil && oldCfg.StorageType == cfg.StorageType
+	}
+
+	var warnings []string
+	result := r.DeepCopy()
+	if legacy("ptpEventConfig", r.Spec.EventConfig) {
+		warnings = append(warnings, fmt.Sprintf("ptpEventConfig.storageType '%s' is not supported, %s is used",
+			r.Spec.EventConfig.StorageType, DefaultEventStorageType))
+		result.Spec.EventConfig.StorageType = ""
+	}
+	for i, pool := range r.Spec.DaemonPools {
+		if legacy("daemonPools "+pool.Name, pool.EventConfig) {
+			warnings = append(warnings, fmt.Sprintf("daemonPools[%d].ptpEventConfig.storageType '%s' is not supported, %s is used",
+				i, pool.EventConfig.StorageType, DefaultEventStorageType))
+			result.Spec.DaemonPools[i].EventConfig.StorageType = ""
+		}
+	}
+	return result, warnings
+}
+
+// validateEventStorage checks the storage type of an event config is supported and its storage settings are set
+// for the type
+func validateEventStorage(field string, cfg *PtpEventConfig) error {
+	if cfg.StorageType != "" && !slices.Contains(EventStorageTypes, cfg.StorageType) {
+		return fmt.Errorf("%s.storageType '%s' is invalid; must be one of %v", field, cfg.StorageType, EventStorageTypes)
+	}
+	storage := cfg.Storage
+	if storage == nil {
+		storage = &PtpEventStorage{}
+	}
+	if storage.HostPath != "" {
+		if cfg.StorageType != EventStorageHostPath {
+			return fmt.Errorf("%s.storage.hostPath requires storageType '%s'", field, EventStorageHostPath)
+		}
+		if !path.IsAbs(storage.HostPath) || path.Clean(storage.HostPath) == "/" {
+			return fmt.Errorf("%s.storage.hostPath '%s' must be an absolute path other than /", field, storage.HostPath)
+		}
+	}
+	if storage.ClaimName != "" && cfg.StorageType != EventStoragePersistentVolumeClaim {
+		return fmt.Errorf("%s.storage.claimName requires storageType '%s'", field, EventStoragePersistentVolumeClaim)
+	}
+	if cfg.StorageType == EventStoragePersistentVolumeClaim {
+		if storage.ClaimName == "" {
+			return fmt.Errorf("%s.storage.claimName is required by storageType '%s'", field, EventStoragePersistentVolumeClaim)
+		}
+		if errs := validation.IsDNS1123Subdomain(storage.ClaimName); len(errs) > 0 {
+			return fmt.Errorf("%s.storage.claimName '%s' is invalid: %s", field, storage.ClaimName, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// validatePtpConfigNamespaces checks the namespace policies name valid namespaces other than the operator
+// namespace, and use valid node selectors
+func (r *PtpOperatorConfig) validatePtpConfigNamespaces() error {
+	for _, policy := range r.Spec.PtpConfigNamespaces {
+		if errs := validation.IsDNS1123Label(policy.Namespace); len(errs) > 0 {
+			return fmt.Errorf("ptpConfigNamespaces namespace '%s' is invalid: %s", policy.Namespace, strings.Join(errs, ", "))
+		}
+		if policy.Namespace == r.Namespace {
+			return fmt.Errorf("ptpConfigNamespaces namespace '%s' is the operator namespace, whose PtpConfigs may target every node", policy.Namespace)
+		}
+		for key, value := range policy.NodeSelector {
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				return fmt.Errorf("ptpConfigNamespaces namespace '%s' node selector key '%s' is invalid: %s", policy.Namespace, key, strings.Join(errs, ", "))
+			}
+			if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+				return fmt.Errorf("ptpConfigNamespaces namespace '%s' node selector value '%s' is invalid: %s", policy.Namespace, value, strings.Join(errs, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// daemonVolumes are the volumes of the linuxptp daemon pods rendered by the operator
+var daemonVolumes = []string{"config-volume", "leap-volume", "linuxptp-certs", "pubsubstore", "event-bus-socket", "socket-dir", "ptp-plugins"}
+
+// validateDaemonSetConfig checks the DaemonSet customizations do not conflict with the volumes and annotations managed
+// by the operator, and that the volume mounts reference the added volumes
+func validateDaemonSetConfig(field string, cfg *PtpDaemonSetConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	for key := range cfg.Annotations {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("%s annotation '%s' is invalid: %s", field, key, strings.Join(errs, ", "))
+		}
+		if strings.HasPrefix(key, "ptp.openshift.io/secret-hash-") {
+			return fmt.Errorf("%s annotation '%s' is managed by the operator", field, key)
+		}
+	}
+	if cfg.MaxUnavailable != nil {
+		if _, err := intstr.GetScaledValueFromIntOrPercent(cfg.MaxUnavailable, 100, true); err != nil {
+			return fmt.Errorf("%s maxUnavailable is invalid: %w", field, err)
+		}
+		if cfg.MaxUnavailable.Type == intstr.Int && cfg.MaxUnavailable.IntVal < 1 {
+			return fmt.Errorf("%s maxUnavailable must be at least 1, got %d", field, cfg.MaxUnavailable.IntVal)
+		}
+	}
+
+	volumes := make(map[string]bool)
+	for _, volume := range cfg.Volumes {
+		if slices.Contains(daemonVolumes, volume.Name) || strings.HasSuffix(volume.Name, "-tlv-auth") {
+			return fmt.Errorf("%s volume '%s' is managed by the operator", field, volume.Name)
+		}
+		volumes[volume.Name] = true
+	}
+	for _, container := range cfg.Containers {
+		env := make(map[string]bool)
+		for _, envVar := range container.Env {
+			if env[envVar.Name] {
+				return fmt.Errorf("%s container %s environment variable %s is set more than once", field, container.Name, envVar.Name)
+			}
+			env[envVar.Name] = true
+		}
+		for _, mount := range container.VolumeMounts {
+			if !volumes[mount.Name] {
+				return fmt.Errorf("%s container %s mounts volume '%s' which is not in %s volumes", field, container.Name, mount.Name, field)
+			}
+		}
+	}
+	return nil
+}
+
+// validateDaemonPools checks the pool names can be used as DaemonSet name suffixes and label values, and validates
+// the settings of each pool
+func (r *PtpOperatorConfig) validateDaemonPools() error {
+	for i, pool := range r.Spec.DaemonPools {
+		if errs := validation.IsDNS1123Label(pool.Name); len(errs) > 0 {
+			return fmt.Errorf("daemonPools name '%s' is invalid: %s", pool.Name, strings.Join(errs, ", "))
+		}
+		if len(pool.NodeSelector) == 0 {
+			return fmt.Errorf("daemonPools pool '%s' node selector must not be empty", pool.Name)
+		}
+		for key, value := range pool.NodeSelector {
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				return fmt.Errorf("daemonPools pool '%s' node selector key '%s' is invalid: %s", pool.Name, key, strings.Join(errs, ", "))
+			}
+			if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+				return fmt.Errorf("daemonPools pool '%s' node selector value '%s' is invalid: %s", pool.Name, value, strings.Join(errs, ", "))
+			}
+		}
+		field := fmt.Sprintf("daemonPools[%d]", i)
+		if err := validateEventConfig(field+".ptpEventConfig", pool.EventConfig); err != nil {
+			return err
+		}
+		if err := validateDaemonSetConfig(field+".daemonSet", pool.DaemonSet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateLeapSeconds checks the leap second file ConfigMap reference
+func (r *PtpOperatorConfig) validateLeapSeconds() error {
+	cfg := r.Spec.LeapSeconds
+	if cfg == nil {
+		return nil
+	}
+	if cfg.ConfigMapName != "" {
+		if errs := validation.IsDNS1123Subdomain(cfg.ConfigMapName); len(errs) > 0 {
+			return fmt.Errorf("leapSeconds configMapName '%s' is invalid: %s", cfg.ConfigMapName, strings.Join(errs, ", "))
+		}
+	} else if cfg.Key != "" {
+		return errors.New("leapSeconds key requires configMapName")
+	}
+	if cfg.Key != "" {
+		if errs := validation.IsConfigMapKey(cfg.Key); len(errs) > 0 {
+			return fmt.Errorf("leapSeconds key '%s' is invalid: %s", cfg.Key, strings.Join(errs, ", "))
+		}
 	}
+	if cfg.ExpiryWarning != nil && cfg.ExpiryWarning.Duration < 0 {
+		return fmt.Errorf("leapSeconds expiryWarning must not be negative, got %s", cfg.ExpiryWarning.Duration)
+	}
+	times := map[time.Time]bool{}
+	for i, event := range cfg.Events {
+		at := event.Time.UTC()
+		if at.Day() != 1 || at.Hour() != 0 || at.Minute() != 0 || at.Second() != 0 || at.Nanosecond() != 0 {
+			return fmt.Errorf("leapSeconds events[%d] time %s must be 00:00:00 UTC of the first day of a month",
+				i, at.Format(time.RFC3339))
+		}
+		if times[at] {
+			return fmt.Errorf("leapSeconds events[%d] time %s is duplicated", i, at.Format(time.RFC3339))
+		}
+		times[at] = true
+		switch event.Action {
+		case LeapEventInsert, LeapEventDelete, LeapEventSuppress:
+		default:
+			return fmt.Errorf("leapSeconds events[%d] action '%s' is invalid; must be one of ['%s', '%s', '%s']",
+				i, event.Action, LeapEventInsert, LeapEventDelete, LeapEventSuppress)
+		}
+	}
+	return nil
+}
+
+type ptpOperatorConfigDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &ptpOperatorConfigDefaulter{}
 
+func (d *ptpOperatorConfigDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	r := obj.(*PtpOperatorConfig)
+	ptpoperatorconfiglog.Info("default", "name", r.Name)
+	r.SetDefaults()
 	return nil
 }
 
@@ -72,19 +330,23 @@ var _ webhook.CustomValidator = &ptpOperatorConfigValidator{}
 func (v *ptpOperatorConfigValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	r := obj.(*PtpOperatorConfig)
 	ptpoperatorconfiglog.Info("validate create", "name", r.Name)
+	warnings := admission.Warnings(r.Deprecations())
 	if err := r.validate(); err != nil {
-		return admission.Warnings{}, err
+		return warnings, err
 	}
-	return admission.Warnings{}, nil
+	return warnings, nil
 }
 
 func (v *ptpOperatorConfigValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	r := newObj.(*PtpOperatorConfig)
 	ptpoperatorconfiglog.Info("validate update", "name", r.Name)
+	warnings := admission.Warnings(r.Deprecations())
+	r, legacy := r.withLegacyStorageTypes(oldObj.(*PtpOperatorConfig))
+	warnings = append(warnings, legacy...)
 	if err := r.validate(); err != nil {
-		return admission.Warnings{}, err
+		return warnings, err
 	}
-	return admission.Warnings{}, nil
+	return warnings, nil
 }
 
 func (v *ptpOperatorConfigValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
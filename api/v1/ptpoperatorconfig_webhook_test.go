@@ -0,0 +1,404 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestValidatePtpConfigNamespaces(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies []PtpConfigNamespacePolicy
+		errMsg   string
+	}{
+		{
+			name: "valid",
+			policies: []PtpConfigNamespacePolicy{
+				{Namespace: "site-a", NodeSelector: map[string]string{"ptp.example.com/site": "a"}},
+				{Namespace: "site-b"},
+			},
+		},
+		{
+			name:     "invalid namespace",
+			policies: []PtpConfigNamespacePolicy{{Namespace: "Site_A"}},
+			errMsg:   "namespace 'Site_A' is invalid",
+		},
+		{
+			name:     "operator namespace",
+			policies: []PtpConfigNamespacePolicy{{Namespace: "openshift-ptp"}},
+			errMsg:   "is the operator namespace",
+		},
+		{
+			name:     "invalid node selector key",
+			policies: []PtpConfigNamespacePolicy{{Namespace: "site-a", NodeSelector: map[string]string{"-site": "a"}}},
+			errMsg:   "node selector key '-site' is invalid",
+		},
+		{
+			name:     "invalid node selector value",
+			policies: []PtpConfigNamespacePolicy{{Namespace: "site-a", NodeSelector: map[string]string{"site": "a b"}}},
+			errMsg:   "node selector value 'a b' is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &PtpOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "openshift-ptp"},
+				Spec:       PtpOperatorConfigSpec{PtpConfigNamespaces: tt.policies},
+			}
+			err := cfg.validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestValidateDaemonSet(t *testing.T) {
+	percent := intstr.FromString("25%")
+	invalidPercent := intstr.FromString("25")
+	zero := intstr.FromInt32(0)
+	tests := []struct {
+		name   string
+		cfg    *PtpDaemonSetConfig
+		errMsg string
+	}{
+		{name: "not set"},
+		{
+			name: "valid",
+			cfg: &PtpDaemonSetConfig{
+				Annotations:    map[string]string{"example.com/team": "ran"},
+				MaxUnavailable: &percent,
+				Volumes:        []corev1.Volume{{Name: "gnss"}},
+				Containers: []PtpDaemonContainerConfig{{
+					Name:         "linuxptp-daemon-container",
+					Env:          []corev1.EnvVar{{Name: "GNSS_DEVICE", Value: "/dev/gnss0"}},
+					VolumeMounts: []corev1.VolumeMount{{Name: "gnss", MountPath: "/dev/gnss0"}},
+				}},
+			},
+		},
+		{
+			name:   "managed annotation",
+			cfg:    &PtpDaemonSetConfig{Annotations: map[string]string{"ptp.openshift.io/secret-hash-sa": "x"}},
+			errMsg: "is managed by the operator",
+		},
+		{
+			name:   "invalid maxUnavailable",
+			cfg:    &PtpDaemonSetConfig{MaxUnavailable: &invalidPercent},
+			errMsg: "maxUnavailable is invalid",
+		},
+		{
+			name:   "zero maxUnavailable",
+			cfg:    &PtpDaemonSetConfig{MaxUnavailable: &zero},
+			errMsg: "must be at least 1",
+		},
+		{
+			name:   "operator volume",
+			cfg:    &PtpDaemonSetConfig{Volumes: []corev1.Volume{{Name: "socket-dir"}}},
+			errMsg: "volume 'socket-dir' is managed by the operator",
+		},
+		{
+			name:   "security volume",
+			cfg:    &PtpDaemonSetConfig{Volumes: []corev1.Volume{{Name: "sa-tlv-auth"}}},
+			errMsg: "volume 'sa-tlv-auth' is managed by the operator",
+		},
+		{
+			name: "duplicate env",
+			cfg: &PtpDaemonSetConfig{Containers: []PtpDaemonContainerConfig{{
+				Name: "linuxptp-daemon-container",
+				Env:  []corev1.EnvVar{{Name: "A"}, {Name: "A"}},
+			}}},
+			errMsg: "environment variable A is set more than once",
+		},
+		{
+			name: "unknown volume mount",
+			cfg: &PtpDaemonSetConfig{Containers: []PtpDaemonContainerConfig{{
+				Name:         "linuxptp-daemon-container",
+				VolumeMounts: []corev1.VolumeMount{{Name: "config-volume", MountPath: "/tmp"}},
+			}}},
+			errMsg: "mounts volume 'config-volume' which is not in daemonSet volumes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &PtpOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "openshift-ptp"},
+				Spec:       PtpOperatorConfigSpec{DaemonSet: tt.cfg},
+			}
+			err := cfg.validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestValidateDaemonPools(t *testing.T) {
+	tests := []struct {
+		name   string
+		pools  []PtpDaemonPool
+		errMsg string
+	}{
+		{
+			name: "valid",
+			pools: []PtpDaemonPool{
+				{Name: "gm", NodeSelector: map[string]string{"ptp.example.com/role": "gm"}},
+				{Name: "oc", NodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+					EventConfig: &PtpEventConfig{EnableEventPublisher: true, ApiVersion: "2.0"}},
+			},
+		},
+		{
+			name:   "invalid name",
+			pools:  []PtpDaemonPool{{Name: "GM", NodeSelector: map[string]string{"role": "gm"}}},
+			errMsg: "daemonPools name 'GM' is invalid",
+		},
+		{
+			name:   "empty node selector",
+			pools:  []PtpDaemonPool{{Name: "gm"}},
+			errMsg: "node selector must not be empty",
+		},
+		{
+			name:   "invalid node selector value",
+			pools:  []PtpDaemonPool{{Name: "gm", NodeSelector: map[string]string{"role": "a b"}}},
+			errMsg: "node selector value 'a b' is invalid",
+		},
+		{
+			name: "invalid event api version",
+			pools: []PtpDaemonPool{{Name: "gm", NodeSelector: map[string]string{"role": "gm"},
+				EventConfig: &PtpEventConfig{EnableEventPublisher: true, ApiVersion: "two"}}},
+			errMsg: "daemonPools[0].ptpEventConfig.apiVersion=two is not a valid version",
+		},
+		{
+			name: "invalid daemonSet",
+			pools: []PtpDaemonPool{{Name: "gm", NodeSelector: map[string]string{"role": "gm"},
+				DaemonSet: &PtpDaemonSetConfig{Volumes: []corev1.Volume{{Name: "socket-dir"}}}}},
+			errMsg: "daemonPools[0].daemonSet volume 'socket-dir' is managed by the operator",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &PtpOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "openshift-ptp"},
+				Spec:       PtpOperatorConfigSpec{DaemonPools: tt.pools},
+			}
+			err := cfg.validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestValidateEventStorage(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *PtpEventConfig
+		errMsg string
+	}{
+		{
+			name: "default",
+			cfg:  &PtpEventConfig{EnableEventPublisher: true},
+		},
+		{
+			name: "host path",
+			cfg: &PtpEventConfig{EnableEventPublisher: true, StorageType: EventStorageHostPath,
+				Storage: &PtpEventStorage{HostPath: "/var/lib/events"}},
+		},
+		{
+			name: "persistent volume claim",
+			cfg: &PtpEventConfig{EnableEventPublisher: true, StorageType: EventStoragePersistentVolumeClaim,
+				Storage: &PtpEventStorage{ClaimName: "ptp-events"}},
+		},
+		{
+			name: "config map",
+			cfg:  &PtpEventConfig{EnableEventPublisher: true, StorageType: EventStorageConfigMap},
+		},
+		{
+			name: "publisher disabled",
+			cfg:  &PtpEventConfig{StorageType: "local-sc"},
+		},
+		{
+			name:   "invalid storage type",
+			cfg:    &PtpEventConfig{EnableEventPublisher: true, StorageType: "local-sc"},
+			errMsg: "ptpEventConfig.storageType 'local-sc' is invalid; must be one of [emptyDir hostPath persistentVolumeClaim configMap]",
+		},
+		{
+			name: "relative host path",
+			cfg: &PtpEventConfig{EnableEventPublisher: true, StorageType: EventStorageHostPath,
+				Storage: &PtpEventStorage{HostPath: "var/lib/events"}},
+			errMsg: "ptpEventConfig.storage.hostPath 'var/lib/events' must be an absolute path other than /",
+		},
+		{
+			name: "root host path",
+			cfg: &PtpEventConfig{EnableEventPublisher: true, StorageType: EventStorageHostPath,
+				Storage: &PtpEventStorage{HostPath: "//"}},
+			errMsg: "must be an absolute path other than /",
+		},
+		{
+			name: "host path without host path type",
+			cfg: &PtpEventConfig{EnableEventPublisher: true, StorageType: EventStorageConfigMap,
+				Storage: &PtpEventStorage{HostPath: "/var/lib/events"}},
+			errMsg: "ptpEventConfig.storage.hostPath requires storageType 'hostPath'",
+		},
+		{
+			name: "claim name without claim type",
+			cfg: &PtpEventConfig{EnableEventPublisher: true,
+				Storage: &PtpEventStorage{ClaimName: "ptp-events"}},
+			errMsg: "ptpEventConfig.storage.claimName requires storageType 'persistentVolumeClaim'",
+		},
+		{
+			name:   "missing claim name",
+			cfg:    &PtpEventConfig{EnableEventPublisher: true, StorageType: EventStoragePersistentVolumeClaim},
+			errMsg: "ptpEventConfig.storage.claimName is required by storageType 'persistentVolumeClaim'",
+		},
+		{
+			name: "invalid claim name",
+			cfg: &PtpEventConfig{EnableEventPublisher: true, StorageType: EventStoragePersistentVolumeClaim,
+				Storage: &PtpEventStorage{ClaimName: "PTP_Events"}},
+			errMsg: "ptpEventConfig.storage.claimName 'PTP_Events' is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &PtpOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "openshift-ptp"},
+				Spec:       PtpOperatorConfigSpec{EventConfig: tt.cfg},
+			}
+			err := cfg.validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestValidateUpdateLegacyStorageType(t *testing.T) {
+	makeConfig := func(storageType, poolStorageType EventStorageType) *PtpOperatorConfig {
+		return &PtpOperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "openshift-ptp"},
+			Spec: PtpOperatorConfigSpec{
+				EventConfig: &PtpEventConfig{EnableEventPublisher: true, StorageType: storageType},
+				DaemonPools: []PtpDaemonPool{{
+					Name:         "gm",
+					NodeSelector: map[string]string{"ptp/role": "gm"},
+					EventConfig:  &PtpEventConfig{EnableEventPublisher: true, StorageType: poolStorageType},
+				}},
+			},
+		}
+	}
+	validator := &ptpOperatorConfigValidator{}
+	ctx := context.Background()
+
+	// an unsupported storage type set before the storage types were validated is kept on update
+	old := makeConfig("local-sc", "local-sc")
+	updated := makeConfig("local-sc", "local-sc")
+	updated.Spec.DaemonSet = &PtpDaemonSetConfig{PriorityClassName: "ptp"}
+	warnings, err := validator.ValidateUpdate(ctx, old, updated)
+	assert.NoError(t, err)
+	assert.Contains(t, warnings, "ptpEventConfig.storageType 'local-sc' is not supported, emptyDir is used")
+	assert.Contains(t, warnings, "daemonPools[0].ptpEventConfig.storageType 'local-sc' is not supported, emptyDir is used")
+	assert.Equal(t, EventStorageType("local-sc"), updated.Spec.EventConfig.StorageType)
+
+	// it cannot be changed to another unsupported storage type
+	_, err = validator.ValidateUpdate(ctx, old, makeConfig("local-sc", "other-sc"))
+	assert.ErrorContains(t, err, "daemonPools[0].ptpEventConfig.storageType 'other-sc' is invalid")
+
+	// nor be set on create or on update
+	_, err = validator.ValidateCreate(ctx, makeConfig("local-sc", EventStorageEmptyDir))
+	assert.ErrorContains(t, err, "ptpEventConfig.storageType 'local-sc' is invalid")
+	_, err = validator.ValidateUpdate(ctx, makeConfig(EventStorageEmptyDir, EventStorageEmptyDir), makeConfig("local-sc", EventStorageEmptyDir))
+	assert.ErrorContains(t, err, "ptpEventConfig.storageType 'local-sc' is invalid")
+}
+
+func TestValidateLeapSeconds(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *PtpLeapSecondsConfig
+		errMsg string
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name: "valid",
+			cfg: &PtpLeapSecondsConfig{ConfigMapName: "leap-seconds", Key: "leap-seconds.list",
+				ExpiryWarning: &metav1.Duration{Duration: 48 * time.Hour}},
+		},
+		{
+			name:   "invalid config map name",
+			cfg:    &PtpLeapSecondsConfig{ConfigMapName: "Leap_Seconds"},
+			errMsg: "leapSeconds configMapName 'Leap_Seconds' is invalid",
+		},
+		{
+			name:   "key without config map",
+			cfg:    &PtpLeapSecondsConfig{Key: "leap-seconds.list"},
+			errMsg: "leapSeconds key requires configMapName",
+		},
+		{
+			name:   "invalid key",
+			cfg:    &PtpLeapSecondsConfig{ConfigMapName: "leap-seconds", Key: "leap/seconds"},
+			errMsg: "leapSeconds key 'leap/seconds' is invalid",
+		},
+		{
+			name:   "negative expiry warning",
+			cfg:    &PtpLeapSecondsConfig{ExpiryWarning: &metav1.Duration{Duration: -time.Hour}},
+			errMsg: "leapSeconds expiryWarning must not be negative",
+		},
+		{
+			name: "valid events",
+			cfg: &PtpLeapSecondsConfig{Events: []PtpLeapEvent{
+				{Time: metav1.NewTime(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)), Action: LeapEventInsert},
+				{Time: metav1.NewTime(time.Date(2027, 7, 1, 0, 0, 0, 0, time.UTC)), Action: LeapEventSuppress},
+			}},
+		},
+		{
+			name: "event not at the start of a month",
+			cfg: &PtpLeapSecondsConfig{Events: []PtpLeapEvent{
+				{Time: metav1.NewTime(time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)), Action: LeapEventInsert},
+			}},
+			errMsg: "leapSeconds events[0] time 2026-12-31T00:00:00Z must be 00:00:00 UTC of the first day of a month",
+		},
+		{
+			name: "duplicated event",
+			cfg: &PtpLeapSecondsConfig{Events: []PtpLeapEvent{
+				{Time: metav1.NewTime(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)), Action: LeapEventInsert},
+				{Time: metav1.NewTime(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)), Action: LeapEventDelete},
+			}},
+			errMsg: "leapSeconds events[1] time 2027-01-01T00:00:00Z is duplicated",
+		},
+		{
+			name: "invalid event action",
+			cfg: &PtpLeapSecondsConfig{Events: []PtpLeapEvent{
+				{Time: metav1.NewTime(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)), Action: "Skip"},
+			}},
+			errMsg: "leapSeconds events[0] action 'Skip' is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &PtpOperatorConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "openshift-ptp"},
+				Spec:       PtpOperatorConfigSpec{LeapSeconds: tt.cfg},
+			}
+			err := cfg.validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
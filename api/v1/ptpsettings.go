@@ -0,0 +1,313 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ptpSettingValidator checks the value of a ptpSettings key, interfaces are the interfaces of the profile
+type ptpSettingValidator func(key, value string, interfaces []string) error
+
+// ptpSettingValidators are the configurable ptpSettings keys, apart from the clockId[<interface>] keys
+var ptpSettingValidators = map[string]ptpSettingValidator{
+	"clockType":                validateClockType,
+	"controllingProfile":       validateProfileNames,
+	"haProfiles":               validateProfileNames,
+	"upstreamPort":             validateProfileInterface,
+	"leadingInterface":         validateProfileInterface,
+	"inSyncConditionThreshold": validateUint32Setting,
+	"inSyncConditionTimes":     validateUint32Setting,
+	"logReduce":                validateLogReduce,
+	"stdoutFilter":             validateStdoutFilter,
+	haHysteresisSetting:        validateDurationSetting,
+	haMaxClockClassSetting:     validateUintListSetting,
+	haMaxOffsetSetting:         validateUintListSetting,
+	haPortStatesSetting:        validatePortStates,
+}
+
+// clockIDSettingRegEx matches the clockId keys, optionally naming the interface whose clock ID is set
+var clockIDSettingRegEx = regexp.MustCompile(`^clockId(\[([\w\-.]+)\])?$`)
+
+// validatePtpSettings checks the keys and values of the rendered ptpSettings of a profile
+func validatePtpSettings(settings map[string]string, interfaces []string) error {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := settings[key]
+		if validate, ok := ptpSettingValidators[key]; ok {
+			if err := validate(key, value, interfaces); err != nil {
+				return err
+			}
+			continue
+		}
+		if clockIDSettingRegEx.MatchString(key) {
+			if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+				if _, err := strconv.ParseUint(value, 16, 64); err != nil {
+					return errors.New(key + "='" + value + "' is invalid; must be an unsigned integer")
+				}
+			}
+			continue
+		}
+		return errors.New("profile.PtpSettings '" + key + "' is not a configurable setting")
+	}
+	return nil
+}
+
+func validateClockType(key, value string, _ []string) error {
+	if !slices.Contains(clockTypes, value) {
+		return errors.New(key + "='" + value + "' is invalid; must be one of ['" + strings.Join(clockTypes, "', '") + "']")
+	}
+	return nil
+}
+
+func validateProfileNames(key, value string, _ []string) error {
+	if !profileRegEx.MatchString(value) {
+		return errors.New(key + "='" + value + "' is invalid; must be comma separated profile names")
+	}
+	if key == "controllingProfile" && strings.Contains(value, ",") {
+		return errors.New(key + "='" + value + "' is invalid; must be a single profile name")
+	}
+	return nil
+}
+
+func validateProfileInterface(key, value string, interfaces []string) error {
+	if !slices.Contains(interfaces, value) {
+		return errors.New(key + "='" + value + "' is invalid; must be one of the profile interfaces ['" +
+			strings.Join(interfaces, "', '") + "']")
+	}
+	return nil
+}
+
+func validateUint32Setting(key, value string, _ []string) error {
+	if _, err := strconv.ParseUint(value, 10, 32); err != nil {
+		return errors.New(key + "='" + value + "' is invalid; must be an unsigned integer")
+	}
+	return nil
+}
+
+func validateDurationSetting(key, value string, _ []string) error {
+	if duration, err := time.ParseDuration(value); err != nil || duration < 0 {
+		return errors.New(key + "='" + value + "' is invalid; must be a non-negative time duration (e.g. '10s')")
+	}
+	return nil
+}
+
+// validateUintListSetting checks a comma separated list of unsigned integers, whose items may be empty
+func validateUintListSetting(key, value string, _ []string) error {
+	for _, item := range strings.Split(value, ",") {
+		if item == "" {
+			continue
+		}
+		if _, err := strconv.ParseUint(item, 10, 63); err != nil {
+			return errors.New(key + "='" + value + "' is invalid; must be comma separated unsigned integers")
+		}
+	}
+	return nil
+}
+
+// validatePortStates checks a comma separated list of the port states of each source, separated by '|'
+func validatePortStates(key, value string, _ []string) error {
+	for _, item := range strings.Split(value, ",") {
+		for _, state := range strings.Split(item, portStateSeparator) {
+			if !slices.Contains(ptpPortStates, PtpPortState(state)) {
+				return fmt.Errorf("%s='%s' is invalid; port state '%s' must be one of %v", key, value, state, ptpPortStates)
+			}
+		}
+	}
+	return nil
+}
+
+func validateLogReduce(_, value string, _ []string) error {
+	logReduceMode := "false"
+	logReduceSettings := strings.Fields(value)
+	if len(logReduceSettings) >= 1 {
+		logReduceMode = strings.ToLower(logReduceSettings[0])
+	}
+	if logReduceMode != "true" && logReduceMode != "false" && logReduceMode != "basic" && logReduceMode != "enhanced" {
+		return errors.New("logReduce mode '" + logReduceMode + "' is invalid; mode must be in 'true', 'false, 'basic', or 'enhanced'")
+	}
+	if logReduceMode == "enhanced" {
+		if len(logReduceSettings) >= 2 {
+			if _, err := time.ParseDuration(logReduceSettings[1]); err != nil {
+				return errors.New("logReduce time " + logReduceSettings[1] + "' is invalid; must be a valid time duration (e.g. '30s')")
+			}
+		}
+		if len(logReduceSettings) >= 3 {
+			if threshold, err := strconv.Atoi(logReduceSettings[2]); err != nil || threshold < 0 {
+				return errors.New("logReduce threshold " + logReduceSettings[2] + "' is invalid; must be a non-negative integer")
+			}
+		}
+	}
+	return nil
+}
+
+func validateStdoutFilter(key, value string, _ []string) error {
+	if _, err := regexp.Compile(value); err != nil {
+		return errors.New(key + "='" + value + "' is invalid; " + err.Error())
+	}
+	return nil
+}
+
+// profileInterfaces returns the interfaces of a profile: its interface, the port sections of ptp4lConf and the
+// interface sections of ts2phcConf
+func profileInterfaces(profile *PtpProfile, conf *Ptp4lConf) []string {
+	var interfaces []string
+	add := func(iface string) {
+		if iface != "" && !slices.Contains(interfaces, iface) {
+			interfaces = append(interfaces, iface)
+		}
+	}
+	if profile.Interface != nil {
+		add(*profile.Interface)
+	}
+	for section := range conf.sections {
+		if name := strings.Trim(section, "[]"); isPortSection(name) {
+			add(name)
+		}
+	}
+	ts2phcConf := &Ptp4lConf{}
+	if err := ts2phcConf.PopulatePtp4lConf(profile.Ts2PhcConf, nil); err == nil {
+		for section := range ts2phcConf.sections {
+			if name := strings.Trim(section, "[]"); name != "global" && name != "nmea" {
+				add(name)
+			}
+		}
+	}
+	sort.Strings(interfaces)
+	return interfaces
+}
+
+// profileReferences returns the profiles referenced by the controllingProfile and haProfiles ptpSettings
+func profileReferences(settings map[string]string) []string {
+	var references []string
+	for _, setting := range profileReferenceSettings {
+		if value := settings[setting]; value != "" {
+			for _, reference := range strings.Split(value, ",") {
+				references = append(references, strings.TrimSpace(reference))
+			}
+		}
+	}
+	return references
+}
+
+// RenderPtpSettings returns the profile ptpSettings with the typed settings and the high availability sources
+// rendered. It fails when a setting is set both as a typed setting and in ptpSettings.
+func RenderPtpSettings(profile *PtpProfile) (map[string]string, error) {
+	if profile.Settings == nil && profile.HighAvailability == nil {
+		return profile.PtpSettings, nil
+	}
+	typed := map[string]string{}
+	if profile.Settings != nil {
+		var err error
+		if typed, err = profile.Settings.render(); err != nil {
+			return nil, err
+		}
+	}
+	if profile.HighAvailability != nil {
+		if _, ok := typed["haProfiles"]; ok {
+			return nil, errors.New("highAvailability and settings.haProfiles must not both be set")
+		}
+		for key, value := range profile.HighAvailability.render() {
+			typed[key] = value
+		}
+	}
+
+	rendered := make(map[string]string, len(profile.PtpSettings)+len(typed))
+	for key, value := range profile.PtpSettings {
+		rendered[key] = value
+	}
+	keys := make([]string, 0, len(typed))
+	for key := range typed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, ok := rendered[key]; ok {
+			field := "settings." + key
+			if slices.Contains(highAvailabilitySettings, key) && profile.HighAvailability != nil {
+				field = "highAvailability"
+			}
+			return nil, fmt.Errorf("%s and ptpSettings %s must not both be set", field, key)
+		}
+		rendered[key] = typed[key]
+	}
+	return rendered, nil
+}
+
+// render returns the ptpSettings of the typed settings
+func (s *PtpProfileSettings) render() (map[string]string, error) {
+	settings := map[string]string{}
+	set := func(key, value string) {
+		if value != "" {
+			settings[key] = value
+		}
+	}
+	set("clockType", s.ClockType)
+	set("controllingProfile", s.ControllingProfile)
+	set("haProfiles", strings.Join(s.HAProfiles, ","))
+	set("upstreamPort", s.UpstreamPort)
+	set("leadingInterface", s.LeadingInterface)
+	if s.InSyncConditionThreshold != nil {
+		set("inSyncConditionThreshold", strconv.FormatInt(*s.InSyncConditionThreshold, 10))
+	}
+	if s.InSyncConditionTimes != nil {
+		set("inSyncConditionTimes", strconv.FormatInt(*s.InSyncConditionTimes, 10))
+	}
+	if s.LogReduce != nil {
+		logReduce, err := s.LogReduce.render()
+		if err != nil {
+			return nil, err
+		}
+		set("logReduce", logReduce)
+	}
+	switch len(s.StdoutFilters) {
+	case 0:
+	case 1:
+		set("stdoutFilter", s.StdoutFilters[0])
+	default:
+		filters := make([]string, len(s.StdoutFilters))
+		for i, filter := range s.StdoutFilters {
+			filters[i] = "(?:" + filter + ")"
+		}
+		set("stdoutFilter", strings.Join(filters, "|"))
+	}
+	return settings, nil
+}
+
+// render returns the logReduce setting: the mode, followed by the interval and threshold in Enhanced mode
+func (l *PtpLogReduce) render() (string, error) {
+	if l.Mode != LogReduceEnhanced && (l.Interval != nil || l.Threshold != nil) {
+		return "", errors.New("settings.logReduce interval and threshold only apply to the Enhanced mode")
+	}
+	switch l.Mode {
+	case LogReduceOff:
+		return "false", nil
+	case LogReduceBasic:
+		return "basic", nil
+	case LogReduceEnhanced:
+	default:
+		return "", fmt.Errorf("settings.logReduce.mode '%s' is invalid; must be one of [%s %s %s]",
+			l.Mode, LogReduceOff, LogReduceBasic, LogReduceEnhanced)
+	}
+	value := "enhanced"
+	if l.Interval != nil {
+		value += " " + l.Interval.Duration.String()
+	}
+	if l.Threshold != nil {
+		if l.Interval == nil {
+			return "", errors.New("settings.logReduce.threshold requires settings.logReduce.interval")
+		}
+		value += " " + strconv.FormatInt(*l.Threshold, 10)
+	}
+	return value, nil
+}
@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderPtpSettings(t *testing.T) {
+	profile := &PtpProfile{
+		Name:        strPtr("tbc"),
+		PtpSettings: map[string]string{"clockId[ens1f0]": "5799633565432596414"},
+		Settings: &PtpProfileSettings{
+			ClockType:            "T-BC",
+			HAProfiles:           []string{"bc1", "bc2"},
+			UpstreamPort:         "ens1f0",
+			InSyncConditionTimes: int64Ptr(0),
+			LogReduce: &PtpLogReduce{Mode: LogReduceEnhanced,
+				Interval: &metav1.Duration{Duration: 30 * time.Second}, Threshold: int64Ptr(100)},
+			StdoutFilters: []string{"^.*delay   filtered.*$", "rms"},
+		},
+	}
+	settings, err := RenderPtpSettings(profile)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"clockId[ens1f0]":      "5799633565432596414",
+		"clockType":            "T-BC",
+		"haProfiles":           "bc1,bc2",
+		"upstreamPort":         "ens1f0",
+		"inSyncConditionTimes": "0",
+		"logReduce":            "enhanced 30s 100",
+		"stdoutFilter":         "(?:^.*delay   filtered.*$)|(?:rms)",
+	}, settings)
+	assert.Len(t, profile.PtpSettings, 1, "the profile ptpSettings must not be modified")
+
+	tests := []struct {
+		name     string
+		settings PtpProfileSettings
+		errMsg   string
+	}{
+		{"set twice", PtpProfileSettings{ClockType: "T-GM"}, "settings.clockType and ptpSettings clockType must not both be set"},
+		{"unknown log reduce mode", PtpProfileSettings{LogReduce: &PtpLogReduce{Mode: "true"}},
+			"settings.logReduce.mode 'true' is invalid"},
+		{"interval in basic mode", PtpProfileSettings{LogReduce: &PtpLogReduce{Mode: LogReduceBasic,
+			Interval: &metav1.Duration{Duration: time.Minute}}}, "only apply to the Enhanced mode"},
+		{"threshold without interval", PtpProfileSettings{LogReduce: &PtpLogReduce{Mode: LogReduceEnhanced,
+			Threshold: int64Ptr(10)}}, "settings.logReduce.threshold requires settings.logReduce.interval"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := &PtpProfile{PtpSettings: map[string]string{"clockType": "T-GM"}, Settings: &tt.settings}
+			_, err := RenderPtpSettings(profile)
+			assert.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
+
+func TestValidatePtpSettings(t *testing.T) {
+	interfaces := []string{"ens1f0", "ens1f1"}
+	tests := []struct {
+		name     string
+		settings map[string]string
+		errMsg   string
+	}{
+		{"valid", map[string]string{"clockType": "T-BC", "upstreamPort": "ens1f0", "leadingInterface": "ens1f1",
+			"controllingProfile": "tbc_tr", "haProfiles": "bc1, bc2", "logReduce": "enhanced 5s 100",
+			"clockId[ens1f0]": "5c7a", "clockId": "42", "inSyncConditionThreshold": "10"}, ""},
+		{"unknown setting", map[string]string{"logReduced": "true"}, "profile.PtpSettings 'logReduced' is not a configurable setting"},
+		{"clockId in another key", map[string]string{"myclockIdSetting": "1"}, "'myclockIdSetting' is not a configurable setting"},
+		{"invalid clockId", map[string]string{"clockId[ens1f0]": "clock"}, "clockId[ens1f0]='clock' is invalid"},
+		{"unknown upstream port", map[string]string{"upstreamPort": "ens2f0"},
+			"upstreamPort='ens2f0' is invalid; must be one of the profile interfaces ['ens1f0', 'ens1f1']"},
+		{"unknown leading interface", map[string]string{"leadingInterface": "ens2f0"}, "leadingInterface='ens2f0' is invalid"},
+		{"several controlling profiles", map[string]string{"controllingProfile": "tr1,tr2"}, "must be a single profile name"},
+		{"invalid ha profiles", map[string]string{"haProfiles": "bc1;bc2"}, "haProfiles='bc1;bc2' is invalid"},
+		{"invalid log reduce", map[string]string{"logReduce": "enhanced 5"}, "logReduce time 5' is invalid"},
+		{"invalid stdout filter", map[string]string{"stdoutFilter": "("}, "stdoutFilter='(' is invalid"},
+		{"valid ha criteria", map[string]string{"haProfiles": "bc1,bc2", "haHysteresis": "10s", "haMaxClockClass": "7,",
+			"haMaxOffset": ",100", "haPortStates": "TimeReceiver,TimeReceiver|Uncalibrated"}, ""},
+		{"negative ha hysteresis", map[string]string{"haHysteresis": "-1s"}, "haHysteresis='-1s' is invalid"},
+		{"invalid ha clock class", map[string]string{"haMaxClockClass": "7,six"}, "haMaxClockClass='7,six' is invalid"},
+		{"invalid ha port state", map[string]string{"haPortStates": "TimeReceiver|SLAVE"},
+			"haPortStates='TimeReceiver|SLAVE' is invalid; port state 'SLAVE' must be one of"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePtpSettings(tt.settings, interfaces)
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestProfileInterfaces(t *testing.T) {
+	profile := &PtpProfile{
+		Interface:  strPtr("ens3f0"),
+		Ts2PhcConf: strPtr("[nmea]\nts2phc.master 1\n[global]\nuse_syslog 0\n[ens1f0]\nts2phc.extts_polarity rising"),
+	}
+	conf := &Ptp4lConf{}
+	assert.NoError(t, conf.PopulatePtp4lConf(strPtr("[global]\n[unicast_master_table]\n[ens1f0]\n[ens1f1]"), nil))
+	assert.Equal(t, []string{"ens1f0", "ens1f1", "ens3f0"}, profileInterfaces(profile, conf))
+}
+
+func TestValidateProfileReferences(t *testing.T) {
+	cfg := &PtpConfig{Spec: PtpConfigSpec{Profile: []PtpProfile{
+		{Name: strPtr("tr"), Ptp4lConf: strPtr("[ens1f0]\nserverOnly 0")},
+		{Name: strPtr("tt"), Ptp4lConf: strPtr("[ens1f1]\nserverOnly 1"),
+			Settings: &PtpProfileSettings{ControllingProfile: "tr", UpstreamPort: "ens1f1"}},
+	}}}
+	cfg.Name = "tbc"
+	assert.NoError(t, cfg.validate())
+
+	cfg.Spec.Profile[1].Settings.ControllingProfile = "tbc_tr"
+	assert.NoError(t, cfg.validate())
+
+	// without a webhook client only the profiles of the PtpConfig are known
+	cfg.Spec.Profile[1].Settings.ControllingProfile = "other-tr"
+	assert.EqualError(t, cfg.validate(), "profile 'other-tr' referenced in ptpSettings is not a profile of any PtpConfig")
+
+	cfg.Spec.Profile[1].Settings.ControllingProfile = "tr"
+	cfg.Spec.Profile[1].Settings.UpstreamPort = "ens1f0"
+	assert.ErrorContains(t, cfg.validate(), "upstreamPort='ens1f0' is invalid; must be one of the profile interfaces ['ens1f1']")
+}
@@ -0,0 +1,264 @@
+package v1
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const securityAssociationSection = "security_association"
+
+// macAlgorithm describes the key and integrity check value sizes of a MAC algorithm supported by linuxptp
+type macAlgorithm struct {
+	minKeySize int
+	maxKeySize int
+	icvSize    int
+}
+
+var macAlgorithms = map[string]macAlgorithm{
+	"AES128":     {minKeySize: 16, maxKeySize: 16, icvSize: 16},
+	"AES256":     {minKeySize: 32, maxKeySize: 32, icvSize: 16},
+	"SHA256-128": {minKeySize: 1, maxKeySize: 64, icvSize: 16},
+	"SHA256":     {minKeySize: 1, maxKeySize: 64, icvSize: 32},
+}
+
+// SecurityAssociation is a [security_association] block of a security association file
+type SecurityAssociation struct {
+	SPP  int64
+	Keys []SecurityAssociationKey
+	// Line is the line number of the block header
+	Line int
+}
+
+// SecurityAssociationKey is a key of a security association
+type SecurityAssociationKey struct {
+	ID        int64
+	Algorithm string
+	// Value is the key as written in the file, with its encoding prefix
+	Value string
+	// Size is the decoded key size in bytes
+	Size int
+	// ICVLength is the integrity check value length, 0 for the algorithm default
+	ICVLength int
+	Line      int
+}
+
+// Key returns the key with the given ID, or nil if the security association has none
+func (sa *SecurityAssociation) Key(id int64) *SecurityAssociationKey {
+	for i := range sa.Keys {
+		if sa.Keys[i].ID == id {
+			return &sa.Keys[i]
+		}
+	}
+	return nil
+}
+
+// ParseSaFile parses and validates the contents of a linuxptp security association file: every
+// [security_association] block must set a unique spp and hold at least one key, key IDs must be unique
+// within a block, and each key must be a valid encoding of a key of the size required by its MAC algorithm.
+func ParseSaFile(content string) ([]SecurityAssociation, error) {
+	var sas []SecurityAssociation
+	var current *SecurityAssociation
+	spps := make(map[int64]int)
+
+	closeBlock := func() error {
+		if current == nil {
+			return nil
+		}
+		if current.SPP < 0 {
+			return fmt.Errorf("line %d: security association has no spp", current.Line)
+		}
+		if len(current.Keys) == 0 {
+			return fmt.Errorf("line %d: security association spp %d has no keys", current.Line, current.SPP)
+		}
+		sas = append(sas, *current)
+		return nil
+	}
+
+	for i, line := range strings.Split(content, "\n") {
+		lineNumber := i + 1
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		if name, ok := parseSectionName(line); ok {
+			if name != securityAssociationSection {
+				return nil, fmt.Errorf("line %d: unsupported section [%s]", lineNumber, name)
+			}
+			if err := closeBlock(); err != nil {
+				return nil, err
+			}
+			current = &SecurityAssociation{SPP: -1, Line: lineNumber}
+			continue
+		}
+		if current == nil {
+			return nil, fmt.Errorf("line %d: '%s' is outside of a [%s] section", lineNumber, strings.TrimSpace(line), securityAssociationSection)
+		}
+
+		if fields[0] == "spp" {
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("line %d: spp takes exactly one value", lineNumber)
+			}
+			if current.SPP >= 0 {
+				return nil, fmt.Errorf("line %d: spp is set more than once in the security association", lineNumber)
+			}
+			spp, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil || spp < 0 || spp > 255 {
+				return nil, fmt.Errorf("line %d: spp must be between 0 and 255, got '%s'", lineNumber, fields[1])
+			}
+			if previous, exists := spps[spp]; exists {
+				return nil, fmt.Errorf("line %d: spp %d is already defined on line %d", lineNumber, spp, previous)
+			}
+			spps[spp] = lineNumber
+			current.SPP = spp
+			continue
+		}
+
+		key, err := parseSecurityAssociationKey(fields, lineNumber)
+		if err != nil {
+			return nil, err
+		}
+		if previous := current.Key(key.ID); previous != nil {
+			return nil, fmt.Errorf("line %d: key ID %d is already defined on line %d", lineNumber, key.ID, previous.Line)
+		}
+		current.Keys = append(current.Keys, *key)
+	}
+
+	if err := closeBlock(); err != nil {
+		return nil, err
+	}
+	if len(sas) == 0 {
+		return nil, fmt.Errorf("no [%s] section found", securityAssociationSection)
+	}
+	return sas, nil
+}
+
+// parseSecurityAssociationKey parses a key line: <key ID> <MAC algorithm> <key> [ICV length]
+func parseSecurityAssociationKey(fields []string, lineNumber int) (*SecurityAssociationKey, error) {
+	id, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: unsupported option '%s'", lineNumber, fields[0])
+	}
+	if len(fields) < 3 || len(fields) > 4 {
+		return nil, fmt.Errorf("line %d: key must be '<key ID> <algorithm> <key> [ICV length]'", lineNumber)
+	}
+	if id < 1 || id > 4294967295 {
+		return nil, fmt.Errorf("line %d: key ID must be between 1 and 4294967295, got %d", lineNumber, id)
+	}
+	algorithm, ok := macAlgorithms[fields[1]]
+	if !ok {
+		return nil, fmt.Errorf("line %d: key ID %d has unsupported MAC algorithm '%s'", lineNumber, id, fields[1])
+	}
+	value, err := decodeSecurityAssociationKey(fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("line %d: key ID %d: %w", lineNumber, id, err)
+	}
+	if len(value) < algorithm.minKeySize || len(value) > algorithm.maxKeySize {
+		if algorithm.minKeySize == algorithm.maxKeySize {
+			return nil, fmt.Errorf("line %d: key ID %d: %s key must be %d bytes, got %d",
+				lineNumber, id, fields[1], algorithm.minKeySize, len(value))
+		}
+		return nil, fmt.Errorf("line %d: key ID %d: %s key must be between %d and %d bytes, got %d",
+			lineNumber, id, fields[1], algorithm.minKeySize, algorithm.maxKeySize, len(value))
+	}
+
+	key := &SecurityAssociationKey{ID: id, Algorithm: fields[1], Value: fields[2], Size: len(value), Line: lineNumber}
+	if len(fields) == 4 {
+		icv, err := strconv.Atoi(fields[3])
+		if err != nil || icv < 1 || icv > algorithm.icvSize {
+			return nil, fmt.Errorf("line %d: key ID %d: ICV length must be between 1 and %d, got '%s'",
+				lineNumber, id, algorithm.icvSize, fields[3])
+		}
+		key.ICVLength = icv
+	}
+	return key, nil
+}
+
+// decodeSecurityAssociationKey decodes a key with a HEX:, B64: or ASCII: prefix, or plain ASCII without prefix
+func decodeSecurityAssociationKey(value string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(value, "HEX:"):
+		key, err := hex.DecodeString(strings.TrimPrefix(value, "HEX:"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex key: %v", err)
+		}
+		return key, nil
+	case strings.HasPrefix(value, "B64:"):
+		key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, "B64:"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 key: %v", err)
+		}
+		return key, nil
+	default:
+		key := strings.TrimPrefix(value, "ASCII:")
+		for _, c := range key {
+			if c < 0x21 || c > 0x7e {
+				return nil, fmt.Errorf("ASCII key contains a non printable character")
+			}
+		}
+		return []byte(key), nil
+	}
+}
+
+// ValidateProfileSaFile checks the security association file of a profile defines the spp and the
+// active key ID of every authenticated port of the profile
+func ValidateProfileSaFile(profile *PtpProfile, saFile string) error {
+	sas, err := ParseSaFile(saFile)
+	if err != nil {
+		return err
+	}
+
+	conf := &Ptp4lConf{}
+	if profile.Authentication != nil {
+		rendered, err := RenderPtp4lAuthentication(profile)
+		if err != nil {
+			return err
+		}
+		conf.PopulatePtp4lConf(&rendered, profile.Ptp4lOpts)
+	} else {
+		conf.PopulatePtp4lConf(profile.Ptp4lConf, profile.Ptp4lOpts)
+	}
+	globalKeyID := conf.GetOption("[global]", "active_key_id")
+
+	for sectionName, section := range conf.sections {
+		if name, _ := parseSectionName(sectionName); !isPortSection(name) {
+			continue
+		}
+		sppValue, hasSpp := section.options["spp"]
+		if !hasSpp {
+			continue
+		}
+		spp, err := strconv.ParseInt(sppValue, 10, 64)
+		if err != nil {
+			return fmt.Errorf("interface %s: invalid spp '%s'", sectionName, sppValue)
+		}
+		var sa *SecurityAssociation
+		for i := range sas {
+			if sas[i].SPP == spp {
+				sa = &sas[i]
+			}
+		}
+		if sa == nil {
+			return fmt.Errorf("interface %s: spp %d is not defined in the security association file", sectionName, spp)
+		}
+
+		keyIDValue, hasKeyID := section.options["active_key_id"]
+		if !hasKeyID {
+			keyIDValue = globalKeyID
+		}
+		if keyIDValue == "" {
+			continue
+		}
+		keyID, err := strconv.ParseInt(keyIDValue, 10, 64)
+		if err != nil {
+			return fmt.Errorf("interface %s: invalid active_key_id '%s'", sectionName, keyIDValue)
+		}
+		if sa.Key(keyID) == nil {
+			return fmt.Errorf("interface %s: active key ID %d is not defined for spp %d in the security association file", sectionName, keyID, spp)
+		}
+	}
+	return nil
+}
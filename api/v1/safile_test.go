@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const validSaFile = `# site keys
+[security_association]
+spp 1
+1 AES128 HEX:000102030405060708090a0b0c0d0e0f
+2 SHA256-128 B64:c2VjcmV0LWtleQ==
+[security_association]
+spp 3
+2 SHA256 ASCII:secret-key 16
+`
+
+func TestParseSaFile(t *testing.T) {
+	sas, err := ParseSaFile(validSaFile)
+	assert.NoError(t, err)
+	if !assert.Len(t, sas, 2) {
+		return
+	}
+	assert.Equal(t, int64(1), sas[0].SPP)
+	assert.Len(t, sas[0].Keys, 2)
+	assert.Equal(t, 16, sas[0].Keys[0].Size)
+	assert.Equal(t, 10, sas[0].Key(2).Size)
+	assert.Nil(t, sas[0].Key(3))
+	assert.Equal(t, int64(3), sas[1].SPP)
+	assert.Equal(t, 16, sas[1].Keys[0].ICVLength)
+	assert.Equal(t, "ASCII:secret-key", sas[1].Keys[0].Value)
+}
+
+func TestParseSaFile_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{
+			name:          "empty",
+			content:       "# nothing\n",
+			expectedError: "no [security_association] section found",
+		},
+		{
+			name:          "line outside of a section",
+			content:       "spp 1\n[security_association]\nspp 1\n1 SHA256 key",
+			expectedError: "line 1: 'spp 1' is outside of a [security_association] section",
+		},
+		{
+			name:          "unsupported section",
+			content:       "[global]\nspp 1",
+			expectedError: "line 1: unsupported section [global]",
+		},
+		{
+			name:          "missing spp",
+			content:       "[security_association]\n1 SHA256 key",
+			expectedError: "line 1: security association has no spp",
+		},
+		{
+			name:          "spp out of range",
+			content:       "[security_association]\nspp 256\n1 SHA256 key",
+			expectedError: "line 2: spp must be between 0 and 255, got '256'",
+		},
+		{
+			name:          "duplicate spp",
+			content:       "[security_association]\nspp 1\n1 SHA256 key\n[security_association]\nspp 1\n1 SHA256 key",
+			expectedError: "line 5: spp 1 is already defined on line 2",
+		},
+		{
+			name:          "spp set twice in a block",
+			content:       "[security_association]\nspp 1\nspp 2\n1 SHA256 key",
+			expectedError: "line 3: spp is set more than once in the security association",
+		},
+		{
+			name:          "no keys",
+			content:       "[security_association]\nspp 1\n[security_association]\nspp 2\n1 SHA256 key",
+			expectedError: "line 1: security association spp 1 has no keys",
+		},
+		{
+			name:          "duplicate key ID",
+			content:       "[security_association]\nspp 1\n1 SHA256 key\n1 SHA256 other",
+			expectedError: "line 4: key ID 1 is already defined on line 3",
+		},
+		{
+			name:          "key ID out of range",
+			content:       "[security_association]\nspp 1\n0 SHA256 key",
+			expectedError: "line 3: key ID must be between 1 and 4294967295, got 0",
+		},
+		{
+			name:          "unsupported algorithm",
+			content:       "[security_association]\nspp 1\n1 MD5 key",
+			expectedError: "line 3: key ID 1 has unsupported MAC algorithm 'MD5'",
+		},
+		{
+			name:          "unsupported option",
+			content:       "[security_association]\nspp 1\nseqid_window 4",
+			expectedError: "line 3: unsupported option 'seqid_window'",
+		},
+		{
+			name:          "missing key",
+			content:       "[security_association]\nspp 1\n1 SHA256",
+			expectedError: "line 3: key must be '<key ID> <algorithm> <key> [ICV length]'",
+		},
+		{
+			name:          "invalid hex",
+			content:       "[security_association]\nspp 1\n1 SHA256 HEX:xyz",
+			expectedError: "line 3: key ID 1: invalid hex key",
+		},
+		{
+			name:          "invalid base64",
+			content:       "[security_association]\nspp 1\n1 SHA256 B64:***",
+			expectedError: "line 3: key ID 1: invalid base64 key",
+		},
+		{
+			name:          "wrong AES key size",
+			content:       "[security_association]\nspp 1\n1 AES256 HEX:0001",
+			expectedError: "line 3: key ID 1: AES256 key must be 32 bytes, got 2",
+		},
+		{
+			name:          "HMAC key too long",
+			content:       "[security_association]\nspp 1\n1 SHA256 ASCII:" + strings.Repeat("k", 65),
+			expectedError: "line 3: key ID 1: SHA256 key must be between 1 and 64 bytes, got 65",
+		},
+		{
+			name:          "ICV length too long",
+			content:       "[security_association]\nspp 1\n1 SHA256-128 key 32",
+			expectedError: "line 3: key ID 1: ICV length must be between 1 and 16, got '32'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSaFile(tt.content)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateProfileSaFile(t *testing.T) {
+	auth := makeAuthentication()
+	auth.Ports = []PtpPortAuthentication{{Interface: "ens2f0", SPP: int64Ptr(3)}}
+	profile := makeAuthProfile("[global]\n[ens1f0]\n[ens2f0]", auth)
+	assert.NoError(t, ValidateProfileSaFile(profile, validSaFile))
+
+	// the active key is not defined for spp 3
+	auth.ActiveKeyID = 1
+	err := ValidateProfileSaFile(profile, validSaFile)
+	if assert.Error(t, err) {
+		assert.Equal(t, "interface [ens2f0]: active key ID 1 is not defined for spp 3 in the security association file", err.Error())
+	}
+
+	// spp is not defined
+	auth.ActiveKeyID = 2
+	auth.Ports[0].SPP = int64Ptr(4)
+	err = ValidateProfileSaFile(profile, validSaFile)
+	if assert.Error(t, err) {
+		assert.Equal(t, "interface [ens2f0]: spp 4 is not defined in the security association file", err.Error())
+	}
+
+	// manual ptp4lConf authentication options
+	manual := makeAuthProfile("[global]\nsa_file /etc/ptp-secret-mount/ptp-security/sa_file.conf\nspp -1\n"+
+		"[ens1f0]\nspp 1\nactive_key_id 1", nil)
+	assert.NoError(t, ValidateProfileSaFile(manual, validSaFile))
+}
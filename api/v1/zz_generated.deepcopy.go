@@ -21,9 +21,11 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -41,6 +43,36 @@ func (in *BaseBoardInfo) DeepCopy() *BaseBoardInfo {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventResourceSubscription) DeepCopyInto(out *EventResourceSubscription) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventResourceSubscription.
+func (in *EventResourceSubscription) DeepCopy() *EventResourceSubscription {
+	if in == nil {
+		return nil
+	}
+	out := new(EventResourceSubscription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrandmasterSettings) DeepCopyInto(out *GrandmasterSettings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrandmasterSettings.
+func (in *GrandmasterSettings) DeepCopy() *GrandmasterSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(GrandmasterSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HardwareInfo) DeepCopyInto(out *HardwareInfo) {
 	*out = *in
@@ -76,6 +108,30 @@ func (in *HwConfig) DeepCopy() *HwConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeapSecondsStatus) DeepCopyInto(out *LeapSecondsStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	in.Expires.DeepCopyInto(&out.Expires)
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeLeapSeconds, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeapSecondsStatus.
+func (in *LeapSecondsStatus) DeepCopy() *LeapSecondsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LeapSecondsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MatchRule) DeepCopyInto(out *MatchRule) {
 	*out = *in
@@ -101,6 +157,89 @@ func (in *MatchRule) DeepCopy() *MatchRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAuthenticationStatus) DeepCopyInto(out *NodeAuthenticationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAuthenticationStatus.
+func (in *NodeAuthenticationStatus) DeepCopy() *NodeAuthenticationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAuthenticationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeEventSubscription) DeepCopyInto(out *NodeEventSubscription) {
+	*out = *in
+	if in.Subscriptions != nil {
+		in, out := &in.Subscriptions, &out.Subscriptions
+		*out = make([]EventResourceSubscription, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeEventSubscription.
+func (in *NodeEventSubscription) DeepCopy() *NodeEventSubscription {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeEventSubscription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeHighAvailabilityStatus) DeepCopyInto(out *NodeHighAvailabilityStatus) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MissingSources != nil {
+		in, out := &in.MissingSources, &out.MissingSources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSwitchover != nil {
+		in, out := &in.LastSwitchover, &out.LastSwitchover
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHighAvailabilityStatus.
+func (in *NodeHighAvailabilityStatus) DeepCopy() *NodeHighAvailabilityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeHighAvailabilityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeLeapSeconds) DeepCopyInto(out *NodeLeapSeconds) {
+	*out = *in
+	if in.NextLeapSecond != nil {
+		in, out := &in.NextLeapSecond, &out.NextLeapSecond
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLeapSeconds.
+func (in *NodeLeapSeconds) DeepCopy() *NodeLeapSeconds {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeLeapSeconds)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeMatchList) DeepCopyInto(out *NodeMatchList) {
 	*out = *in
@@ -126,6 +265,26 @@ func (in *NodeMatchList) DeepCopy() *NodeMatchList {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePlugins) DeepCopyInto(out *NodePlugins) {
+	*out = *in
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePlugins.
+func (in *NodePlugins) DeepCopy() *NodePlugins {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePlugins)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePtpDevice) DeepCopyInto(out *NodePtpDevice) {
 	*out = *in
@@ -227,6 +386,18 @@ func (in *NodePtpDeviceStatus) DeepCopyInto(out *NodePtpDeviceStatus) {
 		*out = new(BaseBoardInfo)
 		**out = **in
 	}
+	if in.HighAvailability != nil {
+		in, out := &in.HighAvailability, &out.HighAvailability
+		*out = make([]PhcHighAvailabilityState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GrandmasterSettings != nil {
+		in, out := &in.GrandmasterSettings, &out.GrandmasterSettings
+		*out = make([]GrandmasterSettings, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePtpDeviceStatus.
@@ -239,6 +410,82 @@ func (in *NodePtpDeviceStatus) DeepCopy() *NodePtpDeviceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhcHighAvailability) DeepCopyInto(out *PhcHighAvailability) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]PhcHighAvailabilitySource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hysteresis != nil {
+		in, out := &in.Hysteresis, &out.Hysteresis
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhcHighAvailability.
+func (in *PhcHighAvailability) DeepCopy() *PhcHighAvailability {
+	if in == nil {
+		return nil
+	}
+	out := new(PhcHighAvailability)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhcHighAvailabilitySource) DeepCopyInto(out *PhcHighAvailabilitySource) {
+	*out = *in
+	if in.MaxClockClass != nil {
+		in, out := &in.MaxClockClass, &out.MaxClockClass
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxOffset != nil {
+		in, out := &in.MaxOffset, &out.MaxOffset
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PortStates != nil {
+		in, out := &in.PortStates, &out.PortStates
+		*out = make([]PtpPortState, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhcHighAvailabilitySource.
+func (in *PhcHighAvailabilitySource) DeepCopy() *PhcHighAvailabilitySource {
+	if in == nil {
+		return nil
+	}
+	out := new(PhcHighAvailabilitySource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhcHighAvailabilityState) DeepCopyInto(out *PhcHighAvailabilityState) {
+	*out = *in
+	if in.LastSwitchover != nil {
+		in, out := &in.LastSwitchover, &out.LastSwitchover
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhcHighAvailabilityState.
+func (in *PhcHighAvailabilityState) DeepCopy() *PhcHighAvailabilityState {
+	if in == nil {
+		return nil
+	}
+	out := new(PhcHighAvailabilityState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProcessDowntimeThresholds) DeepCopyInto(out *ProcessDowntimeThresholds) {
 	*out = *in
@@ -333,6 +580,29 @@ func (in *Ptp4lConfSection) DeepCopy() *Ptp4lConfSection {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PtpAuthentication) DeepCopyInto(out *PtpAuthentication) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]PtpPortAuthentication, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpAuthentication.
+func (in *PtpAuthentication) DeepCopy() *PtpAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(PtpAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PtpClockThreshold) DeepCopyInto(out *PtpClockThreshold) {
 	*out = *in
@@ -412,6 +682,28 @@ func (in *PtpConfigList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PtpConfigNamespacePolicy) DeepCopyInto(out *PtpConfigNamespacePolicy) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpConfigNamespacePolicy.
+func (in *PtpConfigNamespacePolicy) DeepCopy() *PtpConfigNamespacePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PtpConfigNamespacePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PtpConfigSpec) DeepCopyInto(out *PtpConfigSpec) {
 	*out = *in
@@ -458,6 +750,18 @@ func (in *PtpConfigStatus) DeepCopyInto(out *PtpConfigStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = make([]NodeAuthenticationStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.HighAvailability != nil {
+		in, out := &in.HighAvailability, &out.HighAvailability
+		*out = make([]NodeHighAvailabilityStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpConfigStatus.
@@ -471,56 +775,398 @@ func (in *PtpConfigStatus) DeepCopy() *PtpConfigStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PtpDevice) DeepCopyInto(out *PtpDevice) {
+func (in *PtpDaemonContainerConfig) DeepCopyInto(out *PtpDaemonContainerConfig) {
 	*out = *in
-	if in.HardwareInfo != nil {
-		in, out := &in.HardwareInfo, &out.HardwareInfo
-		*out = new(HardwareInfo)
-		**out = **in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpDevice.
-func (in *PtpDevice) DeepCopy() *PtpDevice {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpDaemonContainerConfig.
+func (in *PtpDaemonContainerConfig) DeepCopy() *PtpDaemonContainerConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PtpDevice)
+	out := new(PtpDaemonContainerConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PtpEventConfig) DeepCopyInto(out *PtpEventConfig) {
+func (in *PtpDaemonPool) DeepCopyInto(out *PtpDaemonPool) {
 	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EnabledPlugins != nil {
+		in, out := &in.EnabledPlugins, &out.EnabledPlugins
+		*out = new(map[string]*apiextensionsv1.JSON)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make(map[string]*apiextensionsv1.JSON, len(*in))
+			for key, val := range *in {
+				var outVal *apiextensionsv1.JSON
+				if val == nil {
+					(*out)[key] = nil
+				} else {
+					inVal := (*in)[key]
+					in, out := &inVal, &outVal
+					*out = new(apiextensionsv1.JSON)
+					(*in).DeepCopyInto(*out)
+				}
+				(*out)[key] = outVal
+			}
+		}
+	}
+	if in.EventConfig != nil {
+		in, out := &in.EventConfig, &out.EventConfig
+		*out = new(PtpEventConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DaemonSet != nil {
+		in, out := &in.DaemonSet, &out.DaemonSet
+		*out = new(PtpDaemonSetConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpEventConfig.
-func (in *PtpEventConfig) DeepCopy() *PtpEventConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpDaemonPool.
+func (in *PtpDaemonPool) DeepCopy() *PtpDaemonPool {
 	if in == nil {
 		return nil
 	}
-	out := new(PtpEventConfig)
+	out := new(PtpDaemonPool)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PtpOperatorConfig) DeepCopyInto(out *PtpOperatorConfig) {
+func (in *PtpDaemonSetConfig) DeepCopyInto(out *PtpDaemonSetConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpOperatorConfig.
-func (in *PtpOperatorConfig) DeepCopy() *PtpOperatorConfig {
-	if in == nil {
-		return nil
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	out := new(PtpOperatorConfig)
-	in.DeepCopyInto(out)
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LogLevel != nil {
+		in, out := &in.LogLevel, &out.LogLevel
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]PtpDaemonContainerConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpDaemonSetConfig.
+func (in *PtpDaemonSetConfig) DeepCopy() *PtpDaemonSetConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PtpDaemonSetConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PtpDevice) DeepCopyInto(out *PtpDevice) {
+	*out = *in
+	if in.HardwareInfo != nil {
+		in, out := &in.HardwareInfo, &out.HardwareInfo
+		*out = new(HardwareInfo)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpDevice.
+func (in *PtpDevice) DeepCopy() *PtpDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(PtpDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PtpEventConfig) DeepCopyInto(out *PtpEventConfig) {
+	*out = *in
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(PtpEventStorage)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpEventConfig.
+func (in *PtpEventConfig) DeepCopy() *PtpEventConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PtpEventConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PtpEventStorage) DeepCopyInto(out *PtpEventStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpEventStorage.
+func (in *PtpEventStorage) DeepCopy() *PtpEventStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(PtpEventStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PtpEventSubscription) DeepCopyInto(out *PtpEventSubscription) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpEventSubscription.
+func (in *PtpEventSubscription) DeepCopy() *PtpEventSubscription {
+	if in == nil {
+		return nil
+	}
+	out := new(PtpEventSubscription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PtpEventSubscription) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PtpEventSubscriptionList) DeepCopyInto(out *PtpEventSubscriptionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PtpEventSubscription, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpEventSubscriptionList.
+func (in *PtpEventSubscriptionList) DeepCopy() *PtpEventSubscriptionList {
+	if in == nil {
+		return nil
+	}
+	out := new(PtpEventSubscriptionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PtpEventSubscriptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PtpEventSubscriptionSpec) DeepCopyInto(out *PtpEventSubscriptionSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]PtpEventResource, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpEventSubscriptionSpec.
+func (in *PtpEventSubscriptionSpec) DeepCopy() *PtpEventSubscriptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PtpEventSubscriptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PtpEventSubscriptionStatus) DeepCopyInto(out *PtpEventSubscriptionStatus) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeEventSubscription, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpEventSubscriptionStatus.
+func (in *PtpEventSubscriptionStatus) DeepCopy() *PtpEventSubscriptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PtpEventSubscriptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PtpLeapEvent) DeepCopyInto(out *PtpLeapEvent) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpLeapEvent.
+func (in *PtpLeapEvent) DeepCopy() *PtpLeapEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(PtpLeapEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PtpLeapSecondsConfig) DeepCopyInto(out *PtpLeapSecondsConfig) {
+	*out = *in
+	if in.ExpiryWarning != nil {
+		in, out := &in.ExpiryWarning, &out.ExpiryWarning
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]PtpLeapEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpLeapSecondsConfig.
+func (in *PtpLeapSecondsConfig) DeepCopy() *PtpLeapSecondsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PtpLeapSecondsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PtpLogReduce) DeepCopyInto(out *PtpLogReduce) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpLogReduce.
+func (in *PtpLogReduce) DeepCopy() *PtpLogReduce {
+	if in == nil {
+		return nil
+	}
+	out := new(PtpLogReduce)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PtpOperatorConfig) DeepCopyInto(out *PtpOperatorConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpOperatorConfig.
+func (in *PtpOperatorConfig) DeepCopy() *PtpOperatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PtpOperatorConfig)
+	in.DeepCopyInto(out)
 	return out
 }
 
@@ -577,7 +1223,7 @@ func (in *PtpOperatorConfigSpec) DeepCopyInto(out *PtpOperatorConfigSpec) {
 	if in.EventConfig != nil {
 		in, out := &in.EventConfig, &out.EventConfig
 		*out = new(PtpEventConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.EnabledPlugins != nil {
 		in, out := &in.EnabledPlugins, &out.EnabledPlugins
@@ -599,6 +1245,30 @@ func (in *PtpOperatorConfigSpec) DeepCopyInto(out *PtpOperatorConfigSpec) {
 			}
 		}
 	}
+	if in.LeapSeconds != nil {
+		in, out := &in.LeapSeconds, &out.LeapSeconds
+		*out = new(PtpLeapSecondsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PtpConfigNamespaces != nil {
+		in, out := &in.PtpConfigNamespaces, &out.PtpConfigNamespaces
+		*out = make([]PtpConfigNamespacePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DaemonSet != nil {
+		in, out := &in.DaemonSet, &out.DaemonSet
+		*out = new(PtpDaemonSetConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DaemonPools != nil {
+		in, out := &in.DaemonPools, &out.DaemonPools
+		*out = make([]PtpDaemonPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpOperatorConfigSpec.
@@ -614,6 +1284,30 @@ func (in *PtpOperatorConfigSpec) DeepCopy() *PtpOperatorConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PtpOperatorConfigStatus) DeepCopyInto(out *PtpOperatorConfigStatus) {
 	*out = *in
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodePlugins != nil {
+		in, out := &in.NodePlugins, &out.NodePlugins
+		*out = make([]NodePlugins, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LeapSeconds != nil {
+		in, out := &in.LeapSeconds, &out.LeapSeconds
+		*out = new(LeapSecondsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpOperatorConfigStatus.
@@ -626,6 +1320,31 @@ func (in *PtpOperatorConfigStatus) DeepCopy() *PtpOperatorConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PtpPortAuthentication) DeepCopyInto(out *PtpPortAuthentication) {
+	*out = *in
+	if in.SPP != nil {
+		in, out := &in.SPP, &out.SPP
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ActiveKeyID != nil {
+		in, out := &in.ActiveKeyID, &out.ActiveKeyID
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpPortAuthentication.
+func (in *PtpPortAuthentication) DeepCopy() *PtpPortAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(PtpPortAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PtpProfile) DeepCopyInto(out *PtpProfile) {
 	*out = *in
@@ -727,6 +1446,21 @@ func (in *PtpProfile) DeepCopyInto(out *PtpProfile) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(PtpProfileSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HighAvailability != nil {
+		in, out := &in.HighAvailability, &out.HighAvailability
+		*out = new(PhcHighAvailability)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(PtpAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpProfile.
@@ -739,6 +1473,46 @@ func (in *PtpProfile) DeepCopy() *PtpProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PtpProfileSettings) DeepCopyInto(out *PtpProfileSettings) {
+	*out = *in
+	if in.HAProfiles != nil {
+		in, out := &in.HAProfiles, &out.HAProfiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InSyncConditionThreshold != nil {
+		in, out := &in.InSyncConditionThreshold, &out.InSyncConditionThreshold
+		*out = new(int64)
+		**out = **in
+	}
+	if in.InSyncConditionTimes != nil {
+		in, out := &in.InSyncConditionTimes, &out.InSyncConditionTimes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LogReduce != nil {
+		in, out := &in.LogReduce, &out.LogReduce
+		*out = new(PtpLogReduce)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StdoutFilters != nil {
+		in, out := &in.StdoutFilters, &out.StdoutFilters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PtpProfileSettings.
+func (in *PtpProfileSettings) DeepCopy() *PtpProfileSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(PtpProfileSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PtpRecommend) DeepCopyInto(out *PtpRecommend) {
 	*out = *in
@@ -771,6 +1545,41 @@ func (in *PtpRecommend) DeepCopy() *PtpRecommend {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityAssociation) DeepCopyInto(out *SecurityAssociation) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]SecurityAssociationKey, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityAssociation.
+func (in *SecurityAssociation) DeepCopy() *SecurityAssociation {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityAssociation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityAssociationKey) DeepCopyInto(out *SecurityAssociationKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityAssociationKey.
+func (in *SecurityAssociationKey) DeepCopy() *SecurityAssociationKey {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityAssociationKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemInfo) DeepCopyInto(out *SystemInfo) {
 	*out = *in
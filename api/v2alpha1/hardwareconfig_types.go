@@ -3,6 +3,7 @@ package v2alpha1
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -145,6 +146,105 @@ type GNSSConfig struct {
 	// Match defines a mechanism to find a GNSS device on the system.  If omitted, autodetects the best-available GNSS source
 	// +optional
 	Match *GNSSMatcher `json:"match,omitempty" yaml:"match,omitempty"`
+
+	// Receiver describes the GNSS receiver and its receiver-independent settings.
+	// If omitted, a u-blox receiver configured through Init is assumed.
+	// +optional
+	Receiver *GNSSReceiver `json:"receiver,omitempty" yaml:"receiver,omitempty"`
+}
+
+// GNSSReceiverTypeID identifies the protocol used to configure a GNSS receiver
+// +kubebuilder:validation:Enum=ublox;nmea;vendor
+type GNSSReceiverTypeID string
+
+const (
+	// GNSSReceiverUBlox is a u-blox receiver configured with ubxtool
+	GNSSReceiverUBlox GNSSReceiverTypeID = "ublox"
+	// GNSSReceiverNMEA is a receiver that only outputs NMEA sentences and can not be configured
+	GNSSReceiverNMEA GNSSReceiverTypeID = "nmea"
+	// GNSSReceiverVendor is a receiver configured through a vendor binary protocol
+	GNSSReceiverVendor GNSSReceiverTypeID = "vendor"
+)
+
+// LeapSecondSourceID identifies where the UTC-TAI offset and leap second announcements are taken from
+// +kubebuilder:validation:Enum=gnss;leapFile
+type LeapSecondSourceID string
+
+const (
+	// LeapSecondSourceGNSS takes leap second information from the GNSS navigation messages
+	LeapSecondSourceGNSS LeapSecondSourceID = "gnss"
+	// LeapSecondSourceLeapFile takes leap second information from the leap seconds file in leap-configmap
+	LeapSecondSourceLeapFile LeapSecondSourceID = "leapFile"
+)
+
+// GNSSReceiver describes a GNSS receiver and the settings that apply regardless of its configuration protocol.
+// Settings that need to be written to the receiver are only allowed for configurable receiver types.
+type GNSSReceiver struct {
+	// Type is the receiver configuration protocol. Valid values: "ublox", "nmea", "vendor"
+	// +kubebuilder:default=ublox
+	Type GNSSReceiverTypeID `json:"type" yaml:"type"`
+
+	// Model is an optional receiver model name, e.g. "ZED-F9T"
+	// +optional
+	Model string `json:"model,omitempty" yaml:"model,omitempty"`
+
+	// Protocol is the name of the vendor binary protocol (required if the type is set to 'vendor')
+	// +optional
+	Protocol string `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+
+	// AntennaCableDelay is the antenna cable delay compensation in nanoseconds.
+	// It is applied by the receiver when it is configurable, and in software otherwise.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000000
+	// +optional
+	AntennaCableDelay *int64 `json:"antennaCableDelay,omitempty" yaml:"antennaCableDelay,omitempty"`
+
+	// ElevationMask is the minimum satellite elevation in degrees used for the timing solution
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=90
+	// +optional
+	ElevationMask *int64 `json:"elevationMask,omitempty" yaml:"elevationMask,omitempty"`
+
+	// TimePulse configures the receiver time pulse output
+	// +optional
+	TimePulse *GNSSTimePulse `json:"timePulse,omitempty" yaml:"timePulse,omitempty"`
+
+	// LeapSecondSource selects where leap second information is taken from. Valid values: "gnss", "leapFile".
+	// Receivers of type 'nmea' do not provide leap second information and require 'leapFile'.
+	// +optional
+	LeapSecondSource LeapSecondSourceID `json:"leapSecondSource,omitempty" yaml:"leapSecondSource,omitempty"`
+
+	// Commands are raw vendor protocol commands sent to the receiver at initialization
+	// (only allowed if the type is set to 'vendor'; u-blox receivers use init.extraCommands)
+	// +optional
+	Commands []GNSSReceiverCommand `json:"commands,omitempty" yaml:"commands,omitempty"`
+}
+
+// GNSSTimePulse defines the receiver time pulse output
+type GNSSTimePulse struct {
+	// Frequency is the time pulse frequency in Hz. If omitted, set to 1Hz (1PPS). Default: 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Frequency int64 `json:"frequency,omitempty" yaml:"frequency,omitempty"`
+
+	// PulseWidth is the time pulse width in nanoseconds. Must be shorter than the pulse period
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PulseWidth int64 `json:"pulseWidth,omitempty" yaml:"pulseWidth,omitempty"`
+
+	// Polarity is the active edge of the time pulse. Valid values: "rising", "falling". Default: rising
+	// +kubebuilder:validation:Enum=rising;falling
+	// +optional
+	Polarity string `json:"polarity,omitempty" yaml:"polarity,omitempty"`
+}
+
+// GNSSReceiverCommand is a raw command of a vendor binary protocol
+type GNSSReceiverCommand struct {
+	// Args are the command arguments passed to the vendor configuration tool
+	Args []string `json:"args" yaml:"args"`
+
+	// ReportOutput records the resulting output in the object status when true
+	ReportOutput bool `json:"reportOutput,omitempty" yaml:"reportOutput,omitempty"`
 }
 
 // ConstellationID is a single GPS constellation identifier string
@@ -243,6 +343,21 @@ type SourceState struct {
 	ConditionType string `json:"conditionType" yaml:"conditionType"`
 }
 
+// Source condition types that can be referenced by SourceState.ConditionType
+const (
+	// ConditionTypeInit is reported once when a source is initialized
+	ConditionTypeInit = "init"
+	// ConditionTypeDefault is an alias of ConditionTypeInit used by profile (re)load conditions
+	ConditionTypeDefault = "default"
+	// ConditionTypeLocked is reported when a source is locked
+	ConditionTypeLocked = "locked"
+	// ConditionTypeLost is reported when a locked source is lost
+	ConditionTypeLost = "lost"
+)
+
+// DefaultSourceName is the pseudo-source that triggers conditions on profile (re)load
+const DefaultSourceName = "Default on profile (re)load"
+
 // DesiredState defines the desired configuration that is applied when a condition is triggered.
 // It supports DPLL pin configurations and standardized PTP pin/period configurations.
 type DesiredState struct {
@@ -437,6 +552,67 @@ type HoldoverParameters struct {
 	// LocalHoldoverTimeout is the time the clock will stay in the holdover state before reaching the
 	// LocalMaxHoldoverOffset (in seconds). Default - 14400s
 	LocalHoldoverTimeout uint64 `json:"localHoldoverTimeout,omitempty" yaml:"localHoldoverTimeout,omitempty"`
+
+	// OscillatorClass is the class of the DPLL local oscillator, used to model the holdover phase drift.
+	// Overrides the oscillator class of the hardware-specific defaults. Valid values: "TCXO", "OCXO", "Rubidium"
+	// +optional
+	OscillatorClass OscillatorClassID `json:"oscillatorClass,omitempty" yaml:"oscillatorClass,omitempty"`
+
+	// Oscillator is the model of the DPLL local oscillator, for oscillators whose frequency stability or aging
+	// differ from the typical values of their class. Overrides the oscillator of the hardware-specific defaults.
+	// Mutually exclusive with oscillatorClass.
+	// +optional
+	Oscillator *OscillatorModel `json:"oscillator,omitempty" yaml:"oscillator,omitempty"`
+
+	// ClassTarget is the ITU-T G.8273.2 clock class the holdover specification threshold must comply with.
+	// Valid values: "A", "B", "C", "D"
+	// +optional
+	ClassTarget ClockClassTargetID `json:"classTarget,omitempty" yaml:"classTarget,omitempty"`
+}
+
+// OscillatorClassID identifies the class of a DPLL local oscillator
+// +kubebuilder:validation:Enum=TCXO;OCXO;Rubidium
+type OscillatorClassID string
+
+const (
+	// OscillatorClassTCXO is a temperature compensated crystal oscillator
+	OscillatorClassTCXO OscillatorClassID = "TCXO"
+	// OscillatorClassOCXO is an oven controlled crystal oscillator
+	OscillatorClassOCXO OscillatorClassID = "OCXO"
+	// OscillatorClassRubidium is a rubidium atomic oscillator
+	OscillatorClassRubidium OscillatorClassID = "Rubidium"
+)
+
+// ClockClassTargetID identifies an ITU-T G.8273.2 T-BC/T-TSC clock class
+// +kubebuilder:validation:Enum=A;B;C;D
+type ClockClassTargetID string
+
+const (
+	// ClockClassTargetA is G.8273.2 class A, max|TE| 100ns
+	ClockClassTargetA ClockClassTargetID = "A"
+	// ClockClassTargetB is G.8273.2 class B, max|TE| 70ns
+	ClockClassTargetB ClockClassTargetID = "B"
+	// ClockClassTargetC is G.8273.2 class C, max|TE| 30ns
+	ClockClassTargetC ClockClassTargetID = "C"
+	// ClockClassTargetD is G.8273.2 class D, max|TEL| 5ns
+	ClockClassTargetD ClockClassTargetID = "D"
+)
+
+// OscillatorModel describes the holdover characteristics of a local oscillator.
+// Phase error in holdover is modeled as the sum of a constant frequency error and a linear frequency drift.
+type OscillatorModel struct {
+	// Class is the oscillator class. Valid values: "TCXO", "OCXO", "Rubidium"
+	Class OscillatorClassID `json:"class" yaml:"class"`
+
+	// FrequencyStability is the fractional frequency error at the start of holdover, in parts per trillion.
+	// If omitted, the typical value of the oscillator class is used.
+	// +optional
+	FrequencyStability uint64 `json:"frequencyStability,omitempty" yaml:"frequencyStability,omitempty"`
+
+	// Aging is the fractional frequency drift, in parts per trillion per day.
+	// If omitted, the typical value of the oscillator class is used.
+	// +optional
+	Aging uint64 `json:"aging,omitempty" yaml:"aging,omitempty"`
 }
 
 // DPLL represents generic DPLL configuration within a synchronization subsystem.
@@ -546,12 +722,210 @@ func (sc *SourceConfig) Validate() error {
 		if sc.GNSSConfig == nil {
 			return fmt.Errorf("gnssConfig must be specified when sourceType is gnss")
 		}
+		if err := sc.GNSSConfig.Validate(); err != nil {
+			return fmt.Errorf("invalid gnssConfig: %w", err)
+		}
 	default:
 	}
 
 	return nil
 }
 
+// ReceiverType returns the GNSS receiver type, defaulting to u-blox when no receiver is described
+func (gc *GNSSConfig) ReceiverType() GNSSReceiverTypeID {
+	if gc.Receiver == nil || gc.Receiver.Type == "" {
+		return GNSSReceiverUBlox
+	}
+	return gc.Receiver.Type
+}
+
+// Validate ensures the GNSS settings are supported by the receiver type
+func (gc *GNSSConfig) Validate() error {
+	receiverType := gc.ReceiverType()
+	switch receiverType {
+	case GNSSReceiverUBlox:
+	case GNSSReceiverNMEA:
+		// NMEA-only receivers can not be configured, only settings applied in software are allowed
+		if len(gc.Init.ExtraCommands) > 0 {
+			return fmt.Errorf("init.extraCommands are not supported by receiver type %s", receiverType)
+		}
+		if gc.Init.SurveyIn.ObservationTime > 0 {
+			return fmt.Errorf("init.survey is not supported by receiver type %s", receiverType)
+		}
+	case GNSSReceiverVendor:
+		if len(gc.Init.ExtraCommands) > 0 {
+			return fmt.Errorf("init.extraCommands are ubxtool commands and are not supported by receiver type %s, use receiver.commands", receiverType)
+		}
+	default:
+		return fmt.Errorf("unknown GNSS receiver type: %s", receiverType)
+	}
+
+	if gc.Receiver == nil {
+		return nil
+	}
+	return gc.Receiver.validate()
+}
+
+func (r *GNSSReceiver) validate() error {
+	receiverType := r.Type
+	if receiverType == "" {
+		receiverType = GNSSReceiverUBlox
+	}
+
+	if receiverType == GNSSReceiverVendor {
+		if r.Protocol == "" {
+			return fmt.Errorf("receiver.protocol must be specified when receiver type is %s", receiverType)
+		}
+		if err := ValidateAlphanumDash(r.Protocol); err != nil {
+			return fmt.Errorf("invalid receiver.protocol: %w", err)
+		}
+	} else {
+		if r.Protocol != "" {
+			return fmt.Errorf("receiver.protocol is only supported by receiver type %s", GNSSReceiverVendor)
+		}
+		if len(r.Commands) > 0 {
+			return fmt.Errorf("receiver.commands are only supported by receiver type %s", GNSSReceiverVendor)
+		}
+	}
+
+	if r.AntennaCableDelay != nil && *r.AntennaCableDelay < 0 {
+		return fmt.Errorf("receiver.antennaCableDelay must not be negative")
+	}
+
+	if receiverType == GNSSReceiverNMEA {
+		if r.ElevationMask != nil {
+			return fmt.Errorf("receiver.elevationMask is not supported by receiver type %s", receiverType)
+		}
+		if r.TimePulse != nil {
+			return fmt.Errorf("receiver.timePulse is not supported by receiver type %s", receiverType)
+		}
+		if r.LeapSecondSource == LeapSecondSourceGNSS {
+			return fmt.Errorf("receiver type %s does not provide leap second information, leapSecondSource must be %s",
+				receiverType, LeapSecondSourceLeapFile)
+		}
+	}
+
+	if r.ElevationMask != nil && (*r.ElevationMask < 0 || *r.ElevationMask > 90) {
+		return fmt.Errorf("receiver.elevationMask must be between 0 and 90 degrees, got %d", *r.ElevationMask)
+	}
+
+	switch r.LeapSecondSource {
+	case "", LeapSecondSourceGNSS, LeapSecondSourceLeapFile:
+	default:
+		return fmt.Errorf("unknown receiver.leapSecondSource: %s", r.LeapSecondSource)
+	}
+
+	if r.TimePulse != nil {
+		if err := r.TimePulse.validate(); err != nil {
+			return fmt.Errorf("invalid receiver.timePulse: %w", err)
+		}
+	}
+
+	for _, command := range r.Commands {
+		if len(command.Args) == 0 {
+			return fmt.Errorf("receiver.commands must not contain empty commands")
+		}
+	}
+	return nil
+}
+
+func (tp *GNSSTimePulse) validate() error {
+	frequency := tp.Frequency
+	if frequency == 0 {
+		frequency = 1
+	}
+	if frequency < 0 {
+		return fmt.Errorf("frequency must be positive")
+	}
+	if tp.PulseWidth < 0 {
+		return fmt.Errorf("pulseWidth must not be negative")
+	}
+	periodNs := int64(1000000000) / frequency
+	if tp.PulseWidth >= periodNs {
+		return fmt.Errorf("pulseWidth %dns must be shorter than the pulse period %dns", tp.PulseWidth, periodNs)
+	}
+	switch tp.Polarity {
+	case "", "rising", "falling":
+	default:
+		return fmt.Errorf("polarity must be rising or falling, got %s", tp.Polarity)
+	}
+	return nil
+}
+
+var pciIDPattern = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{4}$`)
+
+// Validate ensures the selector PCI identifiers and firmware range are well formed
+func (hs *HardwareSelector) Validate() error {
+	for _, id := range []struct{ field, value string }{
+		{"vendorID", hs.VendorID},
+		{"deviceID", hs.DeviceID},
+		{"subsystemVendorID", hs.SubsystemVendorID},
+		{"subsystemDeviceID", hs.SubsystemDeviceID},
+	} {
+		if id.value != "" && !pciIDPattern.MatchString(id.value) {
+			return fmt.Errorf("%s must be a 4 digit hexadecimal PCI identifier, got %s", id.field, id.value)
+		}
+	}
+	if hs.Firmware != nil {
+		fw := hs.Firmware
+		if fw.MinVersion == "" && fw.MaxVersion == "" {
+			return fmt.Errorf("firmware range must specify minVersion or maxVersion")
+		}
+		if fw.MinVersion != "" && fw.MaxVersion != "" && CompareFirmwareVersions(fw.MinVersion, fw.MaxVersion) > 0 {
+			return fmt.Errorf("firmware minVersion %s is greater than maxVersion %s", fw.MinVersion, fw.MaxVersion)
+		}
+	}
+	return nil
+}
+
+// CompareFirmwareVersions compares the leading dotted version tokens of two firmware versions,
+// e.g. "4.40 0x8001c967 1.3534.0" and "4.5". Numeric components are compared numerically,
+// other components lexically. It returns -1, 0 or 1.
+func CompareFirmwareVersions(a, b string) int {
+	pa := strings.Split(firmwareVersionToken(a), ".")
+	pb := strings.Split(firmwareVersionToken(b), ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var ca, cb string
+		if i < len(pa) {
+			ca = pa[i]
+		}
+		if i < len(pb) {
+			cb = pb[i]
+		}
+		na, errA := strconv.Atoi(ca)
+		nb, errB := strconv.Atoi(cb)
+		if ca == "" {
+			na, errA = 0, nil
+		}
+		if cb == "" {
+			nb, errB = 0, nil
+		}
+		switch {
+		case errA == nil && errB == nil:
+			if na != nb {
+				if na < nb {
+					return -1
+				}
+				return 1
+			}
+		case ca != cb:
+			if ca < cb {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func firmwareVersionToken(version string) string {
+	fields := strings.Fields(version)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
 // Validate performs comprehensive validation of the entire configuration
 func (cc *ClockChain) Validate() error {
 	// Validate that structure has at least one subsystem
@@ -623,6 +997,12 @@ func (cc *ClockChain) Validate() error {
 			freqOutputLabels[label] = struct{}{}
 		}
 
+		if hp := subsystem.DPLL.HoldoverParameters; hp != nil {
+			if err := hp.Validate(); err != nil {
+				return fmt.Errorf("invalid holdover parameters in subsystem %s: %w", subsystem.Name, err)
+			}
+		}
+
 		for label, config := range allPinConfigs {
 			if err := config.Validate(); err != nil {
 				return fmt.Errorf("invalid pin config %s in subsystem %s: %w", label, subsystem.Name, err)
@@ -673,7 +1053,7 @@ func (cc *ClockChain) Validate() error {
 		for _, condition := range cc.Behavior.Conditions {
 			for _, trigger := range condition.Triggers {
 				// Check if referenced source exists (unless it's a special default source)
-				if trigger.SourceName != "Default on profile (re)load" &&
+				if trigger.SourceName != DefaultSourceName &&
 					!sourceNames[trigger.SourceName] {
 					return fmt.Errorf("referenced source %s not found in condition %s",
 						trigger.SourceName, condition.Name)
@@ -755,6 +1135,7 @@ type HardwareOptionsConfig struct {
 	PluginInfo       PluginInfo             `json:"pluginInfo" yaml:"pluginInfo"`
 	SpecificDefaults PluginSpecificDefaults `json:"specificDefaults,omitempty" yaml:"specificDefaults,omitempty"`
 	BehaviorNotes    string                 `json:"behaviorNotes,omitempty" yaml:"behaviorNotes,omitempty"`
+	Oscillator       *OscillatorModel       `json:"oscillator,omitempty" yaml:"oscillator,omitempty"`
 }
 
 // HardwareConfigSpec defines the desired state of HardwareConfig
@@ -764,13 +1145,89 @@ type HardwareConfigSpec struct {
 
 	// RelatedPtpProfileName specifies the name of the related PTP profile
 	RelatedPtpProfileName string `json:"relatedPtpProfileName,omitempty" yaml:"relatedPtpProfileName,omitempty"`
+
+	// HardwareSelector restricts this hardware config to nodes whose NodePtpDevice inventory
+	// contains a matching device. If omitted, every node recommended the related PTP profile matches.
+	// +optional
+	HardwareSelector *HardwareSelector `json:"hardwareSelector,omitempty" yaml:"hardwareSelector,omitempty"`
+}
+
+// HardwareSelector matches a device reported in NodePtpDevice hardwareInfo.
+// All specified fields must match the same device; empty fields match any value.
+type HardwareSelector struct {
+	// VendorID is the PCI vendor identifier, e.g. "8086"
+	// +kubebuilder:validation:Pattern=`^(0x)?[0-9a-fA-F]{4}$`
+	// +optional
+	VendorID string `json:"vendorID,omitempty" yaml:"vendorID,omitempty"`
+
+	// DeviceID is the PCI device identifier, e.g. "159b"
+	// +kubebuilder:validation:Pattern=`^(0x)?[0-9a-fA-F]{4}$`
+	// +optional
+	DeviceID string `json:"deviceID,omitempty" yaml:"deviceID,omitempty"`
+
+	// SubsystemVendorID is the PCI subsystem vendor identifier
+	// +kubebuilder:validation:Pattern=`^(0x)?[0-9a-fA-F]{4}$`
+	// +optional
+	SubsystemVendorID string `json:"subsystemVendorID,omitempty" yaml:"subsystemVendorID,omitempty"`
+
+	// SubsystemDeviceID is the PCI subsystem device identifier
+	// +kubebuilder:validation:Pattern=`^(0x)?[0-9a-fA-F]{4}$`
+	// +optional
+	SubsystemDeviceID string `json:"subsystemDeviceID,omitempty" yaml:"subsystemDeviceID,omitempty"`
+
+	// VPDPartNumber is the manufacturer's part number from VPD
+	// +optional
+	VPDPartNumber string `json:"vpdPartNumber,omitempty" yaml:"vpdPartNumber,omitempty"`
+
+	// Firmware restricts the device firmware version to a range
+	// +optional
+	Firmware *FirmwareVersionRange `json:"firmware,omitempty" yaml:"firmware,omitempty"`
+}
+
+// FirmwareVersionRange is an inclusive range of dotted firmware versions, e.g. "4.40" to "4.60".
+// Only the leading version token of the reported firmware version is compared.
+type FirmwareVersionRange struct {
+	// MinVersion is the lowest accepted firmware version
+	// +optional
+	MinVersion string `json:"minVersion,omitempty" yaml:"minVersion,omitempty"`
+
+	// MaxVersion is the highest accepted firmware version
+	// +optional
+	MaxVersion string `json:"maxVersion,omitempty" yaml:"maxVersion,omitempty"`
 }
 
 // HardwareConfigStatus defines the observed state of HardwareConfig
 type HardwareConfigStatus struct {
 	// MatchedNodes contains the list of nodes that have been matched to this hardware config
-	// based on PTP profile recommendations
+	// based on PTP profile recommendations and the hardware selector
 	MatchedNodes []MatchedNode `json:"matchedNodes,omitempty" yaml:"matchedNodes,omitempty"`
+
+	// Conditions contains the conditions for the HardwareConfig
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+
+	// Holdover contains the modeled holdover budget of each subsystem with a known oscillator class
+	// +optional
+	Holdover []SubsystemHoldoverStatus `json:"holdover,omitempty" yaml:"holdover,omitempty"`
+}
+
+// SubsystemHoldoverStatus reports the modeled holdover budget of a subsystem DPLL
+type SubsystemHoldoverStatus struct {
+	// Subsystem is the name of the subsystem
+	Subsystem string `json:"subsystem" yaml:"subsystem"`
+
+	// OscillatorClass is the oscillator class used by the model
+	OscillatorClass OscillatorClassID `json:"oscillatorClass" yaml:"oscillatorClass"`
+
+	// ClassTarget is the ITU-T G.8273.2 clock class target, if any
+	// +optional
+	ClassTarget ClockClassTargetID `json:"classTarget,omitempty" yaml:"classTarget,omitempty"`
+
+	// TimeInSpec is the expected time in seconds before the holdover offset exceeds MaxInSpecOffset
+	TimeInSpec uint64 `json:"timeInSpec" yaml:"timeInSpec"`
+
+	// TimeToMaxOffset is the expected time in seconds before the holdover offset reaches LocalMaxHoldoverOffset
+	TimeToMaxOffset uint64 `json:"timeToMaxOffset" yaml:"timeToMaxOffset"`
 }
 
 // MatchedNode represents a node that has been matched to this hardware config
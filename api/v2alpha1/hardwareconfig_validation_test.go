@@ -249,6 +249,222 @@ func TestSourceTypeValidation(t *testing.T) {
 	}
 }
 
+func TestGNSSReceiverValidation(t *testing.T) {
+	delay := int64(150)
+	mask := int64(15)
+	badMask := int64(91)
+	tests := []struct {
+		name    string
+		config  *GNSSConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "no receiver defaults to ublox",
+			config: &GNSSConfig{
+				Init: GNSSInit{ExtraCommands: []UBLXCommand{{Args: []string{"-p", "MON-HW"}}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid ublox receiver",
+			config: &GNSSConfig{
+				Receiver: &GNSSReceiver{
+					Type:              GNSSReceiverUBlox,
+					Model:             "ZED-F9T",
+					AntennaCableDelay: &delay,
+					ElevationMask:     &mask,
+					TimePulse:         &GNSSTimePulse{Frequency: 1, PulseWidth: 100000000, Polarity: "rising"},
+					LeapSecondSource:  LeapSecondSourceGNSS,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ublox receiver with vendor commands",
+			config: &GNSSConfig{
+				Receiver: &GNSSReceiver{
+					Type:     GNSSReceiverUBlox,
+					Commands: []GNSSReceiverCommand{{Args: []string{"setTimePulse"}}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "receiver.commands are only supported by receiver type vendor",
+		},
+		{
+			name: "valid nmea receiver",
+			config: &GNSSConfig{
+				Receiver: &GNSSReceiver{
+					Type:              GNSSReceiverNMEA,
+					AntennaCableDelay: &delay,
+					LeapSecondSource:  LeapSecondSourceLeapFile,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "nmea receiver with ubxtool commands",
+			config: &GNSSConfig{
+				Init:     GNSSInit{ExtraCommands: []UBLXCommand{{Args: []string{"-p", "MON-HW"}}}},
+				Receiver: &GNSSReceiver{Type: GNSSReceiverNMEA},
+			},
+			wantErr: true,
+			errMsg:  "init.extraCommands are not supported by receiver type nmea",
+		},
+		{
+			name: "nmea receiver with survey",
+			config: &GNSSConfig{
+				Init:     GNSSInit{SurveyIn: GNSSSurveyParameters{ObservationTime: 300}},
+				Receiver: &GNSSReceiver{Type: GNSSReceiverNMEA},
+			},
+			wantErr: true,
+			errMsg:  "init.survey is not supported",
+		},
+		{
+			name: "nmea receiver with elevation mask",
+			config: &GNSSConfig{
+				Receiver: &GNSSReceiver{Type: GNSSReceiverNMEA, ElevationMask: &mask},
+			},
+			wantErr: true,
+			errMsg:  "receiver.elevationMask is not supported",
+		},
+		{
+			name: "nmea receiver with time pulse",
+			config: &GNSSConfig{
+				Receiver: &GNSSReceiver{Type: GNSSReceiverNMEA, TimePulse: &GNSSTimePulse{}},
+			},
+			wantErr: true,
+			errMsg:  "receiver.timePulse is not supported",
+		},
+		{
+			name: "nmea receiver with gnss leap second source",
+			config: &GNSSConfig{
+				Receiver: &GNSSReceiver{Type: GNSSReceiverNMEA, LeapSecondSource: LeapSecondSourceGNSS},
+			},
+			wantErr: true,
+			errMsg:  "leapSecondSource must be leapFile",
+		},
+		{
+			name: "valid vendor receiver",
+			config: &GNSSConfig{
+				Receiver: &GNSSReceiver{
+					Type:          GNSSReceiverVendor,
+					Protocol:      "septentrio-sbf",
+					ElevationMask: &mask,
+					Commands:      []GNSSReceiverCommand{{Args: []string{"setElevationMask", "15"}, ReportOutput: true}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "vendor receiver without protocol",
+			config: &GNSSConfig{
+				Receiver: &GNSSReceiver{Type: GNSSReceiverVendor},
+			},
+			wantErr: true,
+			errMsg:  "receiver.protocol must be specified",
+		},
+		{
+			name: "vendor receiver with invalid protocol",
+			config: &GNSSConfig{
+				Receiver: &GNSSReceiver{Type: GNSSReceiverVendor, Protocol: "sbf v2"},
+			},
+			wantErr: true,
+			errMsg:  "invalid receiver.protocol",
+		},
+		{
+			name: "vendor receiver with ubxtool commands",
+			config: &GNSSConfig{
+				Init:     GNSSInit{ExtraCommands: []UBLXCommand{{Args: []string{"-p", "MON-HW"}}}},
+				Receiver: &GNSSReceiver{Type: GNSSReceiverVendor, Protocol: "tsip"},
+			},
+			wantErr: true,
+			errMsg:  "use receiver.commands",
+		},
+		{
+			name: "vendor receiver with empty command",
+			config: &GNSSConfig{
+				Receiver: &GNSSReceiver{
+					Type:     GNSSReceiverVendor,
+					Protocol: "tsip",
+					Commands: []GNSSReceiverCommand{{}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "must not contain empty commands",
+		},
+		{
+			name: "protocol on non-vendor receiver",
+			config: &GNSSConfig{
+				Receiver: &GNSSReceiver{Type: GNSSReceiverUBlox, Protocol: "ubx"},
+			},
+			wantErr: true,
+			errMsg:  "receiver.protocol is only supported by receiver type vendor",
+		},
+		{
+			name: "unknown receiver type",
+			config: &GNSSConfig{
+				Receiver: &GNSSReceiver{Type: GNSSReceiverTypeID("sirf")},
+			},
+			wantErr: true,
+			errMsg:  "unknown GNSS receiver type",
+		},
+		{
+			name: "elevation mask out of range",
+			config: &GNSSConfig{
+				Receiver: &GNSSReceiver{ElevationMask: &badMask},
+			},
+			wantErr: true,
+			errMsg:  "elevationMask must be between 0 and 90",
+		},
+		{
+			name: "unknown leap second source",
+			config: &GNSSConfig{
+				Receiver: &GNSSReceiver{LeapSecondSource: LeapSecondSourceID("ntp")},
+			},
+			wantErr: true,
+			errMsg:  "unknown receiver.leapSecondSource",
+		},
+		{
+			name: "time pulse width longer than period",
+			config: &GNSSConfig{
+				Receiver: &GNSSReceiver{TimePulse: &GNSSTimePulse{Frequency: 10, PulseWidth: 100000000}},
+			},
+			wantErr: true,
+			errMsg:  "must be shorter than the pulse period",
+		},
+		{
+			name: "time pulse with invalid polarity",
+			config: &GNSSConfig{
+				Receiver: &GNSSReceiver{TimePulse: &GNSSTimePulse{Polarity: "high"}},
+			},
+			wantErr: true,
+			errMsg:  "polarity must be rising or falling",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errMsg != "" {
+					assert.Contains(t, err.Error(), tt.errMsg)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// errors are reported through the source validation
+	source := &SourceConfig{
+		Subsystem:  "subsystem",
+		SourceType: SourceTypeGNSS,
+		GNSSConfig: &GNSSConfig{Receiver: &GNSSReceiver{Type: GNSSReceiverVendor}},
+	}
+	assert.ErrorContains(t, source.Validate(), "invalid gnssConfig: receiver.protocol must be specified")
+}
+
 func TestClockChainValidation_EdgeCases(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -359,3 +575,37 @@ func TestClockChainValidation_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestHardwareSelectorValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector *HardwareSelector
+		errMsg   string
+	}{
+		{name: "empty selector", selector: &HardwareSelector{}},
+		{name: "valid pci ids", selector: &HardwareSelector{VendorID: "8086", DeviceID: "0x1593", SubsystemVendorID: "8086", SubsystemDeviceID: "000B"}},
+		{name: "invalid device id", selector: &HardwareSelector{DeviceID: "15933"}, errMsg: "deviceID must be a 4 digit hexadecimal PCI identifier"},
+		{name: "valid firmware range", selector: &HardwareSelector{Firmware: &FirmwareVersionRange{MinVersion: "4.20", MaxVersion: "4.60"}}},
+		{name: "empty firmware range", selector: &HardwareSelector{Firmware: &FirmwareVersionRange{}}, errMsg: "must specify minVersion or maxVersion"},
+		{name: "inverted firmware range", selector: &HardwareSelector{Firmware: &FirmwareVersionRange{MinVersion: "4.60", MaxVersion: "4.5"}}, errMsg: "is greater than maxVersion"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.selector.Validate()
+			if tt.errMsg != "" {
+				assert.ErrorContains(t, err, tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCompareFirmwareVersions(t *testing.T) {
+	assert.Equal(t, 0, CompareFirmwareVersions("4.40 0x8001c967 1.3534.0", "4.40"))
+	assert.Equal(t, -1, CompareFirmwareVersions("4.5", "4.40"))
+	assert.Equal(t, 1, CompareFirmwareVersions("4.40.1", "4.40"))
+	assert.Equal(t, 0, CompareFirmwareVersions("4.40.0", "4.40"))
+	assert.Equal(t, -1, CompareFirmwareVersions("1.0a", "1.0b"))
+	assert.Equal(t, -1, CompareFirmwareVersions("", "1.0"))
+}
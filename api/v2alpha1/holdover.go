@@ -0,0 +1,146 @@
+package v2alpha1
+
+import (
+	"fmt"
+	"math"
+)
+
+// Holdover parameter defaults applied by the daemon when the fields are omitted
+const (
+	DefaultMaxInSpecOffset        uint64 = 100
+	DefaultLocalMaxHoldoverOffset uint64 = 1500
+	DefaultLocalHoldoverTimeout   uint64 = 14400
+)
+
+// oscillatorClassModels are the typical holdover characteristics of each oscillator class
+var oscillatorClassModels = map[OscillatorClassID]OscillatorModel{
+	OscillatorClassTCXO:     {Class: OscillatorClassTCXO, FrequencyStability: 1000, Aging: 1000},
+	OscillatorClassOCXO:     {Class: OscillatorClassOCXO, FrequencyStability: 50, Aging: 100},
+	OscillatorClassRubidium: {Class: OscillatorClassRubidium, FrequencyStability: 1, Aging: 2},
+}
+
+// classTargetMaxTE is the maximum absolute time error in nanoseconds of each G.8273.2 clock class
+var classTargetMaxTE = map[ClockClassTargetID]uint64{
+	ClockClassTargetA: 100,
+	ClockClassTargetB: 70,
+	ClockClassTargetC: 30,
+	ClockClassTargetD: 5,
+}
+
+// HoldoverBudget is the modeled holdover performance of a DPLL
+type HoldoverBudget struct {
+	// Oscillator is the oscillator model the budget was computed with
+	Oscillator OscillatorModel
+	// TimeInSpec is the time in seconds before the phase offset exceeds MaxInSpecOffset
+	TimeInSpec uint64
+	// TimeToMaxOffset is the time in seconds before the phase offset reaches LocalMaxHoldoverOffset
+	TimeToMaxOffset uint64
+}
+
+// Effective returns the holdover parameters with the daemon defaults applied to omitted fields
+func (hp *HoldoverParameters) Effective() HoldoverParameters {
+	effective := *hp
+	if effective.MaxInSpecOffset == 0 {
+		effective.MaxInSpecOffset = DefaultMaxInSpecOffset
+	}
+	if effective.LocalMaxHoldoverOffset == 0 {
+		effective.LocalMaxHoldoverOffset = DefaultLocalMaxHoldoverOffset
+	}
+	if effective.LocalHoldoverTimeout == 0 {
+		effective.LocalHoldoverTimeout = DefaultLocalHoldoverTimeout
+	}
+	return effective
+}
+
+// Validate checks the holdover parameters are consistent with each other, with the class target,
+// and achievable by the oscillator class when one is set
+func (hp *HoldoverParameters) Validate() error {
+	_, err := hp.Budget(nil)
+	return err
+}
+
+// Budget validates the holdover parameters and models the holdover performance.
+// The oscillator model is taken from the oscillator or the oscillator class of the parameters if set, and from
+// the oscillator of the hardware-specific defaults otherwise. It returns a nil budget when no oscillator is known.
+func (hp *HoldoverParameters) Budget(defaults *OscillatorModel) (*HoldoverBudget, error) {
+	effective := hp.Effective()
+	if effective.MaxInSpecOffset > effective.LocalMaxHoldoverOffset {
+		return nil, fmt.Errorf("maxInSpecOffset %dns must not exceed localMaxHoldoverOffset %dns",
+			effective.MaxInSpecOffset, effective.LocalMaxHoldoverOffset)
+	}
+
+	if hp.ClassTarget != "" {
+		maxTE, ok := classTargetMaxTE[hp.ClassTarget]
+		if !ok {
+			return nil, fmt.Errorf("unknown classTarget: %s", hp.ClassTarget)
+		}
+		if effective.MaxInSpecOffset > maxTE {
+			return nil, fmt.Errorf("maxInSpecOffset %dns exceeds the max|TE| of %dns of G.8273.2 class %s",
+				effective.MaxInSpecOffset, maxTE, hp.ClassTarget)
+		}
+	}
+
+	if hp.Oscillator != nil {
+		if hp.OscillatorClass != "" {
+			return nil, fmt.Errorf("oscillatorClass and oscillator must not both be set")
+		}
+		defaults = hp.Oscillator
+	}
+	model, err := resolveOscillatorModel(hp.OscillatorClass, defaults)
+	if err != nil || model == nil {
+		return nil, err
+	}
+
+	budget := &HoldoverBudget{
+		Oscillator:      *model,
+		TimeInSpec:      model.timeToOffset(effective.MaxInSpecOffset),
+		TimeToMaxOffset: model.timeToOffset(effective.LocalMaxHoldoverOffset),
+	}
+	if effective.LocalHoldoverTimeout > budget.TimeToMaxOffset {
+		return nil, fmt.Errorf("localHoldoverTimeout %ds is not achievable with a %s oscillator, the offset is expected to reach %dns after %ds",
+			effective.LocalHoldoverTimeout, model.Class, effective.LocalMaxHoldoverOffset, budget.TimeToMaxOffset)
+	}
+	return budget, nil
+}
+
+// resolveOscillatorModel returns the oscillator model of the class, or the hardware-specific defaults if no class is set.
+// Coefficients omitted in the defaults are taken from the typical values of their class.
+func resolveOscillatorModel(class OscillatorClassID, defaults *OscillatorModel) (*OscillatorModel, error) {
+	if class == "" {
+		if defaults == nil || defaults.Class == "" {
+			return nil, nil
+		}
+		class = defaults.Class
+	}
+	model, ok := oscillatorClassModels[class]
+	if !ok {
+		return nil, fmt.Errorf("unknown oscillatorClass: %s", class)
+	}
+	if defaults != nil && defaults.Class == class {
+		if defaults.FrequencyStability != 0 {
+			model.FrequencyStability = defaults.FrequencyStability
+		}
+		if defaults.Aging != 0 {
+			model.Aging = defaults.Aging
+		}
+	}
+	return &model, nil
+}
+
+// timeToOffset returns the time in seconds before the modeled phase error reaches the offset in nanoseconds,
+// solving offset = y*t + a*t^2/2 for the frequency error y and the frequency drift a
+func (m *OscillatorModel) timeToOffset(offsetNs uint64) uint64 {
+	offset := float64(offsetNs) * 1e-9
+	y := float64(m.FrequencyStability) * 1e-12
+	a := float64(m.Aging) * 1e-12 / 86400
+	var t float64
+	switch {
+	case a == 0 && y == 0:
+		return math.MaxUint64
+	case a == 0:
+		t = offset / y
+	default:
+		t = (-y + math.Sqrt(y*y+2*a*offset)) / a
+	}
+	return uint64(t)
+}
@@ -0,0 +1,127 @@
+package v2alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHoldoverBudget(t *testing.T) {
+	tests := []struct {
+		name       string
+		params     HoldoverParameters
+		defaults   *OscillatorModel
+		wantErr    string
+		wantBudget bool
+		inSpec     [2]uint64
+	}{
+		{
+			name:   "defaults without oscillator class",
+			params: HoldoverParameters{},
+		},
+		{
+			name:    "in-spec offset above max offset",
+			params:  HoldoverParameters{MaxInSpecOffset: 200, LocalMaxHoldoverOffset: 100},
+			wantErr: "must not exceed localMaxHoldoverOffset",
+		},
+		{
+			name:    "in-spec offset above class target",
+			params:  HoldoverParameters{MaxInSpecOffset: 100, ClassTarget: ClockClassTargetC},
+			wantErr: "exceeds the max|TE| of 30ns of G.8273.2 class C",
+		},
+		{
+			name:    "unknown class target",
+			params:  HoldoverParameters{ClassTarget: "E"},
+			wantErr: "unknown classTarget",
+		},
+		{
+			name:    "unknown oscillator class",
+			params:  HoldoverParameters{OscillatorClass: "Cesium"},
+			wantErr: "unknown oscillatorClass",
+		},
+		{
+			name:       "default timeout achievable by OCXO",
+			params:     HoldoverParameters{OscillatorClass: OscillatorClassOCXO},
+			wantBudget: true,
+			inSpec:     [2]uint64{1900, 2100},
+		},
+		{
+			name:    "default timeout not achievable by TCXO",
+			params:  HoldoverParameters{OscillatorClass: OscillatorClassTCXO},
+			wantErr: "localHoldoverTimeout 14400s is not achievable with a TCXO oscillator",
+		},
+		{
+			name:       "short timeout achievable by TCXO",
+			params:     HoldoverParameters{OscillatorClass: OscillatorClassTCXO, ClassTarget: ClockClassTargetB, MaxInSpecOffset: 70, LocalHoldoverTimeout: 1200},
+			wantBudget: true,
+			inSpec:     [2]uint64{60, 75},
+		},
+		{
+			name:       "oscillator class from hardware defaults",
+			params:     HoldoverParameters{},
+			defaults:   &OscillatorModel{Class: OscillatorClassRubidium},
+			wantBudget: true,
+			inSpec:     [2]uint64{55000, 65000},
+		},
+		{
+			name:       "hardware defaults override class coefficients",
+			params:     HoldoverParameters{LocalHoldoverTimeout: 100},
+			defaults:   &OscillatorModel{Class: OscillatorClassTCXO, FrequencyStability: 10000, Aging: 1},
+			wantBudget: true,
+			inSpec:     [2]uint64{10, 10},
+		},
+		{
+			name:       "user oscillator overrides class coefficients",
+			params:     HoldoverParameters{Oscillator: &OscillatorModel{Class: OscillatorClassTCXO, FrequencyStability: 50, Aging: 100}},
+			wantBudget: true,
+			inSpec:     [2]uint64{1900, 2100},
+		},
+		{
+			name:     "user oscillator overrides hardware defaults",
+			params:   HoldoverParameters{Oscillator: &OscillatorModel{Class: OscillatorClassOCXO, FrequencyStability: 1000}},
+			defaults: &OscillatorModel{Class: OscillatorClassRubidium},
+			wantErr:  "not achievable with a OCXO oscillator",
+		},
+		{
+			name:    "user oscillator and oscillator class",
+			params:  HoldoverParameters{OscillatorClass: OscillatorClassOCXO, Oscillator: &OscillatorModel{Class: OscillatorClassOCXO}},
+			wantErr: "oscillatorClass and oscillator must not both be set",
+		},
+		{
+			name:     "oscillator class overrides hardware defaults",
+			params:   HoldoverParameters{OscillatorClass: OscillatorClassTCXO},
+			defaults: &OscillatorModel{Class: OscillatorClassRubidium},
+			wantErr:  "not achievable with a TCXO oscillator",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget, err := tt.params.Budget(tt.defaults)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			if !tt.wantBudget {
+				assert.Nil(t, budget)
+				return
+			}
+			if !assert.NotNil(t, budget) {
+				return
+			}
+			assert.GreaterOrEqual(t, budget.TimeInSpec, tt.inSpec[0])
+			assert.LessOrEqual(t, budget.TimeInSpec, tt.inSpec[1])
+			assert.Greater(t, budget.TimeToMaxOffset, budget.TimeInSpec)
+		})
+	}
+}
+
+func TestHoldoverValidationInClockChain(t *testing.T) {
+	chain := &ClockChain{
+		Structure: []Subsystem{{
+			Name: "leader",
+			DPLL: DPLL{HoldoverParameters: &HoldoverParameters{MaxInSpecOffset: 20, LocalMaxHoldoverOffset: 10}},
+		}},
+	}
+	assert.ErrorContains(t, chain.Validate(), "invalid holdover parameters in subsystem leader")
+}
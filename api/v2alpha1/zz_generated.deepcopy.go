@@ -21,6 +21,7 @@ limitations under the License.
 package v2alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -143,7 +144,7 @@ func (in *DPLL) DeepCopyInto(out *DPLL) {
 	if in.HoldoverParameters != nil {
 		in, out := &in.HoldoverParameters, &out.HoldoverParameters
 		*out = new(HoldoverParameters)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.PhaseInputs != nil {
 		in, out := &in.PhaseInputs, &out.PhaseInputs
@@ -291,6 +292,21 @@ func (in *Ethernet) DeepCopy() *Ethernet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirmwareVersionRange) DeepCopyInto(out *FirmwareVersionRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirmwareVersionRange.
+func (in *FirmwareVersionRange) DeepCopy() *FirmwareVersionRange {
+	if in == nil {
+		return nil
+	}
+	out := new(FirmwareVersionRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GNSSConfig) DeepCopyInto(out *GNSSConfig) {
 	*out = *in
@@ -300,6 +316,11 @@ func (in *GNSSConfig) DeepCopyInto(out *GNSSConfig) {
 		*out = new(GNSSMatcher)
 		**out = **in
 	}
+	if in.Receiver != nil {
+		in, out := &in.Receiver, &out.Receiver
+		*out = new(GNSSReceiver)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GNSSConfig.
@@ -355,6 +376,63 @@ func (in *GNSSMatcher) DeepCopy() *GNSSMatcher {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GNSSReceiver) DeepCopyInto(out *GNSSReceiver) {
+	*out = *in
+	if in.AntennaCableDelay != nil {
+		in, out := &in.AntennaCableDelay, &out.AntennaCableDelay
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ElevationMask != nil {
+		in, out := &in.ElevationMask, &out.ElevationMask
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TimePulse != nil {
+		in, out := &in.TimePulse, &out.TimePulse
+		*out = new(GNSSTimePulse)
+		**out = **in
+	}
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = make([]GNSSReceiverCommand, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GNSSReceiver.
+func (in *GNSSReceiver) DeepCopy() *GNSSReceiver {
+	if in == nil {
+		return nil
+	}
+	out := new(GNSSReceiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GNSSReceiverCommand) DeepCopyInto(out *GNSSReceiverCommand) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GNSSReceiverCommand.
+func (in *GNSSReceiverCommand) DeepCopy() *GNSSReceiverCommand {
+	if in == nil {
+		return nil
+	}
+	out := new(GNSSReceiverCommand)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GNSSSurveyParameters) DeepCopyInto(out *GNSSSurveyParameters) {
 	*out = *in
@@ -370,6 +448,21 @@ func (in *GNSSSurveyParameters) DeepCopy() *GNSSSurveyParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GNSSTimePulse) DeepCopyInto(out *GNSSTimePulse) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GNSSTimePulse.
+func (in *GNSSTimePulse) DeepCopy() *GNSSTimePulse {
+	if in == nil {
+		return nil
+	}
+	out := new(GNSSTimePulse)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HardwareConfig) DeepCopyInto(out *HardwareConfig) {
 	*out = *in
@@ -433,6 +526,11 @@ func (in *HardwareConfigList) DeepCopyObject() runtime.Object {
 func (in *HardwareConfigSpec) DeepCopyInto(out *HardwareConfigSpec) {
 	*out = *in
 	in.Profile.DeepCopyInto(&out.Profile)
+	if in.HardwareSelector != nil {
+		in, out := &in.HardwareSelector, &out.HardwareSelector
+		*out = new(HardwareSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareConfigSpec.
@@ -453,6 +551,18 @@ func (in *HardwareConfigStatus) DeepCopyInto(out *HardwareConfigStatus) {
 		*out = make([]MatchedNode, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Holdover != nil {
+		in, out := &in.Holdover, &out.Holdover
+		*out = make([]SubsystemHoldoverStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareConfigStatus.
@@ -485,6 +595,11 @@ func (in *HardwareOptionsConfig) DeepCopyInto(out *HardwareOptionsConfig) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Oscillator != nil {
+		in, out := &in.Oscillator, &out.Oscillator
+		*out = new(OscillatorModel)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareOptionsConfig.
@@ -532,9 +647,50 @@ func (in *HardwareProfile) DeepCopy() *HardwareProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HardwareSelector) DeepCopyInto(out *HardwareSelector) {
+	*out = *in
+	if in.Firmware != nil {
+		in, out := &in.Firmware, &out.Firmware
+		*out = new(FirmwareVersionRange)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HardwareSelector.
+func (in *HardwareSelector) DeepCopy() *HardwareSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(HardwareSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HoldoverBudget) DeepCopyInto(out *HoldoverBudget) {
+	*out = *in
+	out.Oscillator = in.Oscillator
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HoldoverBudget.
+func (in *HoldoverBudget) DeepCopy() *HoldoverBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(HoldoverBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HoldoverParameters) DeepCopyInto(out *HoldoverParameters) {
 	*out = *in
+	if in.Oscillator != nil {
+		in, out := &in.Oscillator, &out.Oscillator
+		*out = new(OscillatorModel)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HoldoverParameters.
@@ -562,6 +718,21 @@ func (in *MatchedNode) DeepCopy() *MatchedNode {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OscillatorModel) DeepCopyInto(out *OscillatorModel) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OscillatorModel.
+func (in *OscillatorModel) DeepCopy() *OscillatorModel {
+	if in == nil {
+		return nil
+	}
+	out := new(OscillatorModel)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PTPPeriodDesiredState) DeepCopyInto(out *PTPPeriodDesiredState) {
 	*out = *in
@@ -830,6 +1001,21 @@ func (in *Subsystem) DeepCopy() *Subsystem {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubsystemHoldoverStatus) DeepCopyInto(out *SubsystemHoldoverStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubsystemHoldoverStatus.
+func (in *SubsystemHoldoverStatus) DeepCopy() *SubsystemHoldoverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SubsystemHoldoverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UBLXCommand) DeepCopyInto(out *UBLXCommand) {
 	*out = *in
@@ -0,0 +1,91 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// ptp-upgrade-advisor reports the deprecated settings of the PtpOperatorConfig and PtpConfig objects of a cluster,
+// the same reported as warnings by the admission webhooks, so they can be changed before an upgrade.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+)
+
+func main() {
+	var failOnWarnings bool
+	flag.BoolVar(&failOnWarnings, "fail-on-warnings", false, "Exit with status 2 when deprecated settings are found.")
+	flag.Parse()
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(ptpv1.AddToScheme(scheme))
+	c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create client: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	operatorConfigs := &ptpv1.PtpOperatorConfigList{}
+	if err := c.List(ctx, operatorConfigs); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to list PtpOperatorConfigs: %v\n", err)
+		os.Exit(1)
+	}
+	ptpConfigs := &ptpv1.PtpConfigList{}
+	if err := c.List(ctx, ptpConfigs); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to list PtpConfigs: %v\n", err)
+		os.Exit(1)
+	}
+
+	if count := report(os.Stdout, operatorConfigs.Items, ptpConfigs.Items); count > 0 && failOnWarnings {
+		os.Exit(2)
+	}
+}
+
+// report prints the deprecated settings of each object and returns their count
+func report(w io.Writer, operatorConfigs []ptpv1.PtpOperatorConfig, ptpConfigs []ptpv1.PtpConfig) int {
+	count := 0
+	printWarnings := func(kind, namespace, name string, warnings []string) {
+		for _, warning := range warnings {
+			fmt.Fprintf(w, "%s %s/%s: %s\n", kind, namespace, name, warning)
+		}
+		count += len(warnings)
+	}
+	for i := range operatorConfigs {
+		cfg := &operatorConfigs[i]
+		printWarnings("PtpOperatorConfig", cfg.Namespace, cfg.Name, cfg.StoredDeprecations())
+	}
+	for i := range ptpConfigs {
+		cfg := &ptpConfigs[i]
+		printWarnings("PtpConfig", cfg.Namespace, cfg.Name, cfg.Deprecations())
+	}
+	if count == 0 {
+		fmt.Fprintln(w, "no deprecated settings found")
+	} else {
+		fmt.Fprintf(w, "%d deprecated settings found\n", count)
+	}
+	return count
+}
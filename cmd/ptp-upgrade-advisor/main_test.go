@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestReport(t *testing.T) {
+	operatorConfig := ptpv1.PtpOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "openshift-ptp"},
+		Spec: ptpv1.PtpOperatorConfigSpec{
+			EventConfig: &ptpv1.PtpEventConfig{ApiVersion: "1.0"},
+		},
+	}
+	currentOperatorConfig := ptpv1.PtpOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "openshift-ptp"},
+	}
+	currentOperatorConfig.SetDefaults()
+	ptpConfig := ptpv1.PtpConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "bc", Namespace: "site-a"},
+		Spec: ptpv1.PtpConfigSpec{Profile: []ptpv1.PtpProfile{
+			{Name: strPtr("bc"), Ptp4lOpts: strPtr("-2 --slaveOnly 1")},
+		}},
+	}
+	currentConfig := ptpv1.PtpConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "gm", Namespace: "openshift-ptp"},
+		Spec: ptpv1.PtpConfigSpec{Profile: []ptpv1.PtpProfile{
+			{Name: strPtr("gm"), Ptp4lConf: strPtr("[global]\nserverOnly 1")},
+		}},
+	}
+
+	tests := []struct {
+		name            string
+		operatorConfigs []ptpv1.PtpOperatorConfig
+		ptpConfigs      []ptpv1.PtpConfig
+		expectedCount   int
+		expectedOutput  string
+	}{
+		{
+			name:           "no objects",
+			expectedOutput: "no deprecated settings found\n",
+		},
+		{
+			name:            "no deprecated settings",
+			operatorConfigs: []ptpv1.PtpOperatorConfig{currentOperatorConfig},
+			ptpConfigs:      []ptpv1.PtpConfig{currentConfig},
+			expectedOutput:  "no deprecated settings found\n",
+		},
+		{
+			name:            "deprecated settings",
+			operatorConfigs: []ptpv1.PtpOperatorConfig{operatorConfig},
+			ptpConfigs:      []ptpv1.PtpConfig{currentConfig, ptpConfig},
+			expectedCount:   3,
+			expectedOutput: "PtpOperatorConfig openshift-ptp/default: ptpEventConfig.apiVersion 1.0: the event API 1.0 has reached end of life, use 2.0\n" +
+				"PtpOperatorConfig openshift-ptp/default: plugins is not set, the default plugins e810, e825, e830, ntpfailover are enabled, set the plugins explicitly\n" +
+				"PtpConfig site-a/bc: profile bc: ptp4lOpts option slaveOnly was renamed clientOnly by linuxptp, use clientOnly\n" +
+				"3 deprecated settings found\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			assert.Equal(t, tt.expectedCount, report(&output, tt.operatorConfigs, tt.ptpConfigs))
+			assert.Equal(t, tt.expectedOutput, output.String())
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/golang/glog"
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// The security association files are mounted in the linuxptp daemon pods by a projected volume of the DaemonSet of
+// their daemon pool, holding only the secret keys referenced by the profiles recommended to the nodes of the pool.
+// The kubelet updates the mounted files when the secrets change, so key rotations do not restart the daemons; adding
+// or removing a referenced key updates the pod template of the DaemonSets of the pools referencing it.
+const (
+	authSecretsVolume       = "ptp-secrets" + TLV_AUTH_SUFFIX
+	authSecretsFileMode     = int32(0400)
+	linuxptpDaemonContainer = "linuxptp-daemon-container"
+)
+
+// saFileSecret returns the secret name and key of an sa_file path
+func saFileSecret(saFilePath string) (string, string, bool) {
+	path := strings.TrimPrefix(saFilePath, PTP_SEC_FOLDER)
+	if path == saFilePath || !strings.Contains(path, "/") {
+		return "", "", false
+	}
+	return ptpv1.GetSecretNameFromSaFilePath(saFilePath), ptpv1.GetSecretKeyFromSaFilePath(saFilePath), true
+}
+
+// getNodeSecrets returns the keys of the existing secrets of the operator namespace referenced by the profiles
+// recommended to each node, by secret name. Nodes that need no secret are omitted.
+func getNodeSecrets(ptpConfigList *ptpv1.PtpConfigList, nodeList *corev1.NodeList, secrets map[string]*corev1.Secret, policies namespacePolicies) map[string]map[string][]string {
+	nodeSecrets := make(map[string]map[string][]string)
+	for _, node := range nodeList.Items {
+		profiles, err := getRecommendNodePtpProfiles(policies.nodePtpConfigs(ptpConfigList, &node), node)
+		if err != nil {
+			glog.Errorf("failed to get recommended profiles for node %s: %v", node.Name, err)
+			continue
+		}
+		referenced := make(map[string]map[string]bool)
+		for _, profile := range profiles {
+			secretName, key, ok := saFileSecret(ptpv1.ProfileSaFilePath(&profile))
+			if !ok {
+				continue
+			}
+			secret, exists := secrets[secretName]
+			if !exists {
+				glog.Warningf("Secret '%s' referenced by node %s profiles not found in cluster - skipping mount", secretName, node.Name)
+				continue
+			}
+			if _, exists = secret.Data[key]; !exists {
+				glog.Warningf("Secret '%s' referenced by node %s profiles has no key '%s' - skipping mount", secretName, node.Name, key)
+				continue
+			}
+			if referenced[secretName] == nil {
+				referenced[secretName] = make(map[string]bool)
+			}
+			referenced[secretName][key] = true
+		}
+		if len(referenced) > 0 {
+			nodeSecrets[node.Name] = make(map[string][]string, len(referenced))
+			for secretName, keys := range referenced {
+				nodeSecrets[node.Name][secretName] = sortedKeys(keys)
+			}
+		}
+	}
+	return nodeSecrets
+}
+
+// getDaemonSetSecrets merges the secret keys of the nodes selected by DaemonNodeSelector by the DaemonSet of their
+// daemon pool. DaemonSets whose nodes need no secret are omitted.
+func getDaemonSetSecrets(cfg *ptpv1.PtpOperatorConfig, nodeList *corev1.NodeList, nodeSecrets map[string]map[string][]string) map[string]map[string][]string {
+	daemonSelector := labels.SelectorFromSet(cfg.Spec.DaemonNodeSelector)
+	referenced := make(map[string]map[string]map[string]bool)
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if len(nodeSecrets[node.Name]) == 0 || !daemonSelector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		pool := daemonPool{name: nodeDaemonPool(cfg, node)}
+		dsSecrets := referenced[pool.daemonSetName()]
+		if dsSecrets == nil {
+			dsSecrets = make(map[string]map[string]bool)
+			referenced[pool.daemonSetName()] = dsSecrets
+		}
+		for secretName, keys := range nodeSecrets[node.Name] {
+			if dsSecrets[secretName] == nil {
+				dsSecrets[secretName] = make(map[string]bool)
+			}
+			for _, key := range keys {
+				dsSecrets[secretName][key] = true
+			}
+		}
+	}
+
+	daemonSetSecrets := make(map[string]map[string][]string, len(referenced))
+	for dsName, dsSecrets := range referenced {
+		daemonSetSecrets[dsName] = make(map[string][]string, len(dsSecrets))
+		for secretName, keys := range dsSecrets {
+			daemonSetSecrets[dsName][secretName] = sortedKeys(keys)
+		}
+	}
+	return daemonSetSecrets
+}
+
+// limitNameLength truncates a name longer than a DNS subdomain, appending a hash of the name to keep it unique
+func limitNameLength(name string) string {
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(name))
+	suffix := "-" + hex.EncodeToString(sum[:])[:10]
+	return strings.TrimRight(name[:validation.DNS1123SubdomainMaxLength-len(suffix)], "-.") + suffix
+}
+
+// setAuthSecretsVolume replaces the security volumes of the DaemonSet by a projected volume of the secret keys,
+// mounted at PTP_SEC_FOLDER in the linuxptp daemon container with a <secret name>/<key> file per key. The DaemonSet
+// has no security volume when there is no secret key.
+func setAuthSecretsVolume(ds *appsv1.DaemonSet, secrets map[string][]string) {
+	removeSecurityVolumesFromDaemonSet(ds)
+	if len(secrets) == 0 {
+		return
+	}
+
+	mode := authSecretsFileMode
+	projected := &corev1.ProjectedVolumeSource{DefaultMode: &mode}
+	for _, secretName := range sortedKeys(secretNameSet(secrets)) {
+		projection := &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: secretName}}
+		for _, key := range secrets[secretName] {
+			projection.Items = append(projection.Items, corev1.KeyToPath{Key: key, Path: secretName + "/" + key})
+		}
+		projected.Sources = append(projected.Sources, corev1.VolumeProjection{Secret: projection})
+	}
+	ds.Spec.Template.Spec.Volumes = append(ds.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name:         authSecretsVolume,
+		VolumeSource: corev1.VolumeSource{Projected: projected},
+	})
+	for i := range ds.Spec.Template.Spec.Containers {
+		container := &ds.Spec.Template.Spec.Containers[i]
+		if container.Name == linuxptpDaemonContainer {
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      authSecretsVolume,
+				MountPath: strings.TrimSuffix(PTP_SEC_FOLDER, "/"),
+				ReadOnly:  true,
+			})
+			break
+		}
+	}
+}
+
+// secretNameSet returns the set of the secret names of the secret keys
+func secretNameSet(secrets map[string][]string) map[string]bool {
+	set := make(map[string]bool, len(secrets))
+	for secretName := range secrets {
+		set[secretName] = true
+	}
+	return set
+}
+
+// linuxptpDaemonSetPredicate filters the creations of the linuxptp daemon DaemonSets, which are rendered without
+// their security volume
+func linuxptpDaemonSetPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return e.Object.GetNamespace() == names.Namespace && isLinuxptpDaemonSet(e.Object)
+		},
+		UpdateFunc:  func(e event.UpdateEvent) bool { return false },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
+// isLinuxptpDaemonSet returns whether the DaemonSet is the linuxptp-daemon DaemonSet or the DaemonSet of a daemon pool
+func isLinuxptpDaemonSet(object client.Object) bool {
+	_, pool := object.GetLabels()[ptpv1.DaemonPoolLabel]
+	return object.GetName() == linuxptpDaemonSetName || pool
+}
@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+)
+
+func makeSaFileProfile(name, secretName string) ptpv1.PtpProfile {
+	profile := makeProfile(name, nil)
+	profile.Ptp4lConf = strPtr("[global]\nsa_file /etc/ptp-secret-mount/" + secretName + "/sa_file\nspp -1\n[ens1f0]\nspp 1")
+	return profile
+}
+
+func makeSaFileSecret(name string, keys ...string) *corev1.Secret {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name}, Data: map[string][]byte{}}
+	for _, key := range keys {
+		secret.Data[key] = []byte("[security_association]\nspp 1\n1 SHA256-128 HEX:0011\n")
+	}
+	return secret
+}
+
+func TestGetNodeSecrets(t *testing.T) {
+	site1 := makeSaFileProfile("site1", "site1-sa")
+	site2 := makeAuthPtpConfig(1).Spec.Profile[0]
+	site2.Name = strPtr("site2")
+	missing := makeSaFileProfile("missing", "missing-sa")
+	missingKey := makeSaFileProfile("missing-key", "other-sa")
+	list := makePtpConfigList(makePtpConfig("cfg",
+		[]ptpv1.PtpProfile{site1, site2, missing, missingKey, makeProfile("plain", nil)},
+		[]ptpv1.PtpRecommend{
+			makeRecommend("site1", 0, "site1"),
+			makeRecommend("site2", 0, "site2"),
+			makeRecommend("missing", 0, "site2"),
+			makeRecommend("missing-key", 0, "site2"),
+			makeRecommend("plain", 0, "plain"),
+		}))
+	nodes := &corev1.NodeList{Items: []corev1.Node{
+		makeNode("node1", map[string]string{"site1": ""}),
+		makeNode("node2", map[string]string{"site1": "", "site2": ""}),
+		makeNode("node3", map[string]string{"plain": ""}),
+	}}
+	secrets := map[string]*corev1.Secret{
+		"site1-sa": makeSaFileSecret("site1-sa", "sa_file", "unused"),
+		"ptp-sa":   makeSaFileSecret("ptp-sa", "sa_file"),
+		"other-sa": makeSaFileSecret("other-sa", "other"),
+	}
+
+	nodeSecrets := getNodeSecrets(list, nodes, secrets, newNamespacePolicies(nil))
+	assert.Equal(t, map[string]map[string][]string{
+		"node1": {"site1-sa": {"sa_file"}},
+		"node2": {"ptp-sa": {"sa_file"}, "site1-sa": {"sa_file"}},
+	}, nodeSecrets)
+}
+
+func TestGetDaemonSetSecrets(t *testing.T) {
+	cfg := makeDaemonPoolsConfig()
+	cfg.Spec.DaemonNodeSelector = map[string]string{"ptp": ""}
+	nodes := &corev1.NodeList{Items: []corev1.Node{
+		makeNode("gm-0", map[string]string{"ptp": "", "ptp/role": "gm"}),
+		makeNode("worker-0", map[string]string{"ptp": ""}),
+		makeNode("worker-1", map[string]string{"ptp": ""}),
+		makeNode("unselected", nil),
+	}}
+	nodeSecrets := map[string]map[string][]string{
+		"gm-0":       {"gm-sa": {"sa_file"}},
+		"worker-0":   {"site-sa": {"sa_file"}},
+		"worker-1":   {"site-sa": {"backup", "sa_file"}},
+		"unselected": {"other-sa": {"sa_file"}},
+	}
+
+	assert.Equal(t, map[string]map[string][]string{
+		"linuxptp-daemon-gm": {"gm-sa": {"sa_file"}},
+		"linuxptp-daemon":    {"site-sa": {"backup", "sa_file"}},
+	}, getDaemonSetSecrets(cfg, nodes, nodeSecrets))
+}
+
+func TestSetAuthSecretsVolume(t *testing.T) {
+	ds := makeDaemonSet()
+	addLegacySecurityVolume(ds)
+
+	setAuthSecretsVolume(ds, map[string][]string{"site2-sa": {"sa_file"}, "site1-sa": {"backup", "sa_file"}})
+	volumes := ds.Spec.Template.Spec.Volumes
+	if assert.Len(t, volumes, 2) {
+		assert.Equal(t, "config-volume", volumes[0].Name)
+		assert.Equal(t, authSecretsVolume, volumes[1].Name)
+		projected := volumes[1].Projected
+		if assert.NotNil(t, projected) {
+			assert.Equal(t, authSecretsFileMode, *projected.DefaultMode)
+			var names []string
+			var paths []string
+			for _, source := range projected.Sources {
+				names = append(names, source.Secret.Name)
+				for _, item := range source.Secret.Items {
+					paths = append(paths, item.Path)
+				}
+			}
+			assert.Equal(t, []string{"site1-sa", "site2-sa"}, names)
+			assert.Equal(t, []string{"site1-sa/backup", "site1-sa/sa_file", "site2-sa/sa_file"}, paths)
+		}
+	}
+	mounts := ds.Spec.Template.Spec.Containers[0].VolumeMounts
+	if assert.Len(t, mounts, 2) {
+		assert.Equal(t, authSecretsVolume, mounts[1].Name)
+		assert.Equal(t, strings.TrimSuffix(PTP_SEC_FOLDER, "/"), mounts[1].MountPath)
+		assert.True(t, mounts[1].ReadOnly)
+	}
+
+	setAuthSecretsVolume(ds, nil)
+	assert.Len(t, ds.Spec.Template.Spec.Volumes, 1)
+	assert.Len(t, ds.Spec.Template.Spec.Containers[0].VolumeMounts, 1)
+}
+
+func TestDaemonSetHasNoHostSecretDir(t *testing.T) {
+	ds := renderTestDaemonSet(t, makeTestRenderData())
+	for _, vol := range ds.Spec.Template.Spec.Volumes {
+		assert.False(t, strings.HasPrefix(vol.Name, "ptp-secrets"), "unexpected volume %s", vol.Name)
+	}
+	for _, mount := range getContainer(ds, linuxptpDaemonContainer).VolumeMounts {
+		assert.NotEqual(t, strings.TrimSuffix(PTP_SEC_FOLDER, "/"), mount.MountPath)
+	}
+}
+
+func TestLinuxptpDaemonSetPredicate(t *testing.T) {
+	p := linuxptpDaemonSetPredicate()
+	ds := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "linuxptp-daemon", Namespace: "openshift-ptp"}}
+	pool := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{
+		Name: "linuxptp-daemon-gm", Namespace: "openshift-ptp", Labels: map[string]string{ptpv1.DaemonPoolLabel: "gm"},
+	}}
+	other := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "openshift-ptp"}}
+	foreign := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "linuxptp-daemon", Namespace: "default"}}
+
+	assert.True(t, p.Create(event.CreateEvent{Object: ds}))
+	assert.True(t, p.Create(event.CreateEvent{Object: pool}))
+	assert.False(t, p.Create(event.CreateEvent{Object: other}))
+	assert.False(t, p.Create(event.CreateEvent{Object: foreign}))
+	assert.False(t, p.Update(event.UpdateEvent{ObjectOld: ds, ObjectNew: ds}))
+	assert.False(t, p.Delete(event.DeleteEvent{Object: ds}))
+}
@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/golang/glog"
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	kscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const linuxptpDaemonSetName = "linuxptp-daemon"
+
+// daemonPool holds the settings a linuxptp daemon DaemonSet is rendered with. The pool without a name is the
+// linuxptp-daemon DaemonSet of the nodes matching no PtpOperatorConfig daemon pool.
+type daemonPool struct {
+	name           string
+	image          string
+	enabledPlugins *map[string]*apiextensions.JSON
+	eventConfig    *ptpv1.PtpEventConfig
+	daemonSet      *ptpv1.PtpDaemonSetConfig
+}
+
+// getDaemonPools returns the linuxptp-daemon pool followed by the PtpOperatorConfig daemon pools, whose unset
+// settings are inherited from the PtpOperatorConfig spec
+func getDaemonPools(cfg *ptpv1.PtpOperatorConfig) []daemonPool {
+	pools := []daemonPool{{
+		image:          os.Getenv("LINUXPTP_DAEMON_IMAGE"),
+		enabledPlugins: cfg.Spec.EnabledPlugins,
+		eventConfig:    cfg.Spec.EventConfig,
+		daemonSet:      cfg.Spec.DaemonSet,
+	}}
+	for _, p := range cfg.Spec.DaemonPools {
+		pool := pools[0]
+		pool.name = p.Name
+		if p.Image != "" {
+			pool.image = p.Image
+		}
+		if p.EnabledPlugins != nil {
+			pool.enabledPlugins = p.EnabledPlugins
+		}
+		if p.EventConfig != nil {
+			pool.eventConfig = p.EventConfig
+		}
+		if p.DaemonSet != nil {
+			pool.daemonSet = p.DaemonSet
+		}
+		pools = append(pools, pool)
+	}
+	return pools
+}
+
+// daemonSetName returns the name of the DaemonSet of the pool
+func (p *daemonPool) daemonSetName() string {
+	if p.name == "" {
+		return linuxptpDaemonSetName
+	}
+	return linuxptpDaemonSetName + "-" + p.name
+}
+
+// eventsEnabled returns true when the pool daemons run the event publisher sidecar
+func (p *daemonPool) eventsEnabled() bool {
+	return p.eventConfig != nil && p.eventConfig.EnableEventPublisher
+}
+
+// nodeDaemonPool returns the name of the first daemon pool whose node selector matches the node labels, or an empty
+// string when the node belongs to no pool
+func nodeDaemonPool(cfg *ptpv1.PtpOperatorConfig, node *corev1.Node) string {
+	for _, pool := range cfg.Spec.DaemonPools {
+		if labels.SelectorFromSet(pool.NodeSelector).Matches(labels.Set(node.Labels)) {
+			return pool.Name
+		}
+	}
+	return ""
+}
+
+// setDaemonPool restricts the linuxptp DaemonSet to the nodes of its pool
+func (r *PtpOperatorConfigReconciler) setDaemonPool(
+	defaultCfg *ptpv1.PtpOperatorConfig,
+	pool *daemonPool,
+	obj *uns.Unstructured,
+) (*uns.Unstructured, error) {
+	if obj.GetKind() != "DaemonSet" || len(defaultCfg.Spec.DaemonPools) == 0 {
+		return obj, nil
+	}
+	scheme := kscheme.Scheme
+	ds := &appsv1.DaemonSet{}
+	if err := scheme.Convert(obj, ds, nil); err != nil {
+		return nil, fmt.Errorf("failed to convert linuxptp obj to appsv1.DaemonSet: %v", err)
+	}
+	applyDaemonPoolScheduling(ds, defaultCfg.Spec.DaemonPools, pool.name)
+	if err := scheme.Convert(ds, obj, nil); err != nil {
+		return nil, fmt.Errorf("failed to convert appsv1.DaemonSet to linuxptp obj: %v", err)
+	}
+	return obj, nil
+}
+
+// applyDaemonPoolScheduling schedules the DaemonSet of a pool on the nodes matching the pool node selector and no
+// earlier pool, and the linuxptp-daemon DaemonSet on the nodes matching no pool. The earlier pools are excluded by
+// node affinity: a node is outside a pool when one of the pool node selector labels does not match, so the exclusions
+// are expanded into a node selector term per combination of excluded labels.
+func applyDaemonPoolScheduling(ds *appsv1.DaemonSet, pools []ptpv1.PtpDaemonPool, pool string) {
+	podSpec := &ds.Spec.Template.Spec
+	var excluded []ptpv1.PtpDaemonPool
+	for _, p := range pools {
+		if p.Name != pool {
+			excluded = append(excluded, p)
+			continue
+		}
+		nodeSelector := make(map[string]string, len(podSpec.NodeSelector)+len(p.NodeSelector))
+		for key, value := range podSpec.NodeSelector {
+			nodeSelector[key] = value
+		}
+		for key, value := range p.NodeSelector {
+			nodeSelector[key] = value
+		}
+		podSpec.NodeSelector = nodeSelector
+		break
+	}
+	if len(excluded) == 0 {
+		return
+	}
+
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	required := podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	terms := []corev1.NodeSelectorTerm{{}}
+	if required != nil && len(required.NodeSelectorTerms) > 0 {
+		terms = required.NodeSelectorTerms
+	}
+	for _, p := range excluded {
+		keys := make([]string, 0, len(p.NodeSelector))
+		for key := range p.NodeSelector {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var expanded []corev1.NodeSelectorTerm
+		for _, term := range terms {
+			for _, key := range keys {
+				outside := term.DeepCopy()
+				outside.MatchExpressions = append(outside.MatchExpressions, corev1.NodeSelectorRequirement{
+					Key:      key,
+					Operator: corev1.NodeSelectorOpNotIn,
+					Values:   []string{p.NodeSelector[key]},
+				})
+				expanded = append(expanded, *outside)
+			}
+		}
+		terms = expanded
+	}
+	podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{NodeSelectorTerms: terms}
+}
+
+// deleteStaleDaemonPools deletes the DaemonSets of the daemon pools removed from the PtpOperatorConfig
+func (r *PtpOperatorConfigReconciler) deleteStaleDaemonPools(ctx context.Context, pools []daemonPool) error {
+	dsList := &appsv1.DaemonSetList{}
+	if err := r.List(ctx, dsList, client.InNamespace(names.Namespace), client.HasLabels{ptpv1.DaemonPoolLabel}); err != nil {
+		return fmt.Errorf("failed to list daemon pool DaemonSets: %v", err)
+	}
+	desired := make(map[string]bool, len(pools))
+	for i := range pools {
+		desired[pools[i].daemonSetName()] = true
+	}
+	for i := range dsList.Items {
+		ds := &dsList.Items[i]
+		if desired[ds.Name] {
+			continue
+		}
+		if err := r.Delete(ctx, ds); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete daemon pool DaemonSet %s: %v", ds.Name, err)
+		}
+		glog.Infof("deleted DaemonSet %s of removed daemon pool", ds.Name)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+)
+
+func makeDaemonPoolsConfig() *ptpv1.PtpOperatorConfig {
+	plugins := map[string]*apiextensions.JSON{"e810": nil}
+	gmPlugins := map[string]*apiextensions.JSON{"e810": nil, "ntpfailover": nil}
+	return &ptpv1.PtpOperatorConfig{
+		Spec: ptpv1.PtpOperatorConfigSpec{
+			EnabledPlugins: &plugins,
+			EventConfig:    &ptpv1.PtpEventConfig{EnableEventPublisher: true},
+			DaemonSet:      &ptpv1.PtpDaemonSetConfig{PriorityClassName: "ptp"},
+			DaemonPools: []ptpv1.PtpDaemonPool{
+				{
+					Name:           "gm",
+					NodeSelector:   map[string]string{"ptp/role": "gm"},
+					Image:          "example.com/linuxptp-daemon:gnss",
+					EnabledPlugins: &gmPlugins,
+				},
+				{
+					Name:         "worker",
+					NodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+					EventConfig:  &ptpv1.PtpEventConfig{},
+				},
+			},
+		},
+	}
+}
+
+func TestGetDaemonPools(t *testing.T) {
+	t.Setenv("LINUXPTP_DAEMON_IMAGE", "example.com/linuxptp-daemon:latest")
+	cfg := makeDaemonPoolsConfig()
+
+	pools := getDaemonPools(cfg)
+	if !assert.Len(t, pools, 3) {
+		return
+	}
+
+	assert.Equal(t, "linuxptp-daemon", pools[0].daemonSetName())
+	assert.Equal(t, "example.com/linuxptp-daemon:latest", pools[0].image)
+	assert.True(t, pools[0].eventsEnabled())
+
+	assert.Equal(t, "linuxptp-daemon-gm", pools[1].daemonSetName())
+	assert.Equal(t, "example.com/linuxptp-daemon:gnss", pools[1].image)
+	assert.Len(t, *pools[1].enabledPlugins, 2)
+	assert.True(t, pools[1].eventsEnabled())
+	assert.Equal(t, "ptp", pools[1].daemonSet.PriorityClassName)
+
+	assert.Equal(t, "linuxptp-daemon-worker", pools[2].daemonSetName())
+	assert.Equal(t, "example.com/linuxptp-daemon:latest", pools[2].image)
+	assert.Len(t, *pools[2].enabledPlugins, 1)
+	assert.False(t, pools[2].eventsEnabled())
+}
+
+func TestNodeDaemonPool(t *testing.T) {
+	cfg := makeDaemonPoolsConfig()
+
+	gm := makeNode("gm", map[string]string{"ptp/role": "gm", "node-role.kubernetes.io/worker": ""})
+	assert.Equal(t, "gm", nodeDaemonPool(cfg, &gm))
+
+	worker := makeNode("worker", map[string]string{"node-role.kubernetes.io/worker": ""})
+	assert.Equal(t, "worker", nodeDaemonPool(cfg, &worker))
+
+	master := makeNode("master", map[string]string{"node-role.kubernetes.io/master": ""})
+	assert.Equal(t, "", nodeDaemonPool(cfg, &master))
+}
+
+func TestApplyDaemonPoolScheduling(t *testing.T) {
+	pools := []ptpv1.PtpDaemonPool{
+		{Name: "gm", NodeSelector: map[string]string{"ptp/role": "gm", "ptp/site": "a"}},
+		{Name: "worker", NodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""}},
+	}
+	notIn := func(key, value string) corev1.NodeSelectorRequirement {
+		return corev1.NodeSelectorRequirement{Key: key, Operator: corev1.NodeSelectorOpNotIn, Values: []string{value}}
+	}
+	notRole, notSite, notWorker := notIn("ptp/role", "gm"), notIn("ptp/site", "a"), notIn("node-role.kubernetes.io/worker", "")
+
+	// the first pool only selects its nodes
+	ds := &appsv1.DaemonSet{}
+	ds.Spec.Template.Spec.NodeSelector = map[string]string{"kubernetes.io/os": "linux"}
+	applyDaemonPoolScheduling(ds, pools, "gm")
+	assert.Equal(t, map[string]string{"kubernetes.io/os": "linux", "ptp/role": "gm", "ptp/site": "a"}, ds.Spec.Template.Spec.NodeSelector)
+	assert.Nil(t, ds.Spec.Template.Spec.Affinity)
+
+	// the next pools exclude the nodes of the earlier pools
+	ds = &appsv1.DaemonSet{}
+	applyDaemonPoolScheduling(ds, pools, "worker")
+	assert.Equal(t, map[string]string{"node-role.kubernetes.io/worker": ""}, ds.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, []corev1.NodeSelectorTerm{
+		{MatchExpressions: []corev1.NodeSelectorRequirement{notRole}},
+		{MatchExpressions: []corev1.NodeSelectorRequirement{notSite}},
+	}, ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+
+	// linuxptp-daemon excludes the nodes of every pool, within the existing terms
+	arch := corev1.NodeSelectorRequirement{Key: "kubernetes.io/arch", Operator: corev1.NodeSelectorOpIn, Values: []string{"amd64"}}
+	ds = &appsv1.DaemonSet{}
+	ds.Spec.Template.Spec.Affinity = &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{arch}}},
+		},
+	}}
+	applyDaemonPoolScheduling(ds, pools, "")
+	assert.Nil(t, ds.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, []corev1.NodeSelectorTerm{
+		{MatchExpressions: []corev1.NodeSelectorRequirement{arch, notRole, notWorker}},
+		{MatchExpressions: []corev1.NodeSelectorRequirement{arch, notSite, notWorker}},
+	}, ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+}
+
+func TestRenderDaemonPoolDaemonSet(t *testing.T) {
+	data := makeTestRenderData()
+	data.Data["DaemonSetName"] = "linuxptp-daemon-gm"
+	data.Data["DaemonPool"] = "gm"
+	ds := renderTestDaemonSet(t, data)
+
+	assert.Equal(t, "linuxptp-daemon-gm", ds.Name)
+	assert.Equal(t, "gm", ds.Labels[ptpv1.DaemonPoolLabel])
+	assert.Equal(t, map[string]string{"app": "linuxptp-daemon-gm", ptpv1.DaemonPoolLabel: "gm"}, ds.Spec.Selector.MatchLabels)
+	assert.Equal(t, "gm", ds.Spec.Template.Labels[ptpv1.DaemonPoolLabel])
+	assert.Equal(t, "linuxptp-daemon-gm", ds.Spec.Template.Labels["app"])
+	assert.Equal(t, "ptp", ds.Spec.Template.Labels["role"])
+
+	data = makeTestRenderData()
+	ds = renderTestDaemonSet(t, data)
+	assert.Equal(t, "linuxptp-daemon", ds.Name)
+	assert.Equal(t, map[string]string{"app": "linuxptp-daemon"}, ds.Spec.Selector.MatchLabels)
+	assert.NotContains(t, ds.Spec.Template.Labels, ptpv1.DaemonPoolLabel)
+}
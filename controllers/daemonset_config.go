@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"fmt"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+// defaultDaemonLogLevel is the verbosity of the linuxptp daemon logs
+const defaultDaemonLogLevel int32 = 10
+
+// daemonLogLevel returns the verbosity of the linuxptp daemon logs
+func daemonLogLevel(cfg *ptpv1.PtpDaemonSetConfig) int32 {
+	if cfg == nil || cfg.LogLevel == nil {
+		return defaultDaemonLogLevel
+	}
+	return *cfg.LogLevel
+}
+
+// setDaemonSetConfig applies the DaemonSet customizations of a daemon pool to its linuxptp DaemonSet
+func (r *PtpOperatorConfigReconciler) setDaemonSetConfig(
+	cfg *ptpv1.PtpDaemonSetConfig,
+	obj *uns.Unstructured,
+) (*uns.Unstructured, error) {
+	if obj.GetKind() != "DaemonSet" || cfg == nil {
+		return obj, nil
+	}
+	scheme := kscheme.Scheme
+	ds := &appsv1.DaemonSet{}
+	if err := scheme.Convert(obj, ds, nil); err != nil {
+		return nil, fmt.Errorf("failed to convert linuxptp obj to appsv1.DaemonSet: %v", err)
+	}
+	if err := applyDaemonSetConfig(ds, cfg); err != nil {
+		return nil, err
+	}
+	if err := scheme.Convert(ds, obj, nil); err != nil {
+		return nil, fmt.Errorf("failed to convert appsv1.DaemonSet to linuxptp obj: %v", err)
+	}
+	return obj, nil
+}
+
+// applyDaemonSetConfig customizes the rendered DaemonSet. Tolerations, priority class, update strategy and container
+// resources replace the rendered ones, annotations, environment variables, volumes and mounts are added to them.
+func applyDaemonSetConfig(ds *appsv1.DaemonSet, cfg *ptpv1.PtpDaemonSetConfig) error {
+	podSpec := &ds.Spec.Template.Spec
+	if len(cfg.Tolerations) > 0 {
+		podSpec.Tolerations = cfg.Tolerations
+	}
+	if cfg.PriorityClassName != "" {
+		podSpec.PriorityClassName = cfg.PriorityClassName
+	}
+	if cfg.MaxUnavailable != nil {
+		ds.Spec.UpdateStrategy.Type = appsv1.RollingUpdateDaemonSetStrategyType
+		if ds.Spec.UpdateStrategy.RollingUpdate == nil {
+			ds.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateDaemonSet{}
+		}
+		maxUnavailable := *cfg.MaxUnavailable
+		ds.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable = &maxUnavailable
+	}
+	if len(cfg.Annotations) > 0 && ds.Spec.Template.Annotations == nil {
+		ds.Spec.Template.Annotations = make(map[string]string)
+	}
+	for key, value := range cfg.Annotations {
+		ds.Spec.Template.Annotations[key] = value
+	}
+
+	for _, volume := range cfg.Volumes {
+		for _, existing := range podSpec.Volumes {
+			if existing.Name == volume.Name {
+				return fmt.Errorf("volume %s is already defined by the linuxptp daemon", volume.Name)
+			}
+		}
+		podSpec.Volumes = append(podSpec.Volumes, volume)
+	}
+
+	for _, containerCfg := range cfg.Containers {
+		var container *corev1.Container
+		for i := range podSpec.Containers {
+			if podSpec.Containers[i].Name == containerCfg.Name {
+				container = &podSpec.Containers[i]
+				break
+			}
+		}
+		if container == nil {
+			// cloud-event-proxy is only deployed when the event publisher is enabled
+			continue
+		}
+		if containerCfg.Resources != nil {
+			container.Resources = *containerCfg.Resources
+		}
+		for _, env := range containerCfg.Env {
+			container.Env = setEnvVar(container.Env, env)
+		}
+		container.VolumeMounts = append(container.VolumeMounts, containerCfg.VolumeMounts...)
+	}
+	return nil
+}
+
+// setEnvVar replaces the variable of the same name, or appends it
+func setEnvVar(env []corev1.EnvVar, envVar corev1.EnvVar) []corev1.EnvVar {
+	for i := range env {
+		if env[i].Name == envVar.Name {
+			env[i] = envVar
+			return env
+		}
+	}
+	return append(env, envVar)
+}
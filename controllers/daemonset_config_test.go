@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kscheme "k8s.io/client-go/kubernetes/scheme"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/render"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func renderTestDaemonSet(t *testing.T, data *render.RenderData) *appsv1.DaemonSet {
+	data.Data["TLSMinVersion"] = "VersionTLS12"
+	data.Data["TLSCipherSuites"] = "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"
+	objs, err := render.RenderTemplate("../bindata/linuxptp/ptp-daemon.yaml", data)
+	assert.NoError(t, err)
+	for _, obj := range objs {
+		if obj.GetKind() == "DaemonSet" {
+			ds := &appsv1.DaemonSet{}
+			assert.NoError(t, kscheme.Scheme.Convert(obj, ds, nil))
+			return ds
+		}
+	}
+	t.Fatal("DaemonSet not rendered")
+	return nil
+}
+
+func getContainer(ds *appsv1.DaemonSet, name string) *corev1.Container {
+	for i := range ds.Spec.Template.Spec.Containers {
+		if ds.Spec.Template.Spec.Containers[i].Name == name {
+			return &ds.Spec.Template.Spec.Containers[i]
+		}
+	}
+	return nil
+}
+
+func TestDaemonLogLevel(t *testing.T) {
+	assert.Equal(t, int32(10), daemonLogLevel(nil))
+	assert.Equal(t, int32(10), daemonLogLevel(&ptpv1.PtpDaemonSetConfig{}))
+	assert.Equal(t, int32(2), daemonLogLevel(&ptpv1.PtpDaemonSetConfig{LogLevel: int32Ptr(2)}))
+
+	data := makeTestRenderData()
+	data.Data["LogLevel"] = int32(2)
+	ds := renderTestDaemonSet(t, data)
+	container := getContainer(ds, linuxptpDaemonContainer)
+	if assert.NotNil(t, container) {
+		assert.Equal(t, []string{"/usr/local/bin/ptp --alsologtostderr -v 2"}, container.Args)
+	}
+}
+
+func TestApplyDaemonSetConfig(t *testing.T) {
+	ds := renderTestDaemonSet(t, makeTestRenderData())
+	maxUnavailable := intstr.FromInt32(1)
+	cfg := &ptpv1.PtpDaemonSetConfig{
+		Tolerations:       []corev1.Toleration{{Key: "ptp", Operator: corev1.TolerationOpExists}},
+		PriorityClassName: "ptp-critical",
+		Annotations:       map[string]string{"example.com/team": "ran"},
+		MaxUnavailable:    &maxUnavailable,
+		Volumes: []corev1.Volume{{
+			Name:         "gnss",
+			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/dev/gnss0"}},
+		}},
+		Containers: []ptpv1.PtpDaemonContainerConfig{
+			{
+				Name: linuxptpDaemonContainer,
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+				},
+				Env: []corev1.EnvVar{
+					{Name: "LOGS_TO_SOCKET", Value: "false"},
+					{Name: "GNSS_DEVICE", Value: "/dev/gnss0"},
+				},
+				VolumeMounts: []corev1.VolumeMount{{Name: "gnss", MountPath: "/dev/gnss0"}},
+			},
+			{
+				// not deployed when the event publisher is disabled
+				Name: "cloud-event-proxy",
+				Env:  []corev1.EnvVar{{Name: "TRACE", Value: "true"}},
+			},
+		},
+	}
+
+	assert.NoError(t, applyDaemonSetConfig(ds, cfg))
+	podSpec := ds.Spec.Template.Spec
+	assert.Equal(t, cfg.Tolerations, podSpec.Tolerations)
+	assert.Equal(t, "ptp-critical", podSpec.PriorityClassName)
+	assert.Equal(t, intstr.FromInt32(1), *ds.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable)
+	assert.Equal(t, "ran", ds.Spec.Template.Annotations["example.com/team"])
+	assert.Contains(t, ds.Spec.Template.Annotations, "target.workload.openshift.io/management")
+	assert.Equal(t, "gnss", podSpec.Volumes[len(podSpec.Volumes)-1].Name)
+
+	container := getContainer(ds, linuxptpDaemonContainer)
+	if assert.NotNil(t, container) {
+		assert.Equal(t, resource.MustParse("100m"), container.Resources.Requests[corev1.ResourceCPU])
+		assert.NotContains(t, container.Resources.Requests, corev1.ResourceMemory)
+		env := make(map[string]string)
+		for _, envVar := range container.Env {
+			env[envVar.Name] = envVar.Value
+		}
+		assert.Equal(t, "false", env["LOGS_TO_SOCKET"])
+		assert.Equal(t, "/dev/gnss0", env["GNSS_DEVICE"])
+		assert.Equal(t, "gnss", container.VolumeMounts[len(container.VolumeMounts)-1].Name)
+	}
+	assert.Nil(t, getContainer(ds, "cloud-event-proxy"))
+}
+
+func TestApplyDaemonSetConfig_Defaults(t *testing.T) {
+	ds := renderTestDaemonSet(t, makeTestRenderData())
+	rendered := ds.DeepCopy()
+
+	assert.NoError(t, applyDaemonSetConfig(ds, &ptpv1.PtpDaemonSetConfig{}))
+	assert.Equal(t, rendered, ds)
+}
+
+func TestApplyDaemonSetConfig_DuplicateVolume(t *testing.T) {
+	ds := renderTestDaemonSet(t, makeTestRenderData())
+	err := applyDaemonSetConfig(ds, &ptpv1.PtpDaemonSetConfig{
+		Volumes: []corev1.Volume{{Name: "config-volume"}},
+	})
+	assert.ErrorContains(t, err, "volume config-volume is already defined")
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// eventPublisherAPIPath is the path of the O-RAN notification API served by the event publisher
+const eventPublisherAPIPath = "/api/ocloudNotifications/v2"
+
+// eventSubscription is a subscription of the O-RAN notification API
+type eventSubscription struct {
+	ID              string `json:"SubscriptionId,omitempty"`
+	EndpointURI     string `json:"EndpointUri"`
+	URILocation     string `json:"UriLocation,omitempty"`
+	ResourceAddress string `json:"ResourceAddress"`
+}
+
+// eventPublisherClient manages the subscriptions of the event publisher of a node, served at the publisher URL
+type eventPublisherClient interface {
+	ListSubscriptions(ctx context.Context, publisherURL string) ([]eventSubscription, error)
+	CreateSubscription(ctx context.Context, publisherURL string, sub eventSubscription) (eventSubscription, error)
+	// DeleteSubscription deletes a subscription, and succeeds when the subscription does not exist
+	DeleteSubscription(ctx context.Context, publisherURL string, id string) error
+}
+
+// eventPublisherURL returns the URL of the O-RAN notification API of the event publisher Service
+func eventPublisherURL(namespace, serviceName string) string {
+	return fmt.Sprintf("http://%s.%s.svc:%d%s", serviceName, namespace, eventPublisherPort, eventPublisherAPIPath)
+}
+
+// httpEventPublisherClient calls the O-RAN notification API of the event publishers
+type httpEventPublisherClient struct {
+	client *http.Client
+}
+
+func newHTTPEventPublisherClient() *httpEventPublisherClient {
+	return &httpEventPublisherClient{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// do sends a request to the event publisher and decodes the response into out, when not nil
+func (c *httpEventPublisherClient) do(ctx context.Context, method, url string, in, out any, expected ...int) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	ok := false
+	for _, code := range expected {
+		ok = ok || resp.StatusCode == code
+	}
+	if !ok {
+		return fmt.Errorf("%s %s returned %s: %s", method, url, resp.Status, bytes.TrimSpace(data))
+	}
+	if out != nil && len(data) > 0 {
+		if err = json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("%s %s returned an invalid response: %v", method, url, err)
+		}
+	}
+	return nil
+}
+
+func (c *httpEventPublisherClient) ListSubscriptions(ctx context.Context, publisherURL string) ([]eventSubscription, error) {
+	var subs []eventSubscription
+	err := c.do(ctx, http.MethodGet, publisherURL+"/subscriptions", nil, &subs, http.StatusOK)
+	return subs, err
+}
+
+func (c *httpEventPublisherClient) CreateSubscription(ctx context.Context, publisherURL string,
+	sub eventSubscription) (eventSubscription, error) {
+	created := eventSubscription{}
+	err := c.do(ctx, http.MethodPost, publisherURL+"/subscriptions", &sub, &created, http.StatusCreated, http.StatusOK)
+	return created, err
+}
+
+func (c *httpEventPublisherClient) DeleteSubscription(ctx context.Context, publisherURL string, id string) error {
+	return c.do(ctx, http.MethodDelete, publisherURL+"/subscriptions/"+id, nil, nil,
+		http.StatusOK, http.StatusNoContent, http.StatusNotFound)
+}
@@ -0,0 +1,184 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/apply"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/render"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// eventPublisherServicePrefix is the name prefix of the per-node event publisher Services
+const eventPublisherServicePrefix = "ptp-event-publisher-service-"
+
+// shortNodeName returns the node name up to the first dot, the default event publisher Service name suffix
+func shortNodeName(nodeName string) string {
+	return strings.Split(nodeName, ".")[0]
+}
+
+// eventPublisherPods returns the linuxptp daemon pod of each node, which the event publisher Service of the node
+// targets. Pods being deleted or without an IP are skipped.
+func eventPublisherPods(pods []corev1.Pod) map[string]*corev1.Pod {
+	nodePods := make(map[string]*corev1.Pod, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil || pod.Status.PodIP == "" {
+			continue
+		}
+		nodePods[pod.Spec.NodeName] = pod
+	}
+	return nodePods
+}
+
+// setEventServiceEndpoint sets the template data of the endpoint of an event publisher Service to the linuxptp daemon
+// pod of the node, or to no endpoint when the node has no pod
+func setEventServiceEndpoint(data *render.RenderData, pod *corev1.Pod) {
+	data.Data["AddressType"] = string(discoveryv1.AddressTypeIPv4)
+	data.Data["PodName"] = ""
+	data.Data["PodIP"] = ""
+	data.Data["PodReady"] = false
+	if pod == nil {
+		return
+	}
+	if ip := net.ParseIP(pod.Status.PodIP); ip != nil && ip.To4() == nil {
+		data.Data["AddressType"] = string(discoveryv1.AddressTypeIPv6)
+	}
+	data.Data["PodName"] = pod.Name
+	data.Data["PodIP"] = pod.Status.PodIP
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			data.Data["PodReady"] = condition.Status == corev1.ConditionTrue
+		}
+	}
+}
+
+// listEventPublisherPods returns the linuxptp daemon pod of each node
+func (r *PtpOperatorConfigReconciler) listEventPublisherPods(ctx context.Context) (map[string]*corev1.Pod, error) {
+	if r.APIReader == nil {
+		return map[string]*corev1.Pod{}, nil
+	}
+	podList := &corev1.PodList{}
+	if err := r.APIReader.List(ctx, podList, client.InNamespace(names.Namespace), client.MatchingLabels{"role": "ptp"}); err != nil {
+		return nil, fmt.Errorf("failed to list linuxptp daemon pods: %v", err)
+	}
+	return eventPublisherPods(podList.Items), nil
+}
+
+// eventPublisherServiceNames returns the event publisher Service name of each node:
+// ptp-event-publisher-service-<short node name>. Nodes sharing their short name with another node, or whose short
+// name is too long for a Service name, get ptp-event-publisher-service-<short node name>-<node name hash> instead.
+func eventPublisherServiceNames(nodeNames []string) map[string]string {
+	shortNames := map[string]int{}
+	for _, node := range nodeNames {
+		shortNames[shortNodeName(node)]++
+	}
+	serviceNames := make(map[string]string, len(nodeNames))
+	for _, node := range nodeNames {
+		short := shortNodeName(node)
+		name := eventPublisherServicePrefix + short
+		if shortNames[short] > 1 || len(name) > validation.DNS1035LabelMaxLength {
+			hash := fmt.Sprintf("%x", sha256.Sum256([]byte(node)))[:8]
+			maxLength := validation.DNS1035LabelMaxLength - len(eventPublisherServicePrefix) - len(hash) - 1
+			if len(short) > maxLength {
+				short = strings.TrimRight(short[:maxLength], "-")
+			}
+			name = eventPublisherServicePrefix + short + "-" + hash
+		}
+		serviceNames[node] = name
+	}
+	return serviceNames
+}
+
+// getEventPublisherNodes returns the sorted names of the nodes selected by DaemonNodeSelector whose daemon pool has
+// events enabled
+func getEventPublisherNodes(defaultCfg *ptpv1.PtpOperatorConfig, pools []daemonPool, nodeList *corev1.NodeList) []string {
+	eventPools := make(map[string]bool, len(pools))
+	for i := range pools {
+		eventPools[pools[i].name] = pools[i].eventsEnabled()
+	}
+	daemonSelector := labels.SelectorFromSet(defaultCfg.Spec.DaemonNodeSelector)
+	var nodes []string
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if daemonSelector.Matches(labels.Set(node.Labels)) && eventPools[nodeDaemonPool(defaultCfg, node)] {
+			nodes = append(nodes, node.Name)
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// syncEventPublisherServices applies the event publisher Service of each node with events enabled, and deletes the
+// Services of the other nodes. It returns the names of the applied Services.
+func (r *PtpOperatorConfigReconciler) syncEventPublisherServices(
+	ctx context.Context,
+	defaultCfg *ptpv1.PtpOperatorConfig,
+	pools []daemonPool,
+	nodeList *corev1.NodeList,
+) ([]string, error) {
+	nodes := getEventPublisherNodes(defaultCfg, pools, nodeList)
+	serviceNames := eventPublisherServiceNames(nodes)
+	pods, err := r.listEventPublisherPods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data := render.MakeRenderData()
+	data.Data["Namespace"] = names.Namespace
+	services := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		data.Data["FullNodeName"] = node
+		data.Data["ServiceName"] = serviceNames[node]
+		setEventServiceEndpoint(&data, pods[node])
+		objs, err := render.RenderTemplate(filepath.Join(names.ManifestDir, "linuxptp/event-service.yaml"), &data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render event service manifest: %v", err)
+		}
+		for _, obj := range objs {
+			if err = controllerutil.SetControllerReference(defaultCfg, obj, r.Scheme); err != nil {
+				return nil, fmt.Errorf("failed to set owner reference for event service: %v", err)
+			}
+			if err = apply.ApplyObject(ctx, r.Client, obj); err != nil {
+				return nil, fmt.Errorf("failed to apply service object %v with err: %v", obj, err)
+			}
+		}
+		services = append(services, serviceNames[node])
+	}
+	serviceList := &corev1.ServiceList{}
+	err = r.List(ctx, serviceList, client.InNamespace(names.Namespace), client.MatchingLabels{"app": "linuxptp-daemon"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list event services: %v", err)
+	}
+	for i := range serviceList.Items {
+		service := &serviceList.Items[i]
+		if !strings.HasPrefix(service.Name, eventPublisherServicePrefix) || slices.Contains(services, service.Name) {
+			continue
+		}
+		if err = r.Delete(ctx, service); err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to delete event service %s: %v", service.Name, err)
+		}
+		endpointSlice := &discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Namespace: service.Namespace, Name: service.Name}}
+		if err = r.Delete(ctx, endpointSlice); err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to delete event service endpoint slice %s: %v", service.Name, err)
+		}
+		glog.Infof("deleted event service %s", service.Name)
+	}
+	return services, nil
+}
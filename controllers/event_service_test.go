@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/render"
+)
+
+func TestEventPublisherServiceNames(t *testing.T) {
+	longNode := strings.Repeat("a", 40) + ".example.com"
+	serviceNames := eventPublisherServiceNames([]string{"worker-0", "worker-1.siteA", "worker-1.siteB", "gm.example.com", longNode})
+
+	assert.Equal(t, "ptp-event-publisher-service-worker-0", serviceNames["worker-0"])
+	assert.Equal(t, "ptp-event-publisher-service-gm", serviceNames["gm.example.com"])
+	assert.Regexp(t, "^ptp-event-publisher-service-worker-1-[0-9a-f]{8}$", serviceNames["worker-1.siteA"])
+	assert.Regexp(t, "^ptp-event-publisher-service-worker-1-[0-9a-f]{8}$", serviceNames["worker-1.siteB"])
+	assert.NotEqual(t, serviceNames["worker-1.siteA"], serviceNames["worker-1.siteB"])
+	assert.Regexp(t, "^ptp-event-publisher-service-a+-[0-9a-f]{8}$", serviceNames[longNode])
+	assert.Len(t, serviceNames[longNode], 63)
+
+	// the name of a node does not depend on the other nodes once its short name is unique
+	assert.Equal(t, serviceNames["worker-0"], eventPublisherServiceNames([]string{"worker-0"})["worker-0"])
+}
+
+func TestGetEventPublisherNodes(t *testing.T) {
+	cfg := makeDaemonPoolsConfig()
+	cfg.Spec.DaemonNodeSelector = map[string]string{"ptp": ""}
+	pools := getDaemonPools(cfg)
+	nodeList := &corev1.NodeList{Items: []corev1.Node{
+		makeNode("worker-1", map[string]string{"ptp": "", "node-role.kubernetes.io/worker": ""}),
+		makeNode("gm-1", map[string]string{"ptp": "", "ptp/role": "gm", "node-role.kubernetes.io/worker": ""}),
+		makeNode("master-1", map[string]string{"ptp": ""}),
+		makeNode("infra-1", map[string]string{}),
+	}}
+
+	assert.Equal(t, []string{"gm-1", "master-1"}, getEventPublisherNodes(cfg, pools, nodeList))
+
+	cfg.Spec.EventConfig = nil
+	cfg.Spec.DaemonPools[0].EventConfig = nil
+	assert.Empty(t, getEventPublisherNodes(cfg, getDaemonPools(cfg), nodeList))
+}
+
+func renderTestEventService(t *testing.T, pod *corev1.Pod) (*corev1.Service, *discoveryv1.EndpointSlice) {
+	data := render.MakeRenderData()
+	data.Data["Namespace"] = "openshift-ptp"
+	data.Data["FullNodeName"] = "worker-1.siteA"
+	data.Data["ServiceName"] = "ptp-event-publisher-service-worker-1-0123abcd"
+	setEventServiceEndpoint(&data, pod)
+	objs, err := render.RenderTemplate("../bindata/linuxptp/event-service.yaml", &data)
+	if !assert.NoError(t, err) || !assert.Len(t, objs, 2) {
+		t.FailNow()
+	}
+	service := &corev1.Service{}
+	assert.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(objs[0].Object, service))
+	endpointSlice := &discoveryv1.EndpointSlice{}
+	assert.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(objs[1].Object, endpointSlice))
+	return service, endpointSlice
+}
+
+func TestRenderEventService(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "linuxptp-daemon-a"},
+		Spec:       corev1.PodSpec{NodeName: "worker-1.siteA"},
+		Status: corev1.PodStatus{
+			PodIP:      "10.0.0.1",
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	service, endpointSlice := renderTestEventService(t, pod)
+	assert.Equal(t, "ptp-event-publisher-service-worker-1-0123abcd", service.Name)
+	assert.Equal(t, "worker-1.siteA", service.Annotations["ptp.openshift.io/node"])
+	assert.Empty(t, service.Spec.Selector)
+
+	assert.Equal(t, service.Name, endpointSlice.Name)
+	assert.Equal(t, service.Name, endpointSlice.Labels[discoveryv1.LabelServiceName])
+	assert.Equal(t, "linuxptp-daemon", endpointSlice.Labels["app"])
+	assert.Equal(t, discoveryv1.AddressTypeIPv4, endpointSlice.AddressType)
+	if assert.Len(t, endpointSlice.Endpoints, 1) {
+		endpoint := endpointSlice.Endpoints[0]
+		assert.Equal(t, []string{"10.0.0.1"}, endpoint.Addresses)
+		assert.True(t, *endpoint.Conditions.Ready)
+		assert.Equal(t, "worker-1.siteA", *endpoint.NodeName)
+		assert.Equal(t, "linuxptp-daemon-a", endpoint.TargetRef.Name)
+	}
+
+	pod.Status.PodIP = "fd00::1"
+	pod.Status.Conditions = nil
+	_, endpointSlice = renderTestEventService(t, pod)
+	assert.Equal(t, discoveryv1.AddressTypeIPv6, endpointSlice.AddressType)
+	if assert.Len(t, endpointSlice.Endpoints, 1) {
+		assert.False(t, *endpointSlice.Endpoints[0].Conditions.Ready)
+	}
+
+	_, endpointSlice = renderTestEventService(t, nil)
+	assert.Empty(t, endpointSlice.Endpoints)
+	assert.Len(t, endpointSlice.Ports, 1)
+}
+
+func TestEventPublisherPods(t *testing.T) {
+	makePod := func(name, node, ip string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status:     corev1.PodStatus{PodIP: ip},
+		}
+	}
+	deleted := makePod("linuxptp-daemon-old", "worker-1.siteA", "10.0.0.1")
+	deleted.DeletionTimestamp = &metav1.Time{}
+	pods := []corev1.Pod{
+		deleted,
+		makePod("linuxptp-daemon-a", "worker-1.siteA", "10.0.0.1"),
+		makePod("linuxptp-daemon-b", "worker-1.siteB", ""),
+	}
+
+	nodePods := eventPublisherPods(pods)
+	assert.Len(t, nodePods, 1)
+	assert.Equal(t, "linuxptp-daemon-a", nodePods["worker-1.siteA"].Name)
+}
+
+func TestRenderDaemonSetEventService(t *testing.T) {
+	data := makeTestRenderData()
+	data.Data["EnableEventPublisher"] = true
+	data.Data["EventTransportHost"] = DefaultTransportHost()
+	ds := renderTestDaemonSet(t, data)
+
+	// the daemon pods start without waiting for the operator
+	assert.Empty(t, ds.Spec.Template.Spec.InitContainers)
+	proxy := getContainer(ds, "cloud-event-proxy")
+	if assert.NotNil(t, proxy) {
+		assert.Contains(t, proxy.Args, "--transport-host="+DefaultTransportHost())
+	}
+}
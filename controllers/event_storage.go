@@ -0,0 +1,39 @@
+package controllers
+
+import (
+	"slices"
+
+	"github.com/golang/glog"
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/render"
+)
+
+// defaultEventStorageHostPath is the node directory of the hostPath event storage when storage.hostPath is not set
+const defaultEventStorageHostPath = "/var/lib/ptp-events"
+
+// setEventStorageTemplateData sets the storage the event publisher stores the subscriptions in. Storage types that
+// are not supported, such as the storage class names of older releases, use the default emptyDir storage.
+func setEventStorageTemplateData(data *render.RenderData, cfg *ptpv1.PtpEventConfig) {
+	storageType := ptpv1.EventStorageType(DefaultStorageType)
+	storage := &ptpv1.PtpEventStorage{}
+	if cfg != nil {
+		if cfg.Storage != nil {
+			storage = cfg.Storage
+		}
+		switch {
+		case cfg.StorageType == "":
+		case !slices.Contains(ptpv1.EventStorageTypes, cfg.StorageType):
+			glog.Infof("Event storage type '%s' is not supported, using %s.", cfg.StorageType, DefaultStorageType)
+		case cfg.StorageType == ptpv1.EventStoragePersistentVolumeClaim && storage.ClaimName == "":
+			glog.Infof("Event storage type '%s' has no claimName, using %s.", cfg.StorageType, DefaultStorageType)
+		default:
+			storageType = cfg.StorageType
+		}
+	}
+	data.Data["StorageType"] = string(storageType)
+	data.Data["StorageHostPath"] = defaultEventStorageHostPath
+	if storage.HostPath != "" {
+		data.Data["StorageHostPath"] = storage.HostPath
+	}
+	data.Data["StorageClaimName"] = storage.ClaimName
+}
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+)
+
+func TestEventStorageRendering(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *ptpv1.PtpEventConfig
+		storageType string
+		volume      corev1.VolumeSource
+		subPathExpr string
+	}{
+		{
+			name:        "default",
+			cfg:         &ptpv1.PtpEventConfig{EnableEventPublisher: true},
+			storageType: "emptyDir",
+			volume:      corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+		{
+			name:        "legacy storage class",
+			cfg:         &ptpv1.PtpEventConfig{EnableEventPublisher: true, StorageType: "local-sc"},
+			storageType: "emptyDir",
+			volume:      corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+		{
+			name:        "host path",
+			cfg:         &ptpv1.PtpEventConfig{EnableEventPublisher: true, StorageType: ptpv1.EventStorageHostPath},
+			storageType: "hostPath",
+			volume: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{
+				Path: defaultEventStorageHostPath, Type: ptr.To(corev1.HostPathDirectoryOrCreate)}},
+		},
+		{
+			name: "custom host path",
+			cfg: &ptpv1.PtpEventConfig{EnableEventPublisher: true, StorageType: ptpv1.EventStorageHostPath,
+				Storage: &ptpv1.PtpEventStorage{HostPath: "/var/lib/events"}},
+			storageType: "hostPath",
+			volume: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{
+				Path: "/var/lib/events", Type: ptr.To(corev1.HostPathDirectoryOrCreate)}},
+		},
+		{
+			name: "persistent volume claim",
+			cfg: &ptpv1.PtpEventConfig{EnableEventPublisher: true, StorageType: ptpv1.EventStoragePersistentVolumeClaim,
+				Storage: &ptpv1.PtpEventStorage{ClaimName: "ptp-events"}},
+			storageType: "persistentVolumeClaim",
+			volume: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "ptp-events"}},
+			subPathExpr: "$(NODE_NAME)",
+		},
+		{
+			name:        "persistent volume claim without claim",
+			cfg:         &ptpv1.PtpEventConfig{EnableEventPublisher: true, StorageType: ptpv1.EventStoragePersistentVolumeClaim},
+			storageType: "emptyDir",
+			volume:      corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+		{
+			name:        "config map",
+			cfg:         &ptpv1.PtpEventConfig{EnableEventPublisher: true, StorageType: ptpv1.EventStorageConfigMap},
+			storageType: "configMap",
+			volume:      corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := makeTestRenderData()
+			data.Data["EnableEventPublisher"] = true
+			data.Data["EventTransportHost"] = "http://ptp-event-publisher-service-NODE_NAME.openshift-ptp.svc.cluster.local:9043"
+			setEventStorageTemplateData(data, tt.cfg)
+			ds := renderTestDaemonSet(t, data)
+
+			var volume *corev1.Volume
+			for i := range ds.Spec.Template.Spec.Volumes {
+				if ds.Spec.Template.Spec.Volumes[i].Name == "pubsubstore" {
+					volume = &ds.Spec.Template.Spec.Volumes[i]
+				}
+			}
+			if assert.NotNil(t, volume) {
+				assert.Equal(t, tt.volume, volume.VolumeSource)
+			}
+
+			proxy := getContainer(ds, "cloud-event-proxy")
+			if !assert.NotNil(t, proxy) {
+				return
+			}
+			assert.Contains(t, proxy.Env, corev1.EnvVar{Name: "STORAGE_TYPE", Value: tt.storageType})
+			for _, mount := range proxy.VolumeMounts {
+				if mount.Name == "pubsubstore" {
+					assert.Equal(t, "/store", mount.MountPath)
+					assert.Equal(t, tt.subPathExpr, mount.SubPathExpr)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// eventPublisherPort is the port the cloud-event-proxy sidecar serves the event API on
+	eventPublisherPort = 9043
+
+	eventTransportValidConditionType     = "EventTransportValid"
+	eventPublisherAvailableConditionType = "EventPublisherAvailable"
+
+	reasonTransportHostValid     = "TransportHostValid"
+	reasonTransportHostDefaulted = "TransportHostDefaulted"
+	reasonTransportHostInvalid   = "InvalidTransportHost"
+	reasonEndpointsReady         = "EndpointsReady"
+	reasonEndpointsNotReady      = "EndpointsNotReady"
+)
+
+// supportedEventTransports are the URL schemes of the transports supported by the event publisher
+var supportedEventTransports = []string{"http"}
+
+// validateTransportHost checks the transport host of an event config targets a supported transport on the event
+// publisher port. It returns an empty string when the transport host is valid, the problem found otherwise.
+func validateTransportHost(transportHost string) string {
+	u, err := url.Parse(transportHost)
+	if err != nil {
+		return fmt.Sprintf("transportHost '%s' is not a valid URL: %v", transportHost, err)
+	}
+	if !slices.Contains(supportedEventTransports, u.Scheme) {
+		return fmt.Sprintf("transportHost '%s' scheme '%s' is not supported; must be one of ['%s']",
+			transportHost, u.Scheme, strings.Join(supportedEventTransports, "', '"))
+	}
+	if u.Hostname() == "" {
+		return fmt.Sprintf("transportHost '%s' has no host", transportHost)
+	}
+	if port, err := strconv.Atoi(u.Port()); err != nil || port != eventPublisherPort {
+		return fmt.Sprintf("transportHost '%s' port must be the event publisher port %d", transportHost, eventPublisherPort)
+	}
+	// a cluster Service host is <service>.<namespace>.svc[.<cluster domain>]
+	if labels := strings.Split(u.Hostname(), "."); len(labels) >= 3 && labels[2] == "svc" && labels[1] != names.Namespace {
+		return fmt.Sprintf("transportHost '%s' service namespace '%s' is not the operator namespace %s",
+			transportHost, labels[1], names.Namespace)
+	}
+	return ""
+}
+
+// eventTransportCondition reports whether the transport host of each daemon pool with events enabled is valid, nil
+// when no pool has events enabled
+func eventTransportCondition(pools []daemonPool) *metav1.Condition {
+	var problems []string
+	enabled, defaulted := 0, 0
+	for i := range pools {
+		pool := &pools[i]
+		if !pool.eventsEnabled() {
+			continue
+		}
+		enabled++
+		if pool.eventConfig.TransportHost == "" {
+			defaulted++
+			continue
+		}
+		if problem := validateTransportHost(pool.eventConfig.TransportHost); problem != "" {
+			problems = append(problems, fmt.Sprintf("%s: %s", pool.daemonSetName(), problem))
+		}
+	}
+	switch {
+	case enabled == 0:
+		return nil
+	case len(problems) > 0:
+		return &metav1.Condition{
+			Type:    eventTransportValidConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  reasonTransportHostInvalid,
+			Message: strings.Join(problems, "; ") + ", using " + DefaultTransportHost(),
+		}
+	case defaulted > 0:
+		return &metav1.Condition{
+			Type:    eventTransportValidConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  reasonTransportHostDefaulted,
+			Message: "transportHost is not set, using " + DefaultTransportHost(),
+		}
+	}
+	return &metav1.Condition{
+		Type:    eventTransportValidConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonTransportHostValid,
+		Message: "transportHost is valid",
+	}
+}
+
+// eventPublisherCondition reports whether every event publisher Service has a ready endpoint, nil when there is no
+// event publisher Service
+func eventPublisherCondition(services []string, endpointSlices []discoveryv1.EndpointSlice) *metav1.Condition {
+	if len(services) == 0 {
+		return nil
+	}
+	ready := map[string]bool{}
+	for _, endpointSlice := range endpointSlices {
+		service := endpointSlice.Labels[discoveryv1.LabelServiceName]
+		for _, endpoint := range endpointSlice.Endpoints {
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				ready[service] = true
+			}
+		}
+	}
+	var notReady []string
+	for _, service := range services {
+		if !ready[service] {
+			notReady = append(notReady, service)
+		}
+	}
+	if len(notReady) > 0 {
+		slices.Sort(notReady)
+		return &metav1.Condition{
+			Type:   eventPublisherAvailableConditionType,
+			Status: metav1.ConditionFalse,
+			Reason: reasonEndpointsNotReady,
+			Message: fmt.Sprintf("%d of %d event publisher services have no ready endpoint: %s",
+				len(notReady), len(services), strings.Join(notReady, ", ")),
+		}
+	}
+	return &metav1.Condition{
+		Type:    eventPublisherAvailableConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonEndpointsReady,
+		Message: fmt.Sprintf("%d event publisher services have ready endpoints", len(services)),
+	}
+}
+
+// syncEventTransportStatus reports the transport host validity and the availability of the event publisher Services
+// in the PtpOperatorConfig status conditions
+func (r *PtpOperatorConfigReconciler) syncEventTransportStatus(
+	ctx context.Context,
+	defaultCfg *ptpv1.PtpOperatorConfig,
+	pools []daemonPool,
+	services []string,
+) error {
+	endpointSlices := &discoveryv1.EndpointSliceList{}
+	if len(services) > 0 {
+		err := r.List(ctx, endpointSlices, client.InNamespace(names.Namespace),
+			client.MatchingLabels{"app": "linuxptp-daemon"}, client.HasLabels{discoveryv1.LabelServiceName})
+		if err != nil {
+			return fmt.Errorf("failed to list event publisher endpoint slices: %v", err)
+		}
+	}
+	setOrRemoveStatusCondition(&defaultCfg.Status.Conditions, eventTransportValidConditionType, eventTransportCondition(pools))
+	setOrRemoveStatusCondition(&defaultCfg.Status.Conditions, eventPublisherAvailableConditionType,
+		eventPublisherCondition(services, endpointSlices.Items))
+	return nil
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
+)
+
+func TestValidateTransportHost(t *testing.T) {
+	original := names.Namespace
+	defer func() { names.Namespace = original }()
+	names.Namespace = "openshift-ptp"
+
+	tests := []struct {
+		name          string
+		transportHost string
+		problem       string
+	}{
+		{
+			name:          "default",
+			transportHost: DefaultTransportHost(),
+		},
+		{
+			name:          "external host",
+			transportHost: "http://my-host:9043",
+		},
+		{
+			name:          "invalid url",
+			transportHost: "http://my host:9043",
+			problem:       "is not a valid URL",
+		},
+		{
+			name:          "unsupported scheme",
+			transportHost: "amqp://amq-router.amq-router.svc.cluster.local",
+			problem:       "scheme 'amqp' is not supported; must be one of ['http']",
+		},
+		{
+			name:          "missing host",
+			transportHost: "http://:9043",
+			problem:       "has no host",
+		},
+		{
+			name:          "missing port",
+			transportHost: "http://ptp-event-publisher-service-NODE_NAME.openshift-ptp.svc.cluster.local",
+			problem:       "port must be the event publisher port 9043",
+		},
+		{
+			name:          "wrong port",
+			transportHost: "http://ptp-event-publisher-service-NODE_NAME.openshift-ptp.svc.cluster.local:8080",
+			problem:       "port must be the event publisher port 9043",
+		},
+		{
+			name:          "other namespace",
+			transportHost: "http://ptp-event-publisher-service-NODE_NAME.ptp.svc.cluster.local:9043",
+			problem:       "service namespace 'ptp' is not the operator namespace openshift-ptp",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := validateTransportHost(tt.transportHost)
+			if tt.problem == "" {
+				assert.Empty(t, problem)
+			} else {
+				assert.Contains(t, problem, tt.problem)
+			}
+		})
+	}
+}
+
+func TestEventTransportCondition(t *testing.T) {
+	pools := []daemonPool{{name: ""}, {name: "gm", eventConfig: &ptpv1.PtpEventConfig{}}}
+	assert.Nil(t, eventTransportCondition(pools))
+
+	pools[1].eventConfig = &ptpv1.PtpEventConfig{EnableEventPublisher: true}
+	cond := eventTransportCondition(pools)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, reasonTransportHostDefaulted, cond.Reason)
+
+	pools[1].eventConfig.TransportHost = DefaultTransportHost()
+	cond = eventTransportCondition(pools)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, reasonTransportHostValid, cond.Reason)
+
+	pools[1].eventConfig.TransportHost = "http://my-host:8080"
+	cond = eventTransportCondition(pools)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, reasonTransportHostInvalid, cond.Reason)
+	assert.Contains(t, cond.Message, "linuxptp-daemon-gm: transportHost 'http://my-host:8080' port must be")
+}
+
+func makeEndpointSlice(service string, ready ...bool) discoveryv1.EndpointSlice {
+	endpointSlice := discoveryv1.EndpointSlice{}
+	endpointSlice.Labels = map[string]string{discoveryv1.LabelServiceName: service}
+	for i := range ready {
+		endpointSlice.Endpoints = append(endpointSlice.Endpoints, discoveryv1.Endpoint{
+			Addresses:  []string{"10.0.0.1"},
+			Conditions: discoveryv1.EndpointConditions{Ready: &ready[i]},
+		})
+	}
+	return endpointSlice
+}
+
+func TestEventPublisherCondition(t *testing.T) {
+	assert.Nil(t, eventPublisherCondition(nil, nil))
+
+	services := []string{"ptp-event-publisher-service-worker-1", "ptp-event-publisher-service-worker-0"}
+	cond := eventPublisherCondition(services, []discoveryv1.EndpointSlice{
+		makeEndpointSlice("ptp-event-publisher-service-worker-0", true),
+		makeEndpointSlice("ptp-event-publisher-service-worker-1", false),
+	})
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, reasonEndpointsNotReady, cond.Reason)
+	assert.Equal(t, "1 of 2 event publisher services have no ready endpoint: ptp-event-publisher-service-worker-1",
+		cond.Message)
+
+	cond = eventPublisherCondition(services, []discoveryv1.EndpointSlice{
+		makeEndpointSlice("ptp-event-publisher-service-worker-0", true),
+		makeEndpointSlice("ptp-event-publisher-service-worker-1", false, true),
+	})
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, reasonEndpointsReady, cond.Reason)
+}
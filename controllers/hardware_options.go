@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	ptpv2alpha1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v2alpha1"
+)
+
+// hardwareOptionsDir is the directory of the hardware-specific options files, relative to the manifest directory.
+// The options of a hardwareSpecificDefinitions key such as intel/e810 are read from hardware/intel/e810.yaml.
+const hardwareOptionsDir = "hardware"
+
+// loadHardwareOptions reads the hardware-specific options file of a hardwareSpecificDefinitions key. It returns nil
+// when the key is not set or the hardware has no options file.
+func loadHardwareOptions(manifestDir, key string) (*ptpv2alpha1.HardwareOptionsConfig, error) {
+	if key == "" {
+		return nil, nil
+	}
+	if filepath.IsAbs(key) || strings.Contains(key, "..") {
+		return nil, fmt.Errorf("invalid hardwareSpecificDefinitions %s", key)
+	}
+	content, err := os.ReadFile(filepath.Join(manifestDir, hardwareOptionsDir, key+".yaml"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the hardware options of %s: %v", key, err)
+	}
+	options := &ptpv2alpha1.HardwareOptionsConfig{}
+	if err := yaml.Unmarshal(content, options); err != nil {
+		return nil, fmt.Errorf("hardware options of %s are invalid: %v", key, err)
+	}
+	return options, nil
+}
+
+// hardwareOscillator returns the oscillator of the hardware-specific defaults of a subsystem, nil if unknown
+func hardwareOscillator(manifestDir string, subsystem *ptpv2alpha1.Subsystem) (*ptpv2alpha1.OscillatorModel, error) {
+	options, err := loadHardwareOptions(manifestDir, subsystem.HardwareSpecificDefinitions)
+	if err != nil || options == nil {
+		return nil, err
+	}
+	return options.Oscillator, nil
+}
@@ -19,11 +19,17 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
 	ptpv2alpha1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v2alpha1"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
@@ -44,6 +50,21 @@ type HardwareConfigReconciler struct {
 //+kubebuilder:rbac:groups=ptp.openshift.io,resources=hardwareconfigs/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=ptp.openshift.io,resources=hardwareconfigs/finalizers,verbs=update
 //+kubebuilder:rbac:groups=ptp.openshift.io,resources=ptpconfigs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=ptp.openshift.io,resources=nodeptpdevices,verbs=get;list;watch
+
+const (
+	// hardwareMatchedConditionType reports whether the nodes recommended the related PTP profile
+	// have hardware matching the hardware selector
+	hardwareMatchedConditionType = "HardwareMatched"
+	// holdoverBudgetConditionType reports whether the DPLL holdover parameters are achievable
+	// and consistent with the related PTP profile
+	holdoverBudgetConditionType = "HoldoverBudgetValid"
+
+	// hardwareConfigRelatedProfileIndex indexes HardwareConfigs by Spec.RelatedPtpProfileName
+	hardwareConfigRelatedProfileIndex = "spec.relatedPtpProfileName"
+	// ptpConfigMatchedProfileIndex indexes PtpConfigs by the profiles in Status.MatchList
+	ptpConfigMatchedProfileIndex = "status.matchList.profile"
+)
 
 func (r *HardwareConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconcile.Result, error) {
 	reqLogger := r.Log.WithValues("Request.Namespace", req.Namespace, "Request.Name", req.Name)
@@ -59,74 +80,72 @@ func (r *HardwareConfigReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return reconcile.Result{}, err
 	}
 
-	// Get all PTP configs
+	// Get the PTP configs that recommend the related PTP profile to a node
 	ptpConfigList := &ptpv1.PtpConfigList{}
-	err = r.List(ctx, ptpConfigList, &client.ListOptions{})
-	if err != nil {
-		if errors.IsNotFound(err) {
-			return reconcile.Result{}, nil
+	if hardwareConfig.Spec.RelatedPtpProfileName != "" {
+		err = r.List(ctx, ptpConfigList, client.MatchingFields{ptpConfigMatchedProfileIndex: hardwareConfig.Spec.RelatedPtpProfileName})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return reconcile.Result{}, nil
+			}
+			return reconcile.Result{}, err
 		}
+	}
+
+	// Get the hardware inventory of all nodes
+	nodePtpDeviceList := &ptpv1.NodePtpDeviceList{}
+	err = r.List(ctx, nodePtpDeviceList, client.InNamespace(names.Namespace))
+	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	if err = r.syncHardwareConfigStatus(ctx, hardwareConfig, ptpConfigList); err != nil {
+	if err = r.syncHardwareConfigStatus(ctx, hardwareConfig, ptpConfigList, nodePtpDeviceList); err != nil {
 		return reconcile.Result{}, err
 	}
 
 	return reconcile.Result{}, nil
 }
 
-// syncHardwareConfigStatus updates the HardwareConfig status based on PTP config status
-func (r *HardwareConfigReconciler) syncHardwareConfigStatus(ctx context.Context, hardwareConfig *ptpv2alpha1.HardwareConfig, ptpConfigList *ptpv1.PtpConfigList) error {
+// syncHardwareConfigStatus updates the HardwareConfig status based on PTP config status and node hardware inventory
+func (r *HardwareConfigReconciler) syncHardwareConfigStatus(ctx context.Context, hardwareConfig *ptpv2alpha1.HardwareConfig,
+	ptpConfigList *ptpv1.PtpConfigList, nodePtpDeviceList *ptpv1.NodePtpDeviceList) error {
 	reqLogger := r.Log.WithValues("HardwareConfig", hardwareConfig.Name)
 
-	// If RelatedPtpProfileName is not set, clear the status
-	if hardwareConfig.Spec.RelatedPtpProfileName == "" {
-		if len(hardwareConfig.Status.MatchedNodes) > 0 {
-			hardwareConfig.Status.MatchedNodes = nil
-			err := r.Status().Update(ctx, hardwareConfig)
-			if err != nil {
-				return fmt.Errorf("failed to update hardware config status: %v", err)
-			}
-		}
-		reqLogger.Info("RelatedPtpProfileName not set, clearing matched nodes")
-		return nil
+	holdover, holdoverCondition := holdoverBudgetStatus(hardwareConfig, ptpConfigList, names.ManifestDir)
+	statusChanged := !reflect.DeepEqual(holdover, hardwareConfig.Status.Holdover)
+	if setOrRemoveStatusCondition(&hardwareConfig.Status.Conditions, holdoverBudgetConditionType, holdoverCondition) {
+		statusChanged = true
+	}
+	if holdoverCondition != nil && holdoverCondition.Status == metav1.ConditionFalse {
+		reqLogger.Info("Holdover budget invalid", "reason", holdoverCondition.Reason, "message", holdoverCondition.Message)
 	}
 
-	// Find nodes that have been recommended the related PTP profile
 	var matchedNodes []ptpv2alpha1.MatchedNode
-	for _, ptpConfig := range ptpConfigList.Items {
-		for _, match := range ptpConfig.Status.MatchList {
-			if match.Profile != nil && *match.Profile == hardwareConfig.Spec.RelatedPtpProfileName {
-				if match.NodeName != nil {
-					matchedNodes = append(matchedNodes, ptpv2alpha1.MatchedNode{
-						NodeName:   *match.NodeName,
-						PtpProfile: hardwareConfig.Spec.RelatedPtpProfileName,
-					})
-					reqLogger.Info("Found matching node", "node", *match.NodeName, "ptpProfile", hardwareConfig.Spec.RelatedPtpProfileName)
-				}
-			}
+	var condition *metav1.Condition
+	if hardwareConfig.Spec.RelatedPtpProfileName == "" {
+		// If RelatedPtpProfileName is not set, clear the matched nodes
+		reqLogger.Info("RelatedPtpProfileName not set, clearing matched nodes")
+	} else {
+		matchedNodes, condition = matchHardwareConfigNodes(hardwareConfig, ptpConfigList, nodePtpDeviceList)
+		for _, node := range matchedNodes {
+			reqLogger.Info("Found matching node", "node", node.NodeName, "ptpProfile", node.PtpProfile)
 		}
 	}
 
 	// Update status if it has changed
-	statusChanged := len(matchedNodes) != len(hardwareConfig.Status.MatchedNodes)
-	if !statusChanged && len(matchedNodes) > 0 {
-		// Check if the matched nodes are the same
-		nodeMap := make(map[string]bool)
-		for _, node := range hardwareConfig.Status.MatchedNodes {
-			nodeMap[node.NodeName] = true
-		}
-		for _, node := range matchedNodes {
-			if !nodeMap[node.NodeName] {
-				statusChanged = true
-				break
-			}
-		}
+	if !matchedNodesEqual(matchedNodes, hardwareConfig.Status.MatchedNodes) {
+		statusChanged = true
+	}
+	if setOrRemoveStatusCondition(&hardwareConfig.Status.Conditions, hardwareMatchedConditionType, condition) {
+		statusChanged = true
+	}
+	if condition != nil && condition.Status == metav1.ConditionFalse {
+		reqLogger.Info("Hardware selector mismatch", "reason", condition.Reason, "message", condition.Message)
 	}
 
 	if statusChanged {
 		hardwareConfig.Status.MatchedNodes = matchedNodes
+		hardwareConfig.Status.Holdover = holdover
 		err := r.Status().Update(ctx, hardwareConfig)
 		if err != nil {
 			return fmt.Errorf("failed to update hardware config status: %v", err)
@@ -137,7 +156,285 @@ func (r *HardwareConfigReconciler) syncHardwareConfigStatus(ctx context.Context,
 	return nil
 }
 
-// HardwareConfigPtpConfigHandler handles PTP config changes and triggers HardwareConfig reconciliation
+// setOrRemoveStatusCondition sets the condition, or removes the condition type when the condition is nil.
+// It returns true if the conditions changed.
+func setOrRemoveStatusCondition(conditions *[]metav1.Condition, conditionType string, condition *metav1.Condition) bool {
+	if condition == nil {
+		return meta.RemoveStatusCondition(conditions, conditionType)
+	}
+	return meta.SetStatusCondition(conditions, *condition)
+}
+
+// holdoverBudgetStatus models the holdover budget of each subsystem and checks it against the holdover
+// timeout of the related PTP profile. The oscillator of a subsystem defaults to the oscillator of its
+// hardware-specific options in manifestDir. The condition is nil when no subsystem has holdover parameters.
+func holdoverBudgetStatus(hardwareConfig *ptpv2alpha1.HardwareConfig, ptpConfigList *ptpv1.PtpConfigList,
+	manifestDir string) ([]ptpv2alpha1.SubsystemHoldoverStatus, *metav1.Condition) {
+	clockChain := hardwareConfig.Spec.Profile.ClockChain
+	if clockChain == nil {
+		return nil, nil
+	}
+
+	holdOverTimeout, profileName := relatedProfileHoldOverTimeout(hardwareConfig.Spec.RelatedPtpProfileName, ptpConfigList)
+
+	var holdover []ptpv2alpha1.SubsystemHoldoverStatus
+	var problems []string
+	reason := "InvalidHoldoverParameters"
+	found := false
+	for i := range clockChain.Structure {
+		subsystem := &clockChain.Structure[i]
+		hp := subsystem.DPLL.HoldoverParameters
+		if hp == nil {
+			continue
+		}
+		found = true
+		oscillator, err := hardwareOscillator(manifestDir, subsystem)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("subsystem %s: %v", subsystem.Name, err))
+			continue
+		}
+		budget, err := hp.Budget(oscillator)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("subsystem %s: %v", subsystem.Name, err))
+			continue
+		}
+
+		// the clock must not report holdover for longer than the DPLL stays in spec
+		limit, limitName := hp.Effective().LocalHoldoverTimeout, "localHoldoverTimeout"
+		if budget != nil {
+			holdover = append(holdover, ptpv2alpha1.SubsystemHoldoverStatus{
+				Subsystem:       subsystem.Name,
+				OscillatorClass: budget.Oscillator.Class,
+				ClassTarget:     hp.ClassTarget,
+				TimeInSpec:      budget.TimeInSpec,
+				TimeToMaxOffset: budget.TimeToMaxOffset,
+			})
+			limit, limitName = budget.TimeInSpec, "expected time in spec"
+		}
+		if holdOverTimeout > 0 && uint64(holdOverTimeout) > limit {
+			if len(problems) == 0 {
+				reason = "HoldoverTimeoutInconsistent"
+			}
+			problems = append(problems, fmt.Sprintf("ptpClockThreshold.holdOverTimeout %ds of profile %s exceeds the %s of subsystem %s (%ds)",
+				holdOverTimeout, profileName, limitName, subsystem.Name, limit))
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+
+	condition := &metav1.Condition{
+		Type:               holdoverBudgetConditionType,
+		ObservedGeneration: hardwareConfig.Generation,
+		LastTransitionTime: metav1.Now(),
+	}
+	if len(problems) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reason
+		condition.Message = strings.Join(problems, "; ")
+	} else {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "HoldoverBudgetValid"
+		condition.Message = "holdover parameters are achievable and consistent with the related PTP profile"
+	}
+	return holdover, condition
+}
+
+// relatedProfileHoldOverTimeout returns the holdover timeout of the related PTP profile, or 0 if it is not found
+func relatedProfileHoldOverTimeout(relatedProfile string, ptpConfigList *ptpv1.PtpConfigList) (int64, string) {
+	if relatedProfile == "" {
+		return 0, ""
+	}
+	for _, ptpConfig := range ptpConfigList.Items {
+		for _, profile := range ptpConfig.Spec.Profile {
+			if profile.Name == nil || profile.PtpClockThreshold == nil {
+				continue
+			}
+			if *profile.Name == relatedProfile || ptpv1.QualifyProfileName(ptpConfig.Name, *profile.Name) == relatedProfile {
+				return profile.PtpClockThreshold.HoldOverTimeout, relatedProfile
+			}
+		}
+	}
+	return 0, ""
+}
+
+// matchedNodesEqual compares matched nodes by content, ignoring their order
+func matchedNodesEqual(a, b []ptpv2alpha1.MatchedNode) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[ptpv2alpha1.MatchedNode]int)
+	for _, node := range a {
+		counts[node]++
+	}
+	for _, node := range b {
+		if counts[node] == 0 {
+			return false
+		}
+		counts[node]--
+	}
+	return true
+}
+
+// matchHardwareConfigNodes returns the nodes that have been recommended the related PTP profile and have
+// hardware matching the hardware selector, and the HardwareMatched condition. The condition is nil when
+// the hardware config has no hardware selector.
+func matchHardwareConfigNodes(hardwareConfig *ptpv2alpha1.HardwareConfig, ptpConfigList *ptpv1.PtpConfigList,
+	nodePtpDeviceList *ptpv1.NodePtpDeviceList) ([]ptpv2alpha1.MatchedNode, *metav1.Condition) {
+	profileName := hardwareConfig.Spec.RelatedPtpProfileName
+
+	// Find nodes that have been recommended the related PTP profile
+	var profileNodes []string
+	seen := make(map[string]bool)
+	for _, ptpConfig := range ptpConfigList.Items {
+		for _, match := range ptpConfig.Status.MatchList {
+			if match.Profile != nil && *match.Profile == profileName && match.NodeName != nil && !seen[*match.NodeName] {
+				seen[*match.NodeName] = true
+				profileNodes = append(profileNodes, *match.NodeName)
+			}
+		}
+	}
+
+	selector := hardwareConfig.Spec.HardwareSelector
+	if selector == nil {
+		var matchedNodes []ptpv2alpha1.MatchedNode
+		for _, nodeName := range profileNodes {
+			matchedNodes = append(matchedNodes, ptpv2alpha1.MatchedNode{NodeName: nodeName, PtpProfile: profileName})
+		}
+		return matchedNodes, nil
+	}
+
+	if err := selector.Validate(); err != nil {
+		return nil, &metav1.Condition{
+			Type:               hardwareMatchedConditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             "InvalidHardwareSelector",
+			Message:            err.Error(),
+			ObservedGeneration: hardwareConfig.Generation,
+			LastTransitionTime: metav1.Now(),
+		}
+	}
+
+	devicesByNode := make(map[string][]ptpv1.PtpDevice)
+	for _, nodePtpDevice := range nodePtpDeviceList.Items {
+		devicesByNode[nodePtpDevice.Name] = nodePtpDevice.Status.Devices
+	}
+
+	var matchedNodes []ptpv2alpha1.MatchedNode
+	var mismatches []string
+	for _, nodeName := range profileNodes {
+		devices, found := devicesByNode[nodeName]
+		if !found {
+			mismatches = append(mismatches, fmt.Sprintf("%s: NodePtpDevice not found", nodeName))
+			continue
+		}
+		if hardwareSelectorMatchesAny(selector, devices) {
+			matchedNodes = append(matchedNodes, ptpv2alpha1.MatchedNode{NodeName: nodeName, PtpProfile: profileName})
+			continue
+		}
+		mismatches = append(mismatches, fmt.Sprintf("%s: no device matches the hardware selector (found %s)",
+			nodeName, describeDevices(devices)))
+	}
+
+	condition := &metav1.Condition{
+		Type:               hardwareMatchedConditionType,
+		ObservedGeneration: hardwareConfig.Generation,
+		LastTransitionTime: metav1.Now(),
+	}
+	switch {
+	case len(mismatches) > 0:
+		sort.Strings(mismatches)
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "HardwareMismatch"
+		condition.Message = fmt.Sprintf("profile %s is recommended to nodes without matching hardware: %s",
+			profileName, strings.Join(mismatches, "; "))
+	case len(profileNodes) == 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "NoNodesMatched"
+		condition.Message = fmt.Sprintf("profile %s is not recommended to any node", profileName)
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "HardwareMatched"
+		condition.Message = fmt.Sprintf("%d node(s) have hardware matching the hardware selector", len(matchedNodes))
+	}
+	return matchedNodes, condition
+}
+
+// hardwareSelectorMatchesAny checks if any device reported by the node matches the hardware selector
+func hardwareSelectorMatchesAny(selector *ptpv2alpha1.HardwareSelector, devices []ptpv1.PtpDevice) bool {
+	for _, device := range devices {
+		if hardwareSelectorMatches(selector, device.HardwareInfo) {
+			return true
+		}
+	}
+	return false
+}
+
+// hardwareSelectorMatches checks if the device hardware info matches every field set in the hardware selector
+func hardwareSelectorMatches(selector *ptpv2alpha1.HardwareSelector, info *ptpv1.HardwareInfo) bool {
+	if info == nil {
+		return false
+	}
+	if !pciIDMatches(selector.VendorID, info.VendorID) ||
+		!pciIDMatches(selector.DeviceID, info.DeviceID) ||
+		!pciIDMatches(selector.SubsystemVendorID, info.SubsystemVendorID) ||
+		!pciIDMatches(selector.SubsystemDeviceID, info.SubsystemDeviceID) {
+		return false
+	}
+	if selector.VPDPartNumber != "" && !strings.EqualFold(strings.TrimSpace(info.VPDPartNumber), selector.VPDPartNumber) {
+		return false
+	}
+	if fw := selector.Firmware; fw != nil {
+		if info.FirmwareVersion == "" {
+			return false
+		}
+		if fw.MinVersion != "" && ptpv2alpha1.CompareFirmwareVersions(info.FirmwareVersion, fw.MinVersion) < 0 {
+			return false
+		}
+		if fw.MaxVersion != "" && ptpv2alpha1.CompareFirmwareVersions(info.FirmwareVersion, fw.MaxVersion) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// pciIDMatches compares PCI identifiers ignoring case and the 0x prefix; an empty selector value matches anything
+func pciIDMatches(selectorID, deviceID string) bool {
+	if selectorID == "" {
+		return true
+	}
+	return normalizePCIID(selectorID) == normalizePCIID(deviceID)
+}
+
+func normalizePCIID(id string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(id)), "0x")
+}
+
+// describeDevices summarizes the node devices for mismatch messages
+func describeDevices(devices []ptpv1.PtpDevice) string {
+	var descriptions []string
+	for _, device := range devices {
+		info := device.HardwareInfo
+		if info == nil {
+			continue
+		}
+		description := fmt.Sprintf("%s %s:%s", device.Name, normalizePCIID(info.VendorID), normalizePCIID(info.DeviceID))
+		if info.VPDPartNumber != "" {
+			description += " pn " + strings.TrimSpace(info.VPDPartNumber)
+		}
+		if token := strings.Fields(info.FirmwareVersion); len(token) > 0 {
+			description += " fw " + token[0]
+		}
+		descriptions = append(descriptions, description)
+	}
+	if len(descriptions) == 0 {
+		return "no hardware info"
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// HardwareConfigPtpConfigHandler handles PTP config changes and triggers reconciliation
+// of the HardwareConfigs related to the profiles recommended by the PTP config
 type HardwareConfigPtpConfigHandler struct {
 	Client client.Client
 	Log    logr.Logger
@@ -145,29 +442,122 @@ type HardwareConfigPtpConfigHandler struct {
 
 // Create handles PTP config creation events
 func (h *HardwareConfigPtpConfigHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
-	h.enqueueHardwareConfigs(ctx, q, "create")
+	h.enqueueHardwareConfigs(ctx, q, "create", evt.Object)
 }
 
 // Update handles PTP config update events
 func (h *HardwareConfigPtpConfigHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
-	h.enqueueHardwareConfigs(ctx, q, "update")
+	// profiles dropped from the match list affect HardwareConfigs as much as the added ones
+	h.enqueueHardwareConfigs(ctx, q, "update", evt.ObjectOld, evt.ObjectNew)
 }
 
 // Delete handles PTP config deletion events
 func (h *HardwareConfigPtpConfigHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
-	h.enqueueHardwareConfigs(ctx, q, "delete")
+	h.enqueueHardwareConfigs(ctx, q, "delete", evt.Object)
 }
 
 // Generic handles generic PTP config events
 func (h *HardwareConfigPtpConfigHandler) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
-	h.enqueueHardwareConfigs(ctx, q, "generic")
+	h.enqueueHardwareConfigs(ctx, q, "generic", evt.Object)
+}
+
+// enqueueHardwareConfigs enqueues reconcile requests for the HardwareConfig resources related
+// to a profile present in the match list of the PTP config(s)
+func (h *HardwareConfigPtpConfigHandler) enqueueHardwareConfigs(ctx context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request], eventType string, objects ...client.Object) {
+	profiles := make(map[string]bool)
+	for _, obj := range objects {
+		for _, profile := range ptpConfigMatchedProfiles(obj) {
+			profiles[profile] = true
+		}
+	}
+
+	requests := make(map[reconcile.Request]bool)
+	for profile := range profiles {
+		hardwareConfigList := &ptpv2alpha1.HardwareConfigList{}
+		err := h.Client.List(ctx, hardwareConfigList, client.MatchingFields{hardwareConfigRelatedProfileIndex: profile})
+		if err != nil {
+			h.Log.Error(err, "failed to list HardwareConfig resources", "ptpProfile", profile)
+			continue
+		}
+		for _, hardwareConfig := range hardwareConfigList.Items {
+			requests[reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      hardwareConfig.Name,
+					Namespace: hardwareConfig.Namespace,
+				},
+			}] = true
+		}
+	}
+
+	for request := range requests {
+		q.Add(request)
+	}
+
+	if len(requests) > 0 {
+		h.Log.Info("PTP config changed, enqueued HardwareConfig reconciliation requests", "count", len(requests), "eventType", eventType)
+	}
 }
 
-// enqueueHardwareConfigs enqueues reconcile requests for all HardwareConfig resources
-func (h *HardwareConfigPtpConfigHandler) enqueueHardwareConfigs(ctx context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request], eventType string) {
-	h.Log.Info("PTP config changed, triggering HardwareConfig reconciliation", "eventType", eventType)
+// ptpConfigMatchedProfiles returns the profiles recommended to a node in the PTP config status
+func ptpConfigMatchedProfiles(obj client.Object) []string {
+	ptpConfig, ok := obj.(*ptpv1.PtpConfig)
+	if !ok {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var profiles []string
+	for _, match := range ptpConfig.Status.MatchList {
+		if match.Profile != nil && *match.Profile != "" && !seen[*match.Profile] {
+			seen[*match.Profile] = true
+			profiles = append(profiles, *match.Profile)
+		}
+	}
+	return profiles
+}
+
+// hardwareConfigRelatedProfile returns the related PTP profile name of a HardwareConfig for indexing
+func hardwareConfigRelatedProfile(obj client.Object) []string {
+	hardwareConfig, ok := obj.(*ptpv2alpha1.HardwareConfig)
+	if !ok || hardwareConfig.Spec.RelatedPtpProfileName == "" {
+		return nil
+	}
+	return []string{hardwareConfig.Spec.RelatedPtpProfileName}
+}
 
-	// Get all HardwareConfig resources
+// HardwareConfigNodePtpDeviceHandler handles NodePtpDevice changes and triggers reconciliation
+// of the HardwareConfigs that select nodes by hardware
+type HardwareConfigNodePtpDeviceHandler struct {
+	Client client.Client
+	Log    logr.Logger
+}
+
+// Create handles NodePtpDevice creation events
+func (h *HardwareConfigNodePtpDeviceHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.enqueueHardwareConfigs(ctx, q, "create")
+}
+
+// Update handles NodePtpDevice update events, ignoring updates that don't change the device inventory
+func (h *HardwareConfigNodePtpDeviceHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	oldDevice, okOld := evt.ObjectOld.(*ptpv1.NodePtpDevice)
+	newDevice, okNew := evt.ObjectNew.(*ptpv1.NodePtpDevice)
+	if okOld && okNew && reflect.DeepEqual(oldDevice.Status.Devices, newDevice.Status.Devices) {
+		return
+	}
+	h.enqueueHardwareConfigs(ctx, q, "update")
+}
+
+// Delete handles NodePtpDevice deletion events
+func (h *HardwareConfigNodePtpDeviceHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.enqueueHardwareConfigs(ctx, q, "delete")
+}
+
+// Generic handles generic NodePtpDevice events
+func (h *HardwareConfigNodePtpDeviceHandler) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.enqueueHardwareConfigs(ctx, q, "generic")
+}
+
+// enqueueHardwareConfigs enqueues reconcile requests for the HardwareConfig resources with a hardware selector
+func (h *HardwareConfigNodePtpDeviceHandler) enqueueHardwareConfigs(ctx context.Context, q workqueue.TypedRateLimitingInterface[reconcile.Request], eventType string) {
 	hardwareConfigList := &ptpv2alpha1.HardwareConfigList{}
 	err := h.Client.List(ctx, hardwareConfigList)
 	if err != nil {
@@ -175,22 +565,37 @@ func (h *HardwareConfigPtpConfigHandler) enqueueHardwareConfigs(ctx context.Cont
 		return
 	}
 
-	// Create reconcile requests for all HardwareConfig resources
+	count := 0
 	for _, hardwareConfig := range hardwareConfigList.Items {
+		if hardwareConfig.Spec.HardwareSelector == nil {
+			continue
+		}
 		q.Add(reconcile.Request{
 			NamespacedName: types.NamespacedName{
 				Name:      hardwareConfig.Name,
 				Namespace: hardwareConfig.Namespace,
 			},
 		})
+		count++
 	}
 
-	h.Log.Info("Enqueued HardwareConfig reconciliation requests", "count", len(hardwareConfigList.Items), "eventType", eventType)
+	if count > 0 {
+		h.Log.Info("NodePtpDevice changed, enqueued HardwareConfig reconciliation requests", "count", count, "eventType", eventType)
+	}
 }
 
 func (r *HardwareConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	ctx := context.Background()
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &ptpv2alpha1.HardwareConfig{}, hardwareConfigRelatedProfileIndex, hardwareConfigRelatedProfile); err != nil {
+		return fmt.Errorf("failed to index HardwareConfig by related PTP profile: %v", err)
+	}
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &ptpv1.PtpConfig{}, ptpConfigMatchedProfileIndex, ptpConfigMatchedProfiles); err != nil {
+		return fmt.Errorf("failed to index PtpConfig by matched profiles: %v", err)
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&ptpv2alpha1.HardwareConfig{}).
 		Watches(&ptpv1.PtpConfig{}, &HardwareConfigPtpConfigHandler{Client: r.Client, Log: r.Log}).
+		Watches(&ptpv1.NodePtpDevice{}, &HardwareConfigNodePtpDeviceHandler{Client: r.Client, Log: r.Log}).
 		Complete(r)
 }
@@ -0,0 +1,333 @@
+package controllers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	ptpv2alpha1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v2alpha1"
+)
+
+func makeHardwareConfig(relatedProfile string, selector *ptpv2alpha1.HardwareSelector) *ptpv2alpha1.HardwareConfig {
+	return &ptpv2alpha1.HardwareConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "hwconfig", Namespace: "openshift-ptp"},
+		Spec: ptpv2alpha1.HardwareConfigSpec{
+			RelatedPtpProfileName: relatedProfile,
+			HardwareSelector:      selector,
+		},
+	}
+}
+
+func makeMatchedPtpConfigList(profile string, nodes ...string) *ptpv1.PtpConfigList {
+	cfg := makePtpConfig("cfg", nil, nil)
+	for _, node := range nodes {
+		cfg.Status.MatchList = append(cfg.Status.MatchList, ptpv1.NodeMatchList{
+			NodeName: strPtr(node),
+			Profile:  strPtr(profile),
+		})
+	}
+	return makePtpConfigList(cfg)
+}
+
+func makeNodePtpDevice(node string, infos ...*ptpv1.HardwareInfo) ptpv1.NodePtpDevice {
+	nodePtpDevice := ptpv1.NodePtpDevice{ObjectMeta: metav1.ObjectMeta{Name: node, Namespace: "openshift-ptp"}}
+	for i, info := range infos {
+		nodePtpDevice.Status.Devices = append(nodePtpDevice.Status.Devices, ptpv1.PtpDevice{
+			Name:         []string{"ens1f0", "ens2f0", "ens3f0"}[i],
+			HardwareInfo: info,
+		})
+	}
+	return nodePtpDevice
+}
+
+var (
+	e810Info = &ptpv1.HardwareInfo{
+		VendorID:        "0x8086",
+		DeviceID:        "0x1593",
+		VPDPartNumber:   "K91258-000",
+		FirmwareVersion: "4.40 0x8001c967 1.3534.0",
+	}
+	e830Info = &ptpv1.HardwareInfo{
+		VendorID:        "0x8086",
+		DeviceID:        "0x12d3",
+		FirmwareVersion: "1.00 0x80004d57 1.3600.0",
+	}
+)
+
+func TestHardwareSelectorMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector ptpv2alpha1.HardwareSelector
+		info     *ptpv1.HardwareInfo
+		want     bool
+	}{
+		{"empty selector", ptpv2alpha1.HardwareSelector{}, e810Info, true},
+		{"nil hardware info", ptpv2alpha1.HardwareSelector{}, nil, false},
+		{"pci ids without prefix", ptpv2alpha1.HardwareSelector{VendorID: "8086", DeviceID: "1593"}, e810Info, true},
+		{"pci ids upper case", ptpv2alpha1.HardwareSelector{VendorID: "0X8086", DeviceID: "0x1593"}, e810Info, true},
+		{"device id differs", ptpv2alpha1.HardwareSelector{VendorID: "8086", DeviceID: "159b"}, e810Info, false},
+		{"subsystem id not reported", ptpv2alpha1.HardwareSelector{SubsystemVendorID: "8086"}, e810Info, false},
+		{"part number", ptpv2alpha1.HardwareSelector{VPDPartNumber: "k91258-000"}, e810Info, true},
+		{"part number differs", ptpv2alpha1.HardwareSelector{VPDPartNumber: "K91258-001"}, e810Info, false},
+		{"firmware in range", ptpv2alpha1.HardwareSelector{
+			Firmware: &ptpv2alpha1.FirmwareVersionRange{MinVersion: "4.20", MaxVersion: "4.60"}}, e810Info, true},
+		{"firmware at max", ptpv2alpha1.HardwareSelector{
+			Firmware: &ptpv2alpha1.FirmwareVersionRange{MaxVersion: "4.40"}}, e810Info, true},
+		{"firmware too old", ptpv2alpha1.HardwareSelector{
+			Firmware: &ptpv2alpha1.FirmwareVersionRange{MinVersion: "4.50"}}, e810Info, false},
+		{"firmware not reported", ptpv2alpha1.HardwareSelector{
+			Firmware: &ptpv2alpha1.FirmwareVersionRange{MinVersion: "1.0"}}, &ptpv1.HardwareInfo{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hardwareSelectorMatches(&tt.selector, tt.info))
+		})
+	}
+}
+
+func TestMatchHardwareConfigNodes_NoSelector(t *testing.T) {
+	hwConfig := makeHardwareConfig("tbc", nil)
+	ptpConfigs := makeMatchedPtpConfigList("tbc", "node-1", "node-2")
+
+	matched, condition := matchHardwareConfigNodes(hwConfig, ptpConfigs, &ptpv1.NodePtpDeviceList{})
+	assert.Nil(t, condition)
+	assert.Equal(t, []ptpv2alpha1.MatchedNode{
+		{NodeName: "node-1", PtpProfile: "tbc"},
+		{NodeName: "node-2", PtpProfile: "tbc"},
+	}, matched)
+}
+
+func TestMatchHardwareConfigNodes_AllMatch(t *testing.T) {
+	hwConfig := makeHardwareConfig("tbc", &ptpv2alpha1.HardwareSelector{VendorID: "8086", DeviceID: "1593"})
+	ptpConfigs := makeMatchedPtpConfigList("tbc", "node-1", "node-2")
+	devices := &ptpv1.NodePtpDeviceList{Items: []ptpv1.NodePtpDevice{
+		makeNodePtpDevice("node-1", e810Info),
+		makeNodePtpDevice("node-2", nil, e810Info),
+	}}
+
+	matched, condition := matchHardwareConfigNodes(hwConfig, ptpConfigs, devices)
+	assert.Len(t, matched, 2)
+	assert.Equal(t, hardwareMatchedConditionType, condition.Type)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "HardwareMatched", condition.Reason)
+}
+
+func TestMatchHardwareConfigNodes_Mismatch(t *testing.T) {
+	hwConfig := makeHardwareConfig("tbc", &ptpv2alpha1.HardwareSelector{VendorID: "8086", DeviceID: "1593"})
+	ptpConfigs := makeMatchedPtpConfigList("tbc", "node-1", "node-2", "node-3")
+	devices := &ptpv1.NodePtpDeviceList{Items: []ptpv1.NodePtpDevice{
+		makeNodePtpDevice("node-1", e810Info),
+		makeNodePtpDevice("node-2", e830Info),
+	}}
+
+	matched, condition := matchHardwareConfigNodes(hwConfig, ptpConfigs, devices)
+	assert.Equal(t, []ptpv2alpha1.MatchedNode{{NodeName: "node-1", PtpProfile: "tbc"}}, matched)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "HardwareMismatch", condition.Reason)
+	assert.Contains(t, condition.Message, "node-2: no device matches the hardware selector (found ens1f0 8086:12d3 fw 1.00)")
+	assert.Contains(t, condition.Message, "node-3: NodePtpDevice not found")
+}
+
+func TestMatchHardwareConfigNodes_InvalidSelector(t *testing.T) {
+	hwConfig := makeHardwareConfig("tbc", &ptpv2alpha1.HardwareSelector{
+		Firmware: &ptpv2alpha1.FirmwareVersionRange{MinVersion: "4.60", MaxVersion: "4.40"},
+	})
+	ptpConfigs := makeMatchedPtpConfigList("tbc", "node-1")
+	devices := &ptpv1.NodePtpDeviceList{Items: []ptpv1.NodePtpDevice{makeNodePtpDevice("node-1", e810Info)}}
+
+	matched, condition := matchHardwareConfigNodes(hwConfig, ptpConfigs, devices)
+	assert.Empty(t, matched)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "InvalidHardwareSelector", condition.Reason)
+}
+
+func TestMatchHardwareConfigNodes_NoNodes(t *testing.T) {
+	hwConfig := makeHardwareConfig("tbc", &ptpv2alpha1.HardwareSelector{VendorID: "8086"})
+
+	matched, condition := matchHardwareConfigNodes(hwConfig, makeMatchedPtpConfigList("other", "node-1"), &ptpv1.NodePtpDeviceList{})
+	assert.Empty(t, matched)
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+}
+
+func TestMatchedNodesEqual(t *testing.T) {
+	nodes := []ptpv2alpha1.MatchedNode{
+		{NodeName: "node-1", PtpProfile: "tbc"},
+		{NodeName: "node-2", PtpProfile: "tbc"},
+	}
+	assert.True(t, matchedNodesEqual(nil, nil))
+	assert.True(t, matchedNodesEqual(nodes, []ptpv2alpha1.MatchedNode{nodes[1], nodes[0]}))
+	assert.False(t, matchedNodesEqual(nodes, nodes[:1]))
+	assert.False(t, matchedNodesEqual(nodes, []ptpv2alpha1.MatchedNode{
+		{NodeName: "node-1", PtpProfile: "tbc"},
+		{NodeName: "node-2", PtpProfile: "tgm"},
+	}), "a profile change must be detected")
+}
+
+func TestPtpConfigMatchedProfiles(t *testing.T) {
+	cfg := makePtpConfig("cfg", nil, nil)
+	cfg.Status.MatchList = []ptpv1.NodeMatchList{
+		{NodeName: strPtr("node-1"), Profile: strPtr("tbc")},
+		{NodeName: strPtr("node-2"), Profile: strPtr("tbc")},
+		{NodeName: strPtr("node-3"), Profile: strPtr("tgm")},
+		{NodeName: strPtr("node-4"), Profile: nil},
+	}
+	assert.Equal(t, []string{"tbc", "tgm"}, ptpConfigMatchedProfiles(&cfg))
+	assert.Nil(t, ptpConfigMatchedProfiles(makeHardwareConfig("tbc", nil)))
+}
+
+func TestHardwareConfigRelatedProfile(t *testing.T) {
+	assert.Equal(t, []string{"tbc"}, hardwareConfigRelatedProfile(makeHardwareConfig("tbc", nil)))
+	assert.Nil(t, hardwareConfigRelatedProfile(makeHardwareConfig("", nil)))
+	cfg := makePtpConfig("cfg", nil, nil)
+	assert.Nil(t, hardwareConfigRelatedProfile(&cfg))
+}
+
+func makeHoldoverHardwareConfig(relatedProfile string, params *ptpv2alpha1.HoldoverParameters) *ptpv2alpha1.HardwareConfig {
+	hwConfig := makeHardwareConfig(relatedProfile, nil)
+	hwConfig.Spec.Profile.ClockChain = &ptpv2alpha1.ClockChain{
+		Structure: []ptpv2alpha1.Subsystem{
+			{Name: "leader", DPLL: ptpv2alpha1.DPLL{HoldoverParameters: params}},
+			{Name: "follower"},
+		},
+	}
+	return hwConfig
+}
+
+func makeHoldoverPtpConfigList(holdOverTimeout int64) *ptpv1.PtpConfigList {
+	profile := makeProfile("tbc", nil)
+	profile.PtpClockThreshold = &ptpv1.PtpClockThreshold{HoldOverTimeout: holdOverTimeout}
+	return makePtpConfigList(makePtpConfig("cfg", []ptpv1.PtpProfile{profile}, nil))
+}
+
+func TestHoldoverBudgetStatus_NoHoldoverParameters(t *testing.T) {
+	holdover, condition := holdoverBudgetStatus(makeHoldoverHardwareConfig("tbc", nil), makeHoldoverPtpConfigList(5), "")
+	assert.Nil(t, holdover)
+	assert.Nil(t, condition)
+}
+
+func TestHoldoverBudgetStatus_Valid(t *testing.T) {
+	hwConfig := makeHoldoverHardwareConfig("tbc", &ptpv2alpha1.HoldoverParameters{
+		OscillatorClass: ptpv2alpha1.OscillatorClassOCXO,
+		ClassTarget:     ptpv2alpha1.ClockClassTargetA,
+	})
+
+	holdover, condition := holdoverBudgetStatus(hwConfig, makeHoldoverPtpConfigList(5), "")
+	assert.Len(t, holdover, 1)
+	assert.Equal(t, "leader", holdover[0].Subsystem)
+	assert.Equal(t, ptpv2alpha1.OscillatorClassOCXO, holdover[0].OscillatorClass)
+	assert.Greater(t, holdover[0].TimeInSpec, uint64(0))
+	assert.Equal(t, holdoverBudgetConditionType, condition.Type)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+}
+
+func TestHoldoverBudgetStatus_Unachievable(t *testing.T) {
+	hwConfig := makeHoldoverHardwareConfig("tbc", &ptpv2alpha1.HoldoverParameters{
+		OscillatorClass: ptpv2alpha1.OscillatorClassTCXO,
+	})
+
+	holdover, condition := holdoverBudgetStatus(hwConfig, makeHoldoverPtpConfigList(5), "")
+	assert.Empty(t, holdover)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "InvalidHoldoverParameters", condition.Reason)
+	assert.Contains(t, condition.Message, "subsystem leader: localHoldoverTimeout 14400s is not achievable")
+}
+
+func TestHoldoverBudgetStatus_ProfileTimeoutExceedsTimeInSpec(t *testing.T) {
+	hwConfig := makeHoldoverHardwareConfig("cfg_tbc", &ptpv2alpha1.HoldoverParameters{
+		OscillatorClass: ptpv2alpha1.OscillatorClassOCXO,
+	})
+
+	holdover, condition := holdoverBudgetStatus(hwConfig, makeHoldoverPtpConfigList(3600), "")
+	assert.Len(t, holdover, 1)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, "HoldoverTimeoutInconsistent", condition.Reason)
+	assert.Contains(t, condition.Message, "holdOverTimeout 3600s of profile cfg_tbc exceeds the expected time in spec of subsystem leader")
+}
+
+func TestHoldoverBudgetStatus_ProfileTimeoutExceedsLocalTimeout(t *testing.T) {
+	hwConfig := makeHoldoverHardwareConfig("tbc", &ptpv2alpha1.HoldoverParameters{LocalHoldoverTimeout: 60})
+
+	holdover, condition := holdoverBudgetStatus(hwConfig, makeHoldoverPtpConfigList(120), "")
+	assert.Empty(t, holdover)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Contains(t, condition.Message, "exceeds the localHoldoverTimeout of subsystem leader (60s)")
+}
+
+func TestHoldoverBudgetStatus_HardwareOscillator(t *testing.T) {
+	manifestDir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(manifestDir, "hardware", "acme"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(manifestDir, "hardware", "acme", "tcxo.yaml"),
+		[]byte("oscillator:\n  class: TCXO\n"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(manifestDir, "hardware", "acme", "stable-tcxo.yaml"),
+		[]byte("oscillator:\n  class: TCXO\n  frequencyStability: 50\n  aging: 100\n"), 0o600))
+
+	tests := []struct {
+		name       string
+		hardware   string
+		params     ptpv2alpha1.HoldoverParameters
+		wantStatus metav1.ConditionStatus
+		wantInSpec uint64
+		wantMsg    string
+	}{
+		{
+			name:       "no hardware options",
+			hardware:   "acme/unknown",
+			wantStatus: metav1.ConditionTrue,
+		},
+		{
+			name:       "hardware oscillator class",
+			hardware:   "acme/tcxo",
+			wantStatus: metav1.ConditionFalse,
+			wantMsg:    "subsystem leader: localHoldoverTimeout 14400s is not achievable with a TCXO oscillator",
+		},
+		{
+			name:       "hardware oscillator coefficients",
+			hardware:   "acme/stable-tcxo",
+			wantStatus: metav1.ConditionTrue,
+			wantInSpec: 1900,
+		},
+		{
+			name:       "user oscillator overrides the hardware oscillator",
+			hardware:   "acme/tcxo",
+			params:     ptpv2alpha1.HoldoverParameters{Oscillator: &ptpv2alpha1.OscillatorModel{Class: ptpv2alpha1.OscillatorClassOCXO}},
+			wantStatus: metav1.ConditionTrue,
+			wantInSpec: 1900,
+		},
+		{
+			name:       "invalid hardware key",
+			hardware:   "../acme/tcxo",
+			wantStatus: metav1.ConditionFalse,
+			wantMsg:    "subsystem leader: invalid hardwareSpecificDefinitions ../acme/tcxo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			hwConfig := makeHoldoverHardwareConfig("tbc", &params)
+			hwConfig.Spec.Profile.ClockChain.Structure[0].HardwareSpecificDefinitions = tt.hardware
+
+			holdover, condition := holdoverBudgetStatus(hwConfig, makeHoldoverPtpConfigList(5), manifestDir)
+			assert.Equal(t, tt.wantStatus, condition.Status)
+			assert.Contains(t, condition.Message, tt.wantMsg)
+			if tt.wantInSpec == 0 {
+				assert.Empty(t, holdover)
+				return
+			}
+			if assert.Len(t, holdover, 1) {
+				assert.GreaterOrEqual(t, holdover[0].TimeInSpec, tt.wantInSpec)
+			}
+		})
+	}
+}
+
+func TestLoadHardwareOptions_Bundled(t *testing.T) {
+	options, err := loadHardwareOptions("../bindata", "intel/e810")
+	assert.NoError(t, err)
+	if assert.NotNil(t, options) && assert.NotNil(t, options.Oscillator) {
+		assert.Equal(t, ptpv2alpha1.OscillatorClassOCXO, options.Oscillator.Class)
+	}
+}
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/golang/glog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
+)
+
+const (
+	highAvailabilityConditionType = "HighAvailabilitySourcesAvailable"
+
+	reasonSourcesAvailable = "SourcesAvailable"
+	reasonSourcesMissing   = "SourcesMissing"
+)
+
+// getHighAvailabilityStates returns the sources selected by phc2sys on each node, as reported by the linuxptp
+// daemons in the NodePtpDevice status
+func (r *PtpConfigReconciler) getHighAvailabilityStates(ctx context.Context) map[string][]ptpv1.PhcHighAvailabilityState {
+	devices := &ptpv1.NodePtpDeviceList{}
+	if err := r.List(ctx, devices, client.InNamespace(names.Namespace)); err != nil {
+		glog.Errorf("failed to list NodePtpDevices, the active high availability sources are not reported: %v", err)
+		return nil
+	}
+	states := make(map[string][]ptpv1.PhcHighAvailabilityState, len(devices.Items))
+	for _, device := range devices.Items {
+		if len(device.Status.HighAvailability) > 0 {
+			states[device.Name] = device.Status.HighAvailability
+		}
+	}
+	return states
+}
+
+// getNodeHighAvailabilityStatus returns the status of the high availability profiles of a PtpConfig on a node.
+// profiles are the profiles of the PtpConfig recommended to the node, nodeProfiles the profiles delivered to the
+// node, with their sources rendered into haProfiles and named after the node profile names, and states the sources
+// selected on the node.
+func getNodeHighAvailabilityStatus(nodeName string, ptpConfig *ptpv1.PtpConfig, profiles, nodeProfiles []ptpv1.PtpProfile,
+	states []ptpv1.PhcHighAvailabilityState) []ptpv1.NodeHighAvailabilityStatus {
+	delivered := make(map[string]*ptpv1.PtpProfile, len(nodeProfiles))
+	for i := range nodeProfiles {
+		if nodeProfiles[i].Name != nil {
+			delivered[*nodeProfiles[i].Name] = &nodeProfiles[i]
+		}
+	}
+
+	var status []ptpv1.NodeHighAvailabilityStatus
+	for _, profile := range profiles {
+		if profile.HighAvailability == nil || profile.Name == nil {
+			continue
+		}
+		qualified := ptpv1.NodeProfileName(ptpConfig.Namespace, ptpv1.QualifyProfileName(ptpConfig.Name, *profile.Name))
+		nodeProfile, ok := delivered[qualified]
+		if !ok {
+			continue
+		}
+		profileStatus := ptpv1.NodeHighAvailabilityStatus{NodeName: nodeName, Profile: qualified, Sources: []string{}}
+		if haProfiles := nodeProfile.PtpSettings["haProfiles"]; haProfiles != "" {
+			profileStatus.Sources = strings.Split(haProfiles, ",")
+		}
+		for _, source := range profileStatus.Sources {
+			if _, ok := delivered[source]; !ok {
+				profileStatus.MissingSources = append(profileStatus.MissingSources, source)
+			}
+		}
+		for _, state := range states {
+			if state.Profile == qualified {
+				profileStatus.ActiveSource = state.ActiveSource
+				profileStatus.LastSwitchover = state.LastSwitchover
+			}
+		}
+		status = append(status, profileStatus)
+	}
+	return status
+}
+
+// highAvailabilityCondition reports whether the sources of the high availability profiles are delivered to their
+// nodes. The condition is nil when no high availability profile is delivered.
+func highAvailabilityCondition(status []ptpv1.NodeHighAvailabilityStatus) *metav1.Condition {
+	if len(status) == 0 {
+		return nil
+	}
+	var problems []string
+	for _, profileStatus := range status {
+		if len(profileStatus.MissingSources) > 0 {
+			problems = append(problems, fmt.Sprintf("node %s profile %s: sources %s are not recommended to the node",
+				profileStatus.NodeName, profileStatus.Profile, strings.Join(profileStatus.MissingSources, ", ")))
+		}
+	}
+	if len(problems) > 0 {
+		return &metav1.Condition{
+			Type:    highAvailabilityConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  reasonSourcesMissing,
+			Message: strings.Join(problems, "; "),
+		}
+	}
+	return &metav1.Condition{
+		Type:    highAvailabilityConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonSourcesAvailable,
+		Message: "the sources of the high availability profiles are recommended to their nodes",
+	}
+}
+
+// highAvailabilityStatePredicate filters the NodePtpDevice events that change the selected sources
+func highAvailabilityStatePredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldDevice, ok := e.ObjectOld.(*ptpv1.NodePtpDevice)
+			if !ok {
+				return false
+			}
+			newDevice, ok := e.ObjectNew.(*ptpv1.NodePtpDevice)
+			if !ok {
+				return false
+			}
+			return !reflect.DeepEqual(oldDevice.Status.HighAvailability, newDevice.Status.HighAvailability)
+		},
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
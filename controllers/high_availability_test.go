@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+)
+
+func TestGetNodeHighAvailabilityStatus(t *testing.T) {
+	node := makeNode("worker-1", map[string]string{"ptp/ha": ""})
+	ha := makeProfile("phc2sys-ha", nil)
+	ha.HighAvailability = &ptpv1.PhcHighAvailability{Sources: []ptpv1.PhcHighAvailabilitySource{
+		{Profile: "bc1"}, {Profile: "bc2", MaxClockClass: int64Ptr(6)},
+	}}
+	haConfig := makePtpConfig("phc2sys-config", []ptpv1.PtpProfile{ha},
+		[]ptpv1.PtpRecommend{makeRecommend("phc2sys-ha", 5, "ptp/ha")})
+	list := makePtpConfigList(
+		makePtpConfig("bc-primary", []ptpv1.PtpProfile{makeProfile("bc1", nil)},
+			[]ptpv1.PtpRecommend{makeRecommend("bc1", 5, "ptp/ha")}),
+		// bc2 is recommended to other nodes
+		makePtpConfig("bc-secondary", []ptpv1.PtpProfile{makeProfile("bc2", nil)},
+			[]ptpv1.PtpRecommend{makeRecommend("bc2", 5, "ptp/other")}),
+		haConfig,
+	)
+
+	nodeProfiles, err := getRecommendProfiles(list, node)
+	assert.NoError(t, err)
+	configProfiles, err := getRecommendNodePtpProfilesForConfig(&haConfig, node)
+	assert.NoError(t, err)
+
+	// the selection criteria are delivered to the daemon aligned with the qualified sources
+	var haSettings map[string]string
+	for _, profile := range nodeProfiles {
+		if *profile.Name == "phc2sys-config_phc2sys-ha" {
+			haSettings = profile.PtpSettings
+		}
+	}
+	assert.Equal(t, "bc-primary_bc1,bc-secondary_bc2", haSettings["haProfiles"])
+	assert.Equal(t, ",6", haSettings["haMaxClockClass"])
+	assert.Equal(t, "TimeReceiver,TimeReceiver", haSettings["haPortStates"])
+
+	switchover := metav1.Now()
+	states := []ptpv1.PhcHighAvailabilityState{
+		{Profile: "other_ha", ActiveSource: "other_bc"},
+		{Profile: "phc2sys-config_phc2sys-ha", ActiveSource: "bc-primary_bc1", LastSwitchover: &switchover},
+	}
+	status := getNodeHighAvailabilityStatus(node.Name, &haConfig, configProfiles, nodeProfiles, states)
+	assert.Equal(t, []ptpv1.NodeHighAvailabilityStatus{{
+		NodeName:       "worker-1",
+		Profile:        "phc2sys-config_phc2sys-ha",
+		Sources:        []string{"bc-primary_bc1", "bc-secondary_bc2"},
+		MissingSources: []string{"bc-secondary_bc2"},
+		ActiveSource:   "bc-primary_bc1",
+		LastSwitchover: &switchover,
+	}}, status)
+
+	cond := highAvailabilityCondition(status)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, reasonSourcesMissing, cond.Reason)
+	assert.Equal(t, "node worker-1 profile phc2sys-config_phc2sys-ha: sources bc-secondary_bc2 are not recommended to the node",
+		cond.Message)
+
+	status[0].MissingSources = nil
+	cond = highAvailabilityCondition(status)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, reasonSourcesAvailable, cond.Reason)
+	assert.Nil(t, highAvailabilityCondition(nil))
+
+	// profiles without high availability have no status
+	assert.Empty(t, getNodeHighAvailabilityStatus(node.Name, &list.Items[0], list.Items[0].Spec.Profile, nodeProfiles, states))
+}
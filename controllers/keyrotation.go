@@ -0,0 +1,440 @@
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Secret annotations enabling operator-managed key rotation. The operator owns the security
+// association file of an annotated Secret and records the rotation state in its annotations.
+const (
+	KeyRotationIntervalAnnotation  = ptpv1.KeyRotationIntervalAnnotation
+	KeyRotationOverlapAnnotation   = "ptp.openshift.io/key-rotation-overlap"
+	KeyRotationAlgorithmAnnotation = "ptp.openshift.io/key-rotation-algorithm"
+	ActiveKeyIDAnnotation          = ptpv1.ActiveKeyIDAnnotation
+	KeyActivatedAtAnnotation       = "ptp.openshift.io/key-activated-at"
+	KeyDistributedAtAnnotation     = "ptp.openshift.io/key-distributed-at"
+)
+
+const (
+	defaultKeyRotationOverlap   = 10 * time.Minute
+	defaultKeyRotationAlgorithm = "SHA256-128"
+	maxKeyID                    = 4294967295
+)
+
+// keySizes is the size in bytes of the keys generated for each MAC algorithm
+var keySizes = map[string]int{
+	"AES128":     16,
+	"AES256":     32,
+	"SHA256-128": 32,
+	"SHA256":     32,
+}
+
+// keyRotationPolicy is the rotation policy of a Secret
+type keyRotationPolicy struct {
+	interval  time.Duration
+	overlap   time.Duration
+	algorithm string
+}
+
+// managedKey is a key of an operator-managed security association file
+type managedKey struct {
+	id        int64
+	algorithm string
+	value     string
+}
+
+// keyRotationState is the key set and active key of an operator-managed Secret
+type keyRotationState struct {
+	keys          []managedKey
+	activeKeyID   int64
+	activatedAt   time.Time
+	distributedAt time.Time
+}
+
+// getKeyRotationPolicy returns the rotation policy of the Secret, or nil if the Secret is not annotated for rotation
+func getKeyRotationPolicy(secret *corev1.Secret) (*keyRotationPolicy, error) {
+	value, ok := secret.Annotations[KeyRotationIntervalAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	policy := &keyRotationPolicy{overlap: defaultKeyRotationOverlap, algorithm: defaultKeyRotationAlgorithm}
+	var err error
+	if policy.interval, err = time.ParseDuration(value); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", KeyRotationIntervalAnnotation, err)
+	}
+	if value, ok := secret.Annotations[KeyRotationOverlapAnnotation]; ok {
+		if policy.overlap, err = time.ParseDuration(value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", KeyRotationOverlapAnnotation, err)
+		}
+	}
+	if value, ok := secret.Annotations[KeyRotationAlgorithmAnnotation]; ok {
+		if _, supported := keySizes[value]; !supported {
+			return nil, fmt.Errorf("invalid %s: algorithm '%s' is not supported", KeyRotationAlgorithmAnnotation, value)
+		}
+		policy.algorithm = value
+	}
+	if policy.overlap <= 0 {
+		return nil, fmt.Errorf("key rotation overlap must be positive, got %s", policy.overlap)
+	}
+	if policy.interval <= policy.overlap {
+		return nil, fmt.Errorf("key rotation interval %s must be longer than the overlap %s", policy.interval, policy.overlap)
+	}
+	return policy, nil
+}
+
+// keyRotationPolicyChanged checks if the rotation policy annotations differ between two versions of a Secret
+func keyRotationPolicyChanged(oldSecret, newSecret *corev1.Secret) bool {
+	for _, annotation := range []string{KeyRotationIntervalAnnotation, KeyRotationOverlapAnnotation, KeyRotationAlgorithmAnnotation} {
+		oldValue, oldOk := oldSecret.Annotations[annotation]
+		newValue, newOk := newSecret.Annotations[annotation]
+		if oldOk != newOk || oldValue != newValue {
+			return true
+		}
+	}
+	return false
+}
+
+// getKeyRotationState reads the key set from the security association file and the active key from the Secret annotations
+func getKeyRotationState(secret *corev1.Secret, key string) (*keyRotationState, error) {
+	keys, err := parseManagedKeys(string(secret.Data[key]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid security association file in key '%s': %v", key, err)
+	}
+	state := &keyRotationState{keys: keys}
+	if id, err := strconv.ParseInt(secret.Annotations[ActiveKeyIDAnnotation], 10, 64); err == nil {
+		state.activeKeyID = id
+	}
+	if at, err := time.Parse(time.RFC3339, secret.Annotations[KeyActivatedAtAnnotation]); err == nil {
+		state.activatedAt = at
+	}
+	if at, err := time.Parse(time.RFC3339, secret.Annotations[KeyDistributedAtAnnotation]); err == nil {
+		state.distributedAt = at
+	}
+	return state, nil
+}
+
+// parseManagedKeys returns the keys of the first SPP of a security association file, sorted by key ID.
+// Every SPP of an operator-managed file holds the same keys.
+func parseManagedKeys(saFile string) ([]managedKey, error) {
+	if strings.TrimSpace(saFile) == "" {
+		return nil, nil
+	}
+	sas, err := ptpv1.ParseSaFile(saFile)
+	if err != nil {
+		return nil, err
+	}
+	var keys []managedKey
+	for _, key := range sas[0].Keys {
+		keys = append(keys, managedKey{id: key.ID, algorithm: key.Algorithm, value: key.Value})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].id < keys[j].id })
+	return keys, nil
+}
+
+// renderManagedSaFile renders a security association file holding the keys for every SPP
+func renderManagedSaFile(keys []managedKey, spps []int64) string {
+	var b strings.Builder
+	b.WriteString("# managed by ptp-operator, do not edit\n")
+	for _, spp := range spps {
+		b.WriteString("[security_association]\n")
+		fmt.Fprintf(&b, "spp %d\n", spp)
+		for _, key := range keys {
+			fmt.Fprintf(&b, "%d %s %s\n", key.id, key.algorithm, key.value)
+		}
+	}
+	return b.String()
+}
+
+// generateKey returns a random key for the MAC algorithm in the sa_file hex encoding
+func generateKey(algorithm string) (string, error) {
+	buf := make([]byte, keySizes[algorithm])
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate key: %v", err)
+	}
+	return "HEX:" + hex.EncodeToString(buf), nil
+}
+
+func (s *keyRotationState) hasKey(id int64) bool {
+	for _, key := range s.keys {
+		if key.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingKeyID returns the ID of the key distributed ahead of activation, or 0 if there is none
+func (s *keyRotationState) pendingKeyID() int64 {
+	for _, key := range s.keys {
+		if key.id > s.activeKeyID {
+			return key.id
+		}
+	}
+	return 0
+}
+
+func (s *keyRotationState) addKey(algorithm string) (int64, error) {
+	id := int64(1)
+	if len(s.keys) > 0 {
+		id = s.keys[len(s.keys)-1].id + 1
+	}
+	if id > maxKeyID {
+		return 0, fmt.Errorf("key ID space exhausted")
+	}
+	value, err := generateKey(algorithm)
+	if err != nil {
+		return 0, err
+	}
+	s.keys = append(s.keys, managedKey{id: id, algorithm: algorithm, value: value})
+	return id, nil
+}
+
+// activateAt returns the time the pending key becomes active: once the interval has elapsed,
+// and no earlier than one overlap after the key was distributed
+func (s *keyRotationState) activateAt(policy *keyRotationPolicy) time.Time {
+	activateAt := s.activatedAt.Add(policy.interval)
+	if distributed := s.distributedAt.Add(policy.overlap); distributed.After(activateAt) {
+		return distributed
+	}
+	return activateAt
+}
+
+// advance moves the rotation forward at the given time:
+// a new key is distributed one overlap ahead of its activation so every node sharing the SPP holds it before
+// it is used, it becomes the active key once the interval has elapsed, and the retired keys are removed one
+// overlap after the switchover, once no node transmits with them anymore. It returns whether the state changed.
+func (s *keyRotationState) advance(policy *keyRotationPolicy, now time.Time) (bool, error) {
+	changed := false
+	if !s.hasKey(s.activeKeyID) {
+		if len(s.keys) == 0 {
+			if _, err := s.addKey(policy.algorithm); err != nil {
+				return false, err
+			}
+		}
+		s.activeKeyID = s.keys[len(s.keys)-1].id
+		s.activatedAt = now
+		s.distributedAt = time.Time{}
+		changed = true
+	}
+
+	if s.pendingKeyID() == 0 && !now.Before(s.activatedAt.Add(policy.interval-policy.overlap)) {
+		if _, err := s.addKey(policy.algorithm); err != nil {
+			return false, err
+		}
+		s.distributedAt = now
+		changed = true
+	} else if pending := s.pendingKeyID(); pending != 0 && !now.Before(s.activateAt(policy)) {
+		s.activeKeyID = pending
+		s.activatedAt = now
+		s.distributedAt = time.Time{}
+		changed = true
+	}
+
+	if !now.Before(s.activatedAt.Add(policy.overlap)) {
+		var keys []managedKey
+		for _, key := range s.keys {
+			if key.id >= s.activeKeyID {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) != len(s.keys) {
+			s.keys = keys
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
+// nextEvent returns the time of the next rotation step
+func (s *keyRotationState) nextEvent(policy *keyRotationPolicy) time.Time {
+	next := s.activatedAt.Add(policy.interval - policy.overlap)
+	if s.pendingKeyID() != 0 {
+		next = s.activateAt(policy)
+	}
+	if len(s.keys) > 0 && s.keys[0].id < s.activeKeyID {
+		if retireAt := s.activatedAt.Add(policy.overlap); retireAt.Before(next) {
+			next = retireAt
+		}
+	}
+	return next
+}
+
+// rotatedSecret is a Secret security association file referenced by authentication blocks
+type rotatedSecret struct {
+	keys map[string]bool
+	spps map[int64]bool
+}
+
+// getRotatedSecrets returns the secrets referenced by the profile authentication blocks, with their keys and SPPs.
+// Only the secrets of the operator namespace are rotated, the secrets of other namespaces are mirrored as is.
+func getRotatedSecrets(ptpConfigList *ptpv1.PtpConfigList) map[string]*rotatedSecret {
+	secrets := make(map[string]*rotatedSecret)
+	for _, cfg := range ptpConfigList.Items {
+		if cfg.Namespace != names.Namespace {
+			continue
+		}
+		for _, profile := range cfg.Spec.Profile {
+			auth := profile.Authentication
+			if auth == nil {
+				continue
+			}
+			secret, ok := secrets[auth.SecretRef.Name]
+			if !ok {
+				secret = &rotatedSecret{keys: make(map[string]bool), spps: make(map[int64]bool)}
+				secrets[auth.SecretRef.Name] = secret
+			}
+			secret.keys[auth.Key] = true
+			secret.spps[auth.SPP] = true
+			for _, port := range auth.Ports {
+				if port.SPP != nil {
+					secret.spps[*port.SPP] = true
+				}
+			}
+		}
+	}
+	return secrets
+}
+
+// syncKeyRotation advances the rotation of the operator-managed secrets and writes their security
+// association files. It returns the active key ID of each managed secret and the time until the next rotation step.
+func (r *PtpConfigReconciler) syncKeyRotation(ctx context.Context, ptpConfigList *ptpv1.PtpConfigList) (map[string]int64, time.Duration, error) {
+	activeKeys := make(map[string]int64)
+	var requeueAfter time.Duration
+	now := time.Now().UTC().Truncate(time.Second)
+
+	for secretName, ref := range getRotatedSecrets(ptpConfigList) {
+		secret := &corev1.Secret{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: names.Namespace, Name: secretName}, secret)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, 0, fmt.Errorf("failed to get secret '%s': %v", secretName, err)
+		}
+		policy, err := getKeyRotationPolicy(secret)
+		if err != nil {
+			glog.Errorf("secret '%s' key rotation disabled: %v", secretName, err)
+			continue
+		}
+		if policy == nil {
+			continue
+		}
+
+		keys := sortedKeys(ref.keys)
+		state, err := getKeyRotationState(secret, keys[0])
+		if err != nil {
+			glog.Errorf("secret '%s' key rotation stopped: %v", secretName, err)
+			continue
+		}
+		changed, err := state.advance(policy, now)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to rotate keys of secret '%s': %v", secretName, err)
+		}
+
+		var spps []int64
+		for spp := range ref.spps {
+			spps = append(spps, spp)
+		}
+		sort.Slice(spps, func(i, j int) bool { return spps[i] < spps[j] })
+		saFile := renderManagedSaFile(state.keys, spps)
+		for _, key := range keys {
+			if string(secret.Data[key]) != saFile {
+				changed = true
+			}
+		}
+
+		if changed {
+			if secret.Data == nil {
+				secret.Data = make(map[string][]byte)
+			}
+			for _, key := range keys {
+				secret.Data[key] = []byte(saFile)
+			}
+			secret.Annotations[ActiveKeyIDAnnotation] = strconv.FormatInt(state.activeKeyID, 10)
+			secret.Annotations[KeyActivatedAtAnnotation] = state.activatedAt.Format(time.RFC3339)
+			if state.distributedAt.IsZero() {
+				delete(secret.Annotations, KeyDistributedAtAnnotation)
+			} else {
+				secret.Annotations[KeyDistributedAtAnnotation] = state.distributedAt.Format(time.RFC3339)
+			}
+			if err = r.Update(ctx, secret); err != nil {
+				return nil, 0, fmt.Errorf("failed to update secret '%s': %v", secretName, err)
+			}
+			glog.Infof("secret '%s' keys rotated, active key ID %d", secretName, state.activeKeyID)
+		}
+
+		activeKeys[secretName] = state.activeKeyID
+		if wait := state.nextEvent(policy).Sub(now); requeueAfter == 0 || wait < requeueAfter {
+			requeueAfter = wait
+		}
+	}
+	if requeueAfter < 0 {
+		requeueAfter = time.Second
+	}
+	return activeKeys, requeueAfter, nil
+}
+
+// applyManagedActiveKeys returns a copy of the PtpConfigs with the active key IDs of the operator-managed secrets
+func applyManagedActiveKeys(ptpConfigList *ptpv1.PtpConfigList, activeKeys map[string]int64) *ptpv1.PtpConfigList {
+	rendered := ptpConfigList.DeepCopy()
+	for i := range rendered.Items {
+		if rendered.Items[i].Namespace != names.Namespace {
+			continue
+		}
+		for j := range rendered.Items[i].Spec.Profile {
+			auth := rendered.Items[i].Spec.Profile[j].Authentication
+			if auth == nil {
+				continue
+			}
+			keyID, ok := activeKeys[auth.SecretRef.Name]
+			if !ok {
+				continue
+			}
+			auth.ActiveKeyID = keyID
+			for k := range auth.Ports {
+				auth.Ports[k].ActiveKeyID = nil
+			}
+		}
+	}
+	return rendered
+}
+
+// getNodeAuthenticationStatus returns the active key of each authenticated profile applied to the node
+func getNodeAuthenticationStatus(nodeName string, profiles []ptpv1.PtpProfile) []ptpv1.NodeAuthenticationStatus {
+	var status []ptpv1.NodeAuthenticationStatus
+	for _, profile := range profiles {
+		if profile.Authentication == nil || profile.Name == nil {
+			continue
+		}
+		status = append(status, ptpv1.NodeAuthenticationStatus{
+			NodeName:    nodeName,
+			Profile:     *profile.Name,
+			SecretName:  profile.Authentication.SecretRef.Name,
+			ActiveKeyID: profile.Authentication.ActiveKeyID,
+		})
+	}
+	return status
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,243 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func makeRotatedSecret(annotations map[string]string, saFile string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ptp-sa", Namespace: "openshift-ptp", Annotations: annotations},
+		Data:       map[string][]byte{"sa_file": []byte(saFile)},
+	}
+}
+
+func TestGetKeyRotationPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    *keyRotationPolicy
+		expectError bool
+	}{
+		{
+			name:        "not annotated",
+			annotations: nil,
+			expected:    nil,
+		},
+		{
+			name:        "defaults",
+			annotations: map[string]string{KeyRotationIntervalAnnotation: "24h"},
+			expected:    &keyRotationPolicy{interval: 24 * time.Hour, overlap: 10 * time.Minute, algorithm: "SHA256-128"},
+		},
+		{
+			name: "all set",
+			annotations: map[string]string{
+				KeyRotationIntervalAnnotation:  "1h",
+				KeyRotationOverlapAnnotation:   "5m",
+				KeyRotationAlgorithmAnnotation: "AES256",
+			},
+			expected: &keyRotationPolicy{interval: time.Hour, overlap: 5 * time.Minute, algorithm: "AES256"},
+		},
+		{
+			name:        "invalid interval",
+			annotations: map[string]string{KeyRotationIntervalAnnotation: "daily"},
+			expectError: true,
+		},
+		{
+			name:        "overlap not shorter than interval",
+			annotations: map[string]string{KeyRotationIntervalAnnotation: "10m", KeyRotationOverlapAnnotation: "10m"},
+			expectError: true,
+		},
+		{
+			name:        "unsupported algorithm",
+			annotations: map[string]string{KeyRotationIntervalAnnotation: "1h", KeyRotationAlgorithmAnnotation: "MD5"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := getKeyRotationPolicy(makeRotatedSecret(tt.annotations, ""))
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, policy)
+		})
+	}
+}
+
+func TestManagedSaFileRoundTrip(t *testing.T) {
+	keys := []managedKey{
+		{id: 1, algorithm: "SHA256-128", value: "HEX:00112233"},
+		{id: 2, algorithm: "SHA256-128", value: "HEX:44556677"},
+	}
+	saFile := renderManagedSaFile(keys, []int64{0, 1})
+	assert.Equal(t, "# managed by ptp-operator, do not edit\n"+
+		"[security_association]\nspp 0\n1 SHA256-128 HEX:00112233\n2 SHA256-128 HEX:44556677\n"+
+		"[security_association]\nspp 1\n1 SHA256-128 HEX:00112233\n2 SHA256-128 HEX:44556677\n", saFile)
+	parsed, err := parseManagedKeys(saFile)
+	assert.NoError(t, err)
+	assert.Equal(t, keys, parsed)
+
+	_, err = parseManagedKeys("[security_association]\nspp 1\n1 SHA256-128 HEX:zz\n")
+	assert.Error(t, err)
+}
+
+func TestGenerateKey(t *testing.T) {
+	key, err := generateKey("AES128")
+	assert.NoError(t, err)
+	assert.Len(t, key, len("HEX:")+32)
+
+	other, err := generateKey("AES128")
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestKeyRotationAdvance(t *testing.T) {
+	policy := &keyRotationPolicy{interval: time.Hour, overlap: 10 * time.Minute, algorithm: "SHA256-128"}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	state := &keyRotationState{}
+
+	keyIDs := func() []int64 {
+		var ids []int64
+		for _, key := range state.keys {
+			ids = append(ids, key.id)
+		}
+		return ids
+	}
+
+	// The first key is generated and activated
+	changed, err := state.advance(policy, start)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, int64(1), state.activeKeyID)
+	assert.Equal(t, []int64{1}, keyIDs())
+	assert.Equal(t, start.Add(50*time.Minute), state.nextEvent(policy))
+
+	// Nothing happens before the next key is due
+	changed, err = state.advance(policy, start.Add(49*time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	// The next key is distributed one overlap ahead of the switchover
+	changed, err = state.advance(policy, start.Add(50*time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, int64(1), state.activeKeyID)
+	assert.Equal(t, []int64{1, 2}, keyIDs())
+	assert.Equal(t, start.Add(time.Hour), state.nextEvent(policy))
+
+	// The next key becomes active once the interval has elapsed, the retired key is kept during the overlap
+	changed, err = state.advance(policy, start.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, int64(2), state.activeKeyID)
+	assert.Equal(t, []int64{1, 2}, keyIDs())
+	assert.Equal(t, start.Add(70*time.Minute), state.nextEvent(policy))
+
+	// The retired key is removed after the overlap
+	changed, err = state.advance(policy, start.Add(70*time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, []int64{2}, keyIDs())
+	assert.Equal(t, start.Add(110*time.Minute), state.nextEvent(policy))
+}
+
+func TestKeyRotationAdvance_LateDistribution(t *testing.T) {
+	policy := &keyRotationPolicy{interval: time.Hour, overlap: 10 * time.Minute, algorithm: "SHA256-128"}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	state := &keyRotationState{
+		keys:        []managedKey{{id: 1, algorithm: "SHA256-128", value: "HEX:00"}},
+		activeKeyID: 1,
+		activatedAt: start,
+	}
+
+	// The rotation is overdue: the next key is distributed but not activated yet
+	late := start.Add(2 * time.Hour)
+	_, err := state.advance(policy, late)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), state.activeKeyID)
+	assert.Equal(t, late.Add(10*time.Minute), state.nextEvent(policy))
+
+	changed, err := state.advance(policy, late.Add(5*time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	_, err = state.advance(policy, late.Add(10*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), state.activeKeyID)
+}
+
+func TestGetKeyRotationState_AdoptsExistingKeys(t *testing.T) {
+	policy := &keyRotationPolicy{interval: time.Hour, overlap: 10 * time.Minute, algorithm: "SHA256-128"}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	secret := makeRotatedSecret(map[string]string{KeyRotationIntervalAnnotation: "1h"},
+		"[security_association]\nspp 1\n1 SHA256-128 HEX:00\n2 SHA256-128 B64:AA==\n")
+
+	state, err := getKeyRotationState(secret, "sa_file")
+	assert.NoError(t, err)
+	changed, err := state.advance(policy, now)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, int64(2), state.activeKeyID)
+	assert.Equal(t, now, state.activatedAt)
+	assert.Equal(t, "B64:AA==", state.keys[1].value)
+}
+
+func TestKeyRotationPolicyChanged(t *testing.T) {
+	oldSecret := makeRotatedSecret(map[string]string{KeyRotationIntervalAnnotation: "1h"}, "")
+
+	newSecret := makeRotatedSecret(map[string]string{KeyRotationIntervalAnnotation: "1h", ActiveKeyIDAnnotation: "2"}, "changed")
+	assert.False(t, keyRotationPolicyChanged(oldSecret, newSecret))
+
+	newSecret = makeRotatedSecret(map[string]string{KeyRotationIntervalAnnotation: "2h"}, "")
+	assert.True(t, keyRotationPolicyChanged(oldSecret, newSecret))
+
+	newSecret = makeRotatedSecret(nil, "")
+	assert.True(t, keyRotationPolicyChanged(oldSecret, newSecret))
+}
+
+func TestGetRotatedSecretsAndApplyManagedActiveKeys(t *testing.T) {
+	profile := makeProfile("auth", nil)
+	profile.Authentication = &ptpv1.PtpAuthentication{
+		SecretRef:   corev1.LocalObjectReference{Name: "ptp-sa"},
+		Key:         "sa_file",
+		SPP:         1,
+		ActiveKeyID: 1,
+		Ports:       []ptpv1.PtpPortAuthentication{{Interface: "ens1f0", SPP: int64Ptr(2), ActiveKeyID: int64Ptr(3)}},
+	}
+	other := makeProfile("other", nil)
+	other.Authentication = &ptpv1.PtpAuthentication{
+		SecretRef:   corev1.LocalObjectReference{Name: "static-sa"},
+		Key:         "sa_file",
+		SPP:         1,
+		ActiveKeyID: 1,
+	}
+	list := makePtpConfigList(makePtpConfig("cfg", []ptpv1.PtpProfile{profile, other}, nil))
+
+	secrets := getRotatedSecrets(list)
+	assert.Len(t, secrets, 2)
+	assert.Equal(t, map[int64]bool{1: true, 2: true}, secrets["ptp-sa"].spps)
+	assert.Equal(t, map[string]bool{"sa_file": true}, secrets["ptp-sa"].keys)
+
+	rendered := applyManagedActiveKeys(list, map[string]int64{"ptp-sa": 7})
+	auth := rendered.Items[0].Spec.Profile[0].Authentication
+	assert.Equal(t, int64(7), auth.ActiveKeyID)
+	assert.Nil(t, auth.Ports[0].ActiveKeyID)
+	assert.Equal(t, int64(1), rendered.Items[0].Spec.Profile[1].Authentication.ActiveKeyID)
+	// The original PtpConfigs are left untouched
+	assert.Equal(t, int64(1), list.Items[0].Spec.Profile[0].Authentication.ActiveKeyID)
+
+	status := getNodeAuthenticationStatus("node1", rendered.Items[0].Spec.Profile)
+	assert.Equal(t, []ptpv1.NodeAuthenticationStatus{
+		{NodeName: "node1", Profile: "auth", SecretName: "ptp-sa", ActiveKeyID: 7},
+		{NodeName: "node1", Profile: "other", SecretName: "static-sa", ActiveKeyID: 1},
+	}, status)
+}
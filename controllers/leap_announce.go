@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+)
+
+// leapAnnounce holds the leap fields a ptp4l instance announces as grandmaster
+type leapAnnounce struct {
+	currentUTCOffset int
+	leap61           bool
+	leap59           bool
+}
+
+// nodeLeapAnnounces returns the leap fields announced by the grandmaster ptp4l instances of each node, as reported
+// by the linuxptp daemon in the NodePtpDevice status. Nodes reporting no grandmaster are omitted.
+func nodeLeapAnnounces(nodeDevices map[string]*ptpv1.NodePtpDevice) map[string][]leapAnnounce {
+	announces := map[string][]leapAnnounce{}
+	for node, device := range nodeDevices {
+		for _, settings := range device.Status.GrandmasterSettings {
+			announces[node] = append(announces[node], leapAnnounce{
+				currentUTCOffset: settings.CurrentUTCOffset,
+				leap61:           settings.Leap61,
+				leap59:           settings.Leap59,
+			})
+		}
+	}
+	return announces
+}
@@ -0,0 +1,361 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/leapseconds"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// bundledLeapSecondsFile is the IERS leap second file shipped with the operator, relative to the manifest dir
+	bundledLeapSecondsFile = "linuxptp/leap-seconds.list"
+	// defaultLeapSecondsKey is the ConfigMap key of the leap second file provided by the user
+	defaultLeapSecondsKey = "leap-seconds.list"
+	// defaultLeapSecondsExpiryWarning is how long before the leap second file expires the expiring condition is raised
+	defaultLeapSecondsExpiryWarning = 30 * 24 * time.Hour
+
+	leapSecondsSourceBundled   = "Bundled"
+	leapSecondsSourceConfigMap = "ConfigMap"
+
+	leapSecondsExpiringConditionType    = "LeapSecondsExpiring"
+	leapSecondsSourceValidConditionType = "LeapSecondsSourceValid"
+	leapEventsAppliedConditionType      = "LeapEventsApplied"
+
+	reasonLeapSecondsValid             = "LeapSecondsValid"
+	reasonLeapSecondsExpiring          = "LeapSecondsExpiring"
+	reasonLeapSecondsExpired           = "LeapSecondsExpired"
+	reasonLeapSecondsConfigMapNotFound = "ConfigMapNotFound"
+	reasonLeapSecondsInvalid           = "InvalidLeapSecondsFile"
+	reasonLeapSecondsOutdated          = "LeapSecondsOutdated"
+	reasonLeapEventsApplied            = "LeapEventsApplied"
+	reasonLeapEventConflict            = "LeapEventConflict"
+)
+
+// leapSecondsFile is a leap second file and where it comes from
+type leapSecondsFile struct {
+	content string
+	file    *leapseconds.File
+	source  string
+}
+
+// loadBundledLeapSeconds reads the leap second file shipped with the operator
+func loadBundledLeapSeconds(manifestDir string) (*leapSecondsFile, error) {
+	content, err := os.ReadFile(filepath.Join(manifestDir, bundledLeapSecondsFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundled leap second file: %v", err)
+	}
+	file, err := leapseconds.Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("bundled leap second file is invalid: %v", err)
+	}
+	return &leapSecondsFile{content: string(content), file: file, source: leapSecondsSourceBundled}, nil
+}
+
+// parseUserLeapSeconds validates the leap second file of the user ConfigMap. The file is nil when it cannot be
+// distributed, the condition explains why.
+func parseUserLeapSeconds(cfg *ptpv1.PtpLeapSecondsConfig, cm *corev1.ConfigMap, now time.Time) (*leapSecondsFile, *metav1.Condition) {
+	key := cfg.Key
+	if key == "" {
+		key = defaultLeapSecondsKey
+	}
+	invalid := func(reason, message string) *metav1.Condition {
+		return &metav1.Condition{
+			Type:    leapSecondsSourceValidConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: message,
+		}
+	}
+	if cm == nil {
+		return nil, invalid(reasonLeapSecondsConfigMapNotFound, fmt.Sprintf("ConfigMap %s not found", cfg.ConfigMapName))
+	}
+	content, ok := cm.Data[key]
+	if !ok {
+		return nil, invalid(reasonLeapSecondsInvalid, fmt.Sprintf("ConfigMap %s has no %s key", cm.Name, key))
+	}
+	file, err := leapseconds.Parse(content)
+	if err != nil {
+		return nil, invalid(reasonLeapSecondsInvalid, fmt.Sprintf("ConfigMap %s key %s: %v", cm.Name, key, err))
+	}
+	if file.Expired(now) {
+		return nil, invalid(reasonLeapSecondsExpired,
+			fmt.Sprintf("ConfigMap %s key %s expired on %s", cm.Name, key, file.Expires.Format(time.DateOnly)))
+	}
+	return &leapSecondsFile{content: content, file: file, source: leapSecondsSourceConfigMap}, &metav1.Condition{
+		Type:    leapSecondsSourceValidConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonLeapSecondsValid,
+		Message: fmt.Sprintf("ConfigMap %s key %s expires on %s", cm.Name, key, file.Expires.Format(time.DateOnly)),
+	}
+}
+
+// selectLeapSeconds returns the most recent of the bundled and user leap second files
+func selectLeapSeconds(bundled, user *leapSecondsFile, userCondition *metav1.Condition) (*leapSecondsFile, *metav1.Condition) {
+	if user == nil {
+		return bundled, userCondition
+	}
+	if !user.file.NewerThan(bundled.file) {
+		return bundled, &metav1.Condition{
+			Type:   leapSecondsSourceValidConditionType,
+			Status: metav1.ConditionFalse,
+			Reason: reasonLeapSecondsOutdated,
+			Message: fmt.Sprintf("leap second file updated on %s is not more recent than the bundled file updated on %s",
+				user.file.Updated.Format(time.DateOnly), bundled.file.Updated.Format(time.DateOnly)),
+		}
+	}
+	return user, userCondition
+}
+
+// applyLeapEvents returns the leap second file with the leap events applied, whether it differs from the file, and
+// the events conflicting with the file
+func applyLeapEvents(file *leapseconds.File, events []ptpv1.PtpLeapEvent) (*leapseconds.File, bool, []string) {
+	result := &leapseconds.File{Updated: file.Updated, Expires: file.Expires, LeapSeconds: slices.Clone(file.LeapSeconds)}
+	sorted := slices.Clone(events)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Time.Before(&sorted[j].Time) })
+
+	changed := false
+	var conflicts []string
+	for _, event := range sorted {
+		at := event.Time.UTC()
+		i := slices.IndexFunc(result.LeapSeconds, func(leap leapseconds.LeapSecond) bool { return leap.Time.Equal(at) })
+		last := len(result.LeapSeconds) - 1
+		if event.Action == ptpv1.LeapEventSuppress {
+			switch {
+			case i < 0:
+			case i != last:
+				conflicts = append(conflicts, fmt.Sprintf("cannot suppress leap second of %s, later leap seconds depend on it",
+					at.Format(time.DateOnly)))
+			default:
+				result.LeapSeconds = result.LeapSeconds[:last]
+				changed = true
+			}
+			continue
+		}
+
+		delta := 1
+		if event.Action == ptpv1.LeapEventDelete {
+			delta = -1
+		}
+		switch {
+		case i > 0 && result.LeapSeconds[i].Offset-result.LeapSeconds[i-1].Offset == delta:
+		case i >= 0:
+			conflicts = append(conflicts, fmt.Sprintf("leap second of %s conflicts with the leap second file offset %d",
+				at.Format(time.DateOnly), result.LeapSeconds[i].Offset))
+		case !at.After(result.LeapSeconds[last].Time):
+			conflicts = append(conflicts, fmt.Sprintf("leap second of %s is before the last leap second of the file on %s",
+				at.Format(time.DateOnly), result.LeapSeconds[last].Time.Format(time.DateOnly)))
+		default:
+			result.LeapSeconds = append(result.LeapSeconds, leapseconds.LeapSecond{
+				Time:   at,
+				Offset: result.LeapSeconds[last].Offset + delta,
+			})
+			changed = true
+		}
+	}
+	return result, changed, conflicts
+}
+
+// nodeLeapSecondsData sets the leap second file of each node to the distributed file with the leap events applied.
+// Node files more recent than the distributed one, updated by the linuxptp daemon from GNSS announcements, are kept
+// and the leap events are applied to them. The status of each node reports what its ptp4l instances announce, and
+// whether it is what its leap second file announces at now. It returns the updated data, the number of nodes whose
+// file was set, the status of each node and the events conflicting with the leap second files.
+func nodeLeapSecondsData(
+	data map[string]string,
+	nodeNames []string,
+	leap *leapSecondsFile,
+	events []ptpv1.PtpLeapEvent,
+	announces map[string][]leapAnnounce,
+	now time.Time,
+) (map[string]string, int, []ptpv1.NodeLeapSeconds, []string) {
+	updated := make(map[string]string, len(data))
+	for node, content := range data {
+		updated[node] = content
+	}
+	changed := 0
+	nodes := make([]ptpv1.NodeLeapSeconds, 0, len(nodeNames))
+	var conflicts []string
+	for _, node := range nodeNames {
+		base, content := leap.file, leap.content
+		if current, err := leapseconds.Parse(data[node]); err == nil && current.NewerThan(leap.file) {
+			base, content = current, data[node]
+		}
+		file, modified, fileConflicts := applyLeapEvents(base, events)
+		if modified {
+			content = leapseconds.Format(file)
+		}
+		for _, conflict := range fileConflicts {
+			if !slices.Contains(conflicts, conflict) {
+				conflicts = append(conflicts, conflict)
+			}
+		}
+		if content != data[node] {
+			updated[node] = content
+			changed++
+		}
+
+		status := ptpv1.NodeLeapSeconds{Node: node}
+		var expected leapAnnounce
+		expected.currentUTCOffset, expected.leap61, expected.leap59 = file.Announce(now)
+		if nodeAnnounces := announces[node]; len(nodeAnnounces) > 0 {
+			status.CurrentUTCOffset = nodeAnnounces[0].currentUTCOffset
+			status.Leap61 = nodeAnnounces[0].leap61
+			status.Leap59 = nodeAnnounces[0].leap59
+			// the node acknowledges its file once it was not changed and all its ptp4l instances announce it
+			status.Acknowledged = content == data[node]
+			for _, announce := range nodeAnnounces {
+				status.Acknowledged = status.Acknowledged && announce == expected
+			}
+		}
+		for _, leapSecond := range file.LeapSeconds {
+			if leapSecond.Time.After(now) {
+				next := metav1.NewTime(leapSecond.Time)
+				status.NextLeapSecond = &next
+				break
+			}
+		}
+		nodes = append(nodes, status)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Node < nodes[j].Node })
+	return updated, changed, nodes, conflicts
+}
+
+// leapEventsCondition reports whether the leap events conflict with the leap second files, nil when no event is set
+func leapEventsCondition(events []ptpv1.PtpLeapEvent, conflicts []string) *metav1.Condition {
+	if len(events) == 0 {
+		return nil
+	}
+	if len(conflicts) > 0 {
+		return &metav1.Condition{
+			Type:    leapEventsAppliedConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  reasonLeapEventConflict,
+			Message: strings.Join(conflicts, "; "),
+		}
+	}
+	return &metav1.Condition{
+		Type:    leapEventsAppliedConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonLeapEventsApplied,
+		Message: fmt.Sprintf("%d leap events applied to the leap second file of the nodes", len(events)),
+	}
+}
+
+// leapSecondsExpiringCondition reports whether the leap second file expires within the warning period
+func leapSecondsExpiringCondition(file *leapseconds.File, warning time.Duration, now time.Time) metav1.Condition {
+	expires := file.Expires.Format(time.DateOnly)
+	switch {
+	case file.Expired(now):
+		return metav1.Condition{
+			Type:    leapSecondsExpiringConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  reasonLeapSecondsExpired,
+			Message: fmt.Sprintf("leap second file expired on %s, provide an up to date IERS leap-seconds.list", expires),
+		}
+	case file.Expired(now.Add(warning)):
+		return metav1.Condition{
+			Type:    leapSecondsExpiringConditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  reasonLeapSecondsExpiring,
+			Message: fmt.Sprintf("leap second file expires on %s, provide an up to date IERS leap-seconds.list", expires),
+		}
+	}
+	return metav1.Condition{
+		Type:    leapSecondsExpiringConditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  reasonLeapSecondsValid,
+		Message: fmt.Sprintf("leap second file expires on %s", expires),
+	}
+}
+
+// leapSecondsStatus describes the distributed leap second file
+func leapSecondsStatus(leap *leapSecondsFile, now time.Time) *ptpv1.LeapSecondsStatus {
+	return &ptpv1.LeapSecondsStatus{
+		Source:     leap.source,
+		UTCOffset:  leap.file.UTCOffset(now),
+		LastUpdate: metav1.NewTime(leap.file.Updated),
+		Expires:    metav1.NewTime(leap.file.Expires),
+	}
+}
+
+// syncLeapSeconds distributes the most recent valid leap second file, with the leap events applied, to the
+// leap-configmap key of each node selected by DaemonNodeSelector, and reports it in the PtpOperatorConfig status
+func (r *PtpOperatorConfigReconciler) syncLeapSeconds(ctx context.Context, defaultCfg *ptpv1.PtpOperatorConfig, nodeList *corev1.NodeList) error {
+	now := time.Now()
+	leap, err := loadBundledLeapSeconds(names.ManifestDir)
+	if err != nil {
+		return err
+	}
+
+	warning := defaultLeapSecondsExpiryWarning
+	var sourceCondition *metav1.Condition
+	if cfg := defaultCfg.Spec.LeapSeconds; cfg != nil {
+		if cfg.ExpiryWarning != nil {
+			warning = cfg.ExpiryWarning.Duration
+		}
+		if cfg.ConfigMapName != "" {
+			cm := &corev1.ConfigMap{}
+			err = r.Get(ctx, types.NamespacedName{Namespace: names.Namespace, Name: cfg.ConfigMapName}, cm)
+			if errors.IsNotFound(err) {
+				cm = nil
+			} else if err != nil {
+				return fmt.Errorf("failed to get leap second config map %s: %v", cfg.ConfigMapName, err)
+			}
+			var user *leapSecondsFile
+			user, sourceCondition = parseUserLeapSeconds(cfg, cm, now)
+			leap, sourceCondition = selectLeapSeconds(leap, user, sourceCondition)
+		}
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err = r.Get(ctx, types.NamespacedName{Namespace: names.Namespace, Name: names.DefaultLeapConfigMapName}, cm); err != nil {
+		return fmt.Errorf("failed to get leap config map: %v", err)
+	}
+	daemonSelector := labels.SelectorFromSet(defaultCfg.Spec.DaemonNodeSelector)
+	var nodeNames []string
+	for _, node := range nodeList.Items {
+		if daemonSelector.Matches(labels.Set(node.Labels)) {
+			nodeNames = append(nodeNames, node.Name)
+		}
+	}
+	var events []ptpv1.PtpLeapEvent
+	if defaultCfg.Spec.LeapSeconds != nil {
+		events = defaultCfg.Spec.LeapSeconds.Events
+	}
+	nodeDevices, err := r.getNodePtpDevices(ctx)
+	if err != nil {
+		return err
+	}
+	data, changed, nodes, conflicts := nodeLeapSecondsData(cm.Data, nodeNames, leap, events, nodeLeapAnnounces(nodeDevices), now)
+	if changed > 0 {
+		cm.Data = data
+		if err = r.Update(ctx, cm); err != nil {
+			return fmt.Errorf("failed to update leap config map: %v", err)
+		}
+		glog.Infof("leap second file updated on %d nodes from %s file updated on %s",
+			changed, leap.source, leap.file.Updated.Format(time.DateOnly))
+	}
+
+	defaultCfg.Status.LeapSeconds = leapSecondsStatus(leap, now)
+	defaultCfg.Status.LeapSeconds.Nodes = nodes
+	expiring := leapSecondsExpiringCondition(leap.file, warning, now)
+	setOrRemoveStatusCondition(&defaultCfg.Status.Conditions, leapSecondsExpiringConditionType, &expiring)
+	setOrRemoveStatusCondition(&defaultCfg.Status.Conditions, leapSecondsSourceValidConditionType, sourceCondition)
+	setOrRemoveStatusCondition(&defaultCfg.Status.Conditions, leapEventsAppliedConditionType, leapEventsCondition(events, conflicts))
+	return nil
+}
@@ -0,0 +1,358 @@
+package controllers
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/leapseconds"
+	"github.com/k8snetworkplumbingwg/ptp-operator/test/pkg/pmc"
+)
+
+// loadTestLeapSeconds returns the bundled leap second file, updated on 2025-07-07 and expiring on 2026-06-28, and
+// an older one, updated on 2025-01-07 and expiring on 2025-12-28
+func loadTestLeapSeconds(t *testing.T) (*leapSecondsFile, *leapSecondsFile) {
+	bundled, err := loadBundledLeapSeconds("../bindata")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	content, err := os.ReadFile("../pkg/leapseconds/testdata/leap-seconds-2025b.list")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	file, err := leapseconds.Parse(string(content))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return bundled, &leapSecondsFile{content: string(content), file: file, source: leapSecondsSourceConfigMap}
+}
+
+func makeLeapConfigMap(key, content string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "leap-seconds"},
+		Data:       map[string]string{key: content},
+	}
+}
+
+func TestParseUserLeapSeconds(t *testing.T) {
+	bundled, _ := loadTestLeapSeconds(t)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tampered := strings.Replace(bundled.content, "3692217600      37", "3692217600      38", 1)
+
+	tests := []struct {
+		name   string
+		cfg    ptpv1.PtpLeapSecondsConfig
+		cm     *corev1.ConfigMap
+		now    time.Time
+		valid  bool
+		reason string
+	}{
+		{
+			name:   "config map not found",
+			cfg:    ptpv1.PtpLeapSecondsConfig{ConfigMapName: "leap-seconds"},
+			now:    now,
+			reason: reasonLeapSecondsConfigMapNotFound,
+		},
+		{
+			name:   "missing key",
+			cfg:    ptpv1.PtpLeapSecondsConfig{ConfigMapName: "leap-seconds"},
+			cm:     makeLeapConfigMap("leap-seconds.txt", bundled.content),
+			now:    now,
+			reason: reasonLeapSecondsInvalid,
+		},
+		{
+			name:   "hash mismatch",
+			cfg:    ptpv1.PtpLeapSecondsConfig{ConfigMapName: "leap-seconds"},
+			cm:     makeLeapConfigMap("leap-seconds.list", tampered),
+			now:    now,
+			reason: reasonLeapSecondsInvalid,
+		},
+		{
+			name:   "expired",
+			cfg:    ptpv1.PtpLeapSecondsConfig{ConfigMapName: "leap-seconds"},
+			cm:     makeLeapConfigMap("leap-seconds.list", bundled.content),
+			now:    time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+			reason: reasonLeapSecondsExpired,
+		},
+		{
+			name:   "valid with custom key",
+			cfg:    ptpv1.PtpLeapSecondsConfig{ConfigMapName: "leap-seconds", Key: "leap-seconds.txt"},
+			cm:     makeLeapConfigMap("leap-seconds.txt", bundled.content),
+			now:    now,
+			valid:  true,
+			reason: reasonLeapSecondsValid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, cond := parseUserLeapSeconds(&tt.cfg, tt.cm, tt.now)
+			assert.Equal(t, tt.reason, cond.Reason)
+			assert.Equal(t, leapSecondsSourceValidConditionType, cond.Type)
+			if tt.valid {
+				assert.Equal(t, metav1.ConditionTrue, cond.Status)
+				if assert.NotNil(t, file) {
+					assert.Equal(t, leapSecondsSourceConfigMap, file.source)
+				}
+			} else {
+				assert.Equal(t, metav1.ConditionFalse, cond.Status)
+				assert.Nil(t, file)
+			}
+		})
+	}
+}
+
+func TestSelectLeapSeconds(t *testing.T) {
+	bundled, older := loadTestLeapSeconds(t)
+	valid := &metav1.Condition{Type: leapSecondsSourceValidConditionType, Status: metav1.ConditionTrue}
+
+	leap, cond := selectLeapSeconds(bundled, nil, nil)
+	assert.Equal(t, bundled, leap)
+	assert.Nil(t, cond)
+
+	leap, cond = selectLeapSeconds(older, bundled, valid)
+	assert.Equal(t, bundled, leap)
+	assert.Equal(t, valid, cond)
+
+	leap, cond = selectLeapSeconds(bundled, older, valid)
+	assert.Equal(t, bundled, leap)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, reasonLeapSecondsOutdated, cond.Reason)
+}
+
+func TestNodeLeapSecondsData(t *testing.T) {
+	bundled, older := loadTestLeapSeconds(t)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := map[string]string{
+		"older":    older.content,
+		"current":  bundled.content,
+		"invalid":  "not a leap second file",
+		"excluded": older.content,
+	}
+
+	announces := map[string][]leapAnnounce{
+		"older":   {{currentUTCOffset: 37}},
+		"current": {{currentUTCOffset: 37}, {currentUTCOffset: 37}},
+	}
+
+	updated, changed, nodes, conflicts := nodeLeapSecondsData(data, []string{"older", "current", "invalid", "new"}, bundled, nil, announces, now)
+	assert.Equal(t, 3, changed)
+	assert.Empty(t, conflicts)
+	assert.Equal(t, map[string]string{
+		"older":    bundled.content,
+		"current":  bundled.content,
+		"invalid":  bundled.content,
+		"new":      bundled.content,
+		"excluded": older.content,
+	}, updated)
+	assert.Equal(t, older.content, data["older"])
+	assert.Equal(t, []ptpv1.NodeLeapSeconds{
+		{Node: "current", Acknowledged: true, CurrentUTCOffset: 37},
+		{Node: "invalid"},
+		{Node: "new"},
+		{Node: "older", CurrentUTCOffset: 37},
+	}, nodes)
+
+	// a node file more recent than the distributed one, updated from GNSS announcements, is kept
+	updated, changed, _, _ = nodeLeapSecondsData(map[string]string{"gnss": bundled.content}, []string{"gnss"}, older, nil, nil, now)
+	assert.Equal(t, 0, changed)
+	assert.Equal(t, bundled.content, updated["gnss"])
+
+	// a ptp4l instance announcing another offset than the file of the node does not acknowledge it
+	announces["current"][1].currentUTCOffset = 36
+	_, _, nodes, _ = nodeLeapSecondsData(data, []string{"current"}, bundled, nil, announces, now)
+	assert.Equal(t, []ptpv1.NodeLeapSeconds{{Node: "current", CurrentUTCOffset: 37}}, nodes)
+}
+
+func TestApplyLeapEvents(t *testing.T) {
+	bundled, _ := loadTestLeapSeconds(t)
+	jan2027 := metav1.NewTime(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+	jul2027 := metav1.NewTime(time.Date(2027, 7, 1, 0, 0, 0, 0, time.UTC))
+
+	file, changed, conflicts := applyLeapEvents(bundled.file, []ptpv1.PtpLeapEvent{
+		{Time: jul2027, Action: ptpv1.LeapEventDelete},
+		{Time: jan2027, Action: ptpv1.LeapEventInsert},
+	})
+	assert.True(t, changed)
+	assert.Empty(t, conflicts)
+	assert.Len(t, bundled.file.LeapSeconds, 28)
+	assert.Equal(t, []leapseconds.LeapSecond{{Time: jan2027.Time, Offset: 38}, {Time: jul2027.Time, Offset: 37}},
+		file.LeapSeconds[28:])
+
+	// applying the events again changes nothing
+	_, changed, conflicts = applyLeapEvents(file, []ptpv1.PtpLeapEvent{
+		{Time: jan2027, Action: ptpv1.LeapEventInsert},
+		{Time: jul2027, Action: ptpv1.LeapEventDelete},
+	})
+	assert.False(t, changed)
+	assert.Empty(t, conflicts)
+
+	_, changed, conflicts = applyLeapEvents(file, []ptpv1.PtpLeapEvent{{Time: jan2027, Action: ptpv1.LeapEventDelete}})
+	assert.False(t, changed)
+	assert.Equal(t, []string{"leap second of 2027-01-01 conflicts with the leap second file offset 38"}, conflicts)
+
+	_, changed, conflicts = applyLeapEvents(file, []ptpv1.PtpLeapEvent{{Time: jan2027, Action: ptpv1.LeapEventSuppress}})
+	assert.False(t, changed)
+	assert.Equal(t, []string{"cannot suppress leap second of 2027-01-01, later leap seconds depend on it"}, conflicts)
+
+	suppressed, changed, conflicts := applyLeapEvents(file, []ptpv1.PtpLeapEvent{{Time: jul2027, Action: ptpv1.LeapEventSuppress}})
+	assert.True(t, changed)
+	assert.Empty(t, conflicts)
+	assert.Len(t, suppressed.LeapSeconds, 29)
+
+	_, changed, conflicts = applyLeapEvents(file, []ptpv1.PtpLeapEvent{
+		{Time: metav1.NewTime(time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)), Action: ptpv1.LeapEventInsert},
+	})
+	assert.False(t, changed)
+	assert.Equal(t, []string{"leap second of 2026-07-01 is before the last leap second of the file on 2027-07-01"}, conflicts)
+}
+
+// grandmasterSettings returns the leap fields the linuxptp daemon of node gm reports for a grandmaster ptp4l instance
+func grandmasterSettings(announce pmc.AnnounceData) map[string][]leapAnnounce {
+	device := makeNodePtpDevice("gm")
+	device.Status.GrandmasterSettings = []ptpv1.GrandmasterSettings{{
+		Profile:          "gm",
+		CurrentUTCOffset: announce.CurrentUtcOffset,
+		Leap61:           announce.Leap61,
+		Leap59:           announce.Leap59,
+	}}
+	return nodeLeapAnnounces(map[string]*ptpv1.NodePtpDevice{"gm": &device})
+}
+
+func TestNodeLeapAnnounces(t *testing.T) {
+	gm := makeNodePtpDevice("gm")
+	gm.Status.GrandmasterSettings = []ptpv1.GrandmasterSettings{
+		{Profile: "gm-1", CurrentUTCOffset: 37, Leap61: true},
+		{Profile: "gm-2", CurrentUTCOffset: 38, Leap59: true},
+	}
+	worker := makeNodePtpDevice("worker")
+
+	assert.Empty(t, nodeLeapAnnounces(nil))
+	assert.Equal(t, map[string][]leapAnnounce{
+		"gm": {{currentUTCOffset: 37, leap61: true}, {currentUTCOffset: 38, leap59: true}},
+	}, nodeLeapAnnounces(map[string]*ptpv1.NodePtpDevice{"gm": &gm, "worker": &worker}))
+}
+
+func TestLeapEventTransition(t *testing.T) {
+	bundled, _ := loadTestLeapSeconds(t)
+	leap := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	next := metav1.NewTime(leap)
+	scheduled := []ptpv1.PtpLeapEvent{{Time: next, Action: ptpv1.LeapEventInsert}}
+
+	// the operator writes the leap second to the file of the node, which still announces the previous file
+	now := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	reported := grandmasterSettings(pmc.AnnounceData{CurrentUtcOffset: 37})
+	data, changed, nodes, _ := nodeLeapSecondsData(map[string]string{"gm": bundled.content}, []string{"gm"}, bundled, scheduled, reported, now)
+	assert.Equal(t, 1, changed)
+	assert.Equal(t, []ptpv1.NodeLeapSeconds{{Node: "gm", CurrentUTCOffset: 37, NextLeapSecond: &next}}, nodes)
+
+	steps := []struct {
+		name     string
+		at       time.Time
+		reported pmc.AnnounceData
+		expected ptpv1.NodeLeapSeconds
+	}{
+		{
+			name:     "file written, no announcement yet",
+			at:       leap.Add(-13 * time.Hour),
+			reported: pmc.AnnounceData{CurrentUtcOffset: 37},
+			expected: ptpv1.NodeLeapSeconds{Node: "gm", Acknowledged: true, CurrentUTCOffset: 37, NextLeapSecond: &next},
+		},
+		{
+			name:     "daemon late to announce the insertion",
+			at:       leap.Add(-12 * time.Hour),
+			reported: pmc.AnnounceData{CurrentUtcOffset: 37},
+			expected: ptpv1.NodeLeapSeconds{Node: "gm", CurrentUTCOffset: 37, NextLeapSecond: &next},
+		},
+		{
+			name:     "insertion announced",
+			at:       leap.Add(-12 * time.Hour),
+			reported: pmc.AnnounceData{CurrentUtcOffset: 37, Leap61: true},
+			expected: ptpv1.NodeLeapSeconds{Node: "gm", Acknowledged: true, CurrentUTCOffset: 37, Leap61: true, NextLeapSecond: &next},
+		},
+		{
+			name:     "last second before the insertion",
+			at:       leap.Add(-time.Second),
+			reported: pmc.AnnounceData{CurrentUtcOffset: 37, Leap61: true},
+			expected: ptpv1.NodeLeapSeconds{Node: "gm", Acknowledged: true, CurrentUTCOffset: 37, Leap61: true, NextLeapSecond: &next},
+		},
+		{
+			name:     "daemon still announcing the insertion",
+			at:       leap,
+			reported: pmc.AnnounceData{CurrentUtcOffset: 37, Leap61: true},
+			expected: ptpv1.NodeLeapSeconds{Node: "gm", CurrentUTCOffset: 37, Leap61: true},
+		},
+		{
+			name:     "leap second in effect",
+			at:       leap.Add(time.Hour),
+			reported: pmc.AnnounceData{CurrentUtcOffset: 38},
+			expected: ptpv1.NodeLeapSeconds{Node: "gm", Acknowledged: true, CurrentUTCOffset: 38},
+		},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			reported := grandmasterSettings(step.reported)
+			_, changed, nodes, _ := nodeLeapSecondsData(data, []string{"gm"}, bundled, scheduled, reported, step.at)
+			assert.Equal(t, 0, changed)
+			assert.Equal(t, []ptpv1.NodeLeapSeconds{step.expected}, nodes)
+		})
+	}
+
+	// a node reporting no grandmaster settings does not acknowledge its file
+	_, _, nodes, _ = nodeLeapSecondsData(data, []string{"gm"}, bundled, scheduled, nil, now)
+	assert.Equal(t, []ptpv1.NodeLeapSeconds{{Node: "gm", NextLeapSecond: &next}}, nodes)
+
+	// suppressing the leap second before it takes effect rewrites the file, acknowledged once the daemon stops
+	// announcing it
+	suppressed := []ptpv1.PtpLeapEvent{{Time: next, Action: ptpv1.LeapEventSuppress}}
+	at := leap.Add(-time.Hour)
+	reported = grandmasterSettings(pmc.AnnounceData{CurrentUtcOffset: 37, Leap61: true})
+	data, changed, nodes, _ = nodeLeapSecondsData(data, []string{"gm"}, bundled, suppressed, reported, at)
+	assert.Equal(t, 1, changed)
+	assert.Equal(t, []ptpv1.NodeLeapSeconds{{Node: "gm", CurrentUTCOffset: 37, Leap61: true}}, nodes)
+
+	reported = grandmasterSettings(pmc.AnnounceData{CurrentUtcOffset: 37})
+	_, changed, nodes, _ = nodeLeapSecondsData(data, []string{"gm"}, bundled, suppressed, reported, at)
+	assert.Equal(t, 0, changed)
+	assert.Equal(t, []ptpv1.NodeLeapSeconds{{Node: "gm", Acknowledged: true, CurrentUTCOffset: 37}}, nodes)
+}
+
+func TestLeapEventsCondition(t *testing.T) {
+	assert.Nil(t, leapEventsCondition(nil, nil))
+
+	events := []ptpv1.PtpLeapEvent{{Time: metav1.NewTime(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)), Action: ptpv1.LeapEventInsert}}
+	cond := leapEventsCondition(events, nil)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+
+	cond = leapEventsCondition(events, []string{"a", "b"})
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, reasonLeapEventConflict, cond.Reason)
+	assert.Equal(t, "a; b", cond.Message)
+}
+
+func TestLeapSecondsExpiringCondition(t *testing.T) {
+	bundled, _ := loadTestLeapSeconds(t)
+	warning := 30 * 24 * time.Hour
+
+	cond := leapSecondsExpiringCondition(bundled.file, warning, time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, reasonLeapSecondsValid, cond.Reason)
+
+	cond = leapSecondsExpiringCondition(bundled.file, warning, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, reasonLeapSecondsExpiring, cond.Reason)
+	assert.Contains(t, cond.Message, "2026-06-28")
+
+	cond = leapSecondsExpiringCondition(bundled.file, warning, time.Date(2026, 6, 28, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, reasonLeapSecondsExpired, cond.Reason)
+
+	status := leapSecondsStatus(bundled, time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, 37, status.UTCOffset)
+	assert.Equal(t, leapSecondsSourceBundled, status.Source)
+}
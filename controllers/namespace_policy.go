@@ -0,0 +1,385 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// PtpConfigs may be authored in the namespaces listed in the PtpOperatorConfig ptpConfigNamespaces, in addition to
+// the operator namespace. Their secrets are resolved in their own namespace and mirrored to the operator namespace,
+// where the daemon pool DaemonSets mount them.
+const (
+	namespaceAllowedConditionType = "NamespaceAllowed"
+
+	reasonNamespaceAllowed    = "NamespaceAllowed"
+	reasonNamespaceNotAllowed = "NamespaceNotAllowed"
+
+	mirroredSecretPrefix    = "ptp-auth."
+	mirroredSecretLabel     = "ptp.openshift.io/mirrored-secret"
+	mirroredFromAnnotation  = "ptp.openshift.io/mirrored-from"
+	mirroredSecretsResync   = 5 * time.Minute
+	mirroredSecretLabelTrue = "true"
+)
+
+// namespacePolicies maps the namespaces allowed to author PtpConfigs, other than the operator namespace,
+// to the node selector restricting the nodes their profiles may be recommended to
+type namespacePolicies map[string]map[string]string
+
+// newNamespacePolicies returns the namespace policies of the PtpOperatorConfig
+func newNamespacePolicies(cfg *ptpv1.PtpOperatorConfig) namespacePolicies {
+	policies := make(namespacePolicies)
+	if cfg == nil {
+		return policies
+	}
+	for _, policy := range cfg.Spec.PtpConfigNamespaces {
+		if policy.Namespace == names.Namespace {
+			continue
+		}
+		policies[policy.Namespace] = policy.NodeSelector
+	}
+	return policies
+}
+
+// getOperatorConfig returns the default PtpOperatorConfig, or an empty one when it does not exist yet
+func getOperatorConfig(ctx context.Context, reader client.Reader) (*ptpv1.PtpOperatorConfig, error) {
+	cfg := &ptpv1.PtpOperatorConfig{}
+	err := reader.Get(ctx, types.NamespacedName{Namespace: names.Namespace, Name: names.DefaultOperatorConfigName}, cfg)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return &ptpv1.PtpOperatorConfig{}, nil
+		}
+		return nil, fmt.Errorf("failed to get PtpOperatorConfig: %v", err)
+	}
+	return cfg, nil
+}
+
+// getNamespacePolicies returns the namespace policies of the default PtpOperatorConfig
+func (r *PtpConfigReconciler) getNamespacePolicies(ctx context.Context) (namespacePolicies, error) {
+	cfg, err := getOperatorConfig(ctx, r.Client)
+	if err != nil {
+		return nil, err
+	}
+	return newNamespacePolicies(cfg), nil
+}
+
+// allowed checks if the PtpConfigs of the namespace are reconciled
+func (p namespacePolicies) allowed(namespace string) bool {
+	if namespace == names.Namespace {
+		return true
+	}
+	_, ok := p[namespace]
+	return ok
+}
+
+// targetsNode checks if the profiles of the PtpConfigs of the namespace may be recommended to the node
+func (p namespacePolicies) targetsNode(namespace string, node *corev1.Node) bool {
+	if namespace == names.Namespace {
+		return true
+	}
+	selector, ok := p[namespace]
+	if !ok {
+		return false
+	}
+	return labels.SelectorFromSet(selector).Matches(labels.Set(node.Labels))
+}
+
+// filterPtpConfigs splits the PtpConfigs into the ones of the allowed namespaces and the ignored ones
+func (p namespacePolicies) filterPtpConfigs(ptpConfigList *ptpv1.PtpConfigList) (*ptpv1.PtpConfigList, []ptpv1.PtpConfig) {
+	allowed := &ptpv1.PtpConfigList{TypeMeta: ptpConfigList.TypeMeta, ListMeta: ptpConfigList.ListMeta}
+	var ignored []ptpv1.PtpConfig
+	for _, cfg := range ptpConfigList.Items {
+		if p.allowed(cfg.Namespace) {
+			allowed.Items = append(allowed.Items, cfg)
+		} else {
+			ignored = append(ignored, cfg)
+		}
+	}
+	return allowed, ignored
+}
+
+// nodePtpConfigs returns the PtpConfigs whose profiles may be recommended to the node
+func (p namespacePolicies) nodePtpConfigs(ptpConfigList *ptpv1.PtpConfigList, node *corev1.Node) *ptpv1.PtpConfigList {
+	nodeList := &ptpv1.PtpConfigList{TypeMeta: ptpConfigList.TypeMeta, ListMeta: ptpConfigList.ListMeta}
+	for _, cfg := range ptpConfigList.Items {
+		if p.targetsNode(cfg.Namespace, node) {
+			nodeList.Items = append(nodeList.Items, cfg)
+		}
+	}
+	return nodeList
+}
+
+// namespacePoliciesPredicate filters the PtpOperatorConfig events that change the namespace policies
+func namespacePoliciesPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return e.Object.GetNamespace() == names.Namespace },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCfg, ok := e.ObjectOld.(*ptpv1.PtpOperatorConfig)
+			if !ok {
+				return false
+			}
+			newCfg, ok := e.ObjectNew.(*ptpv1.PtpOperatorConfig)
+			if !ok {
+				return false
+			}
+			return newCfg.Namespace == names.Namespace &&
+				!reflect.DeepEqual(oldCfg.Spec.PtpConfigNamespaces, newCfg.Spec.PtpConfigNamespaces)
+		},
+		DeleteFunc:  func(e event.DeleteEvent) bool { return e.Object.GetNamespace() == names.Namespace },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
+// namespaceAllowedCondition reports if the PtpConfig namespace is allowed to author PtpConfigs.
+// The condition is nil for the PtpConfigs of the operator namespace.
+func namespaceAllowedCondition(ptpConfig *ptpv1.PtpConfig, policies namespacePolicies) *metav1.Condition {
+	return namespacePolicyCondition(ptpConfig.Namespace, "PtpConfig", "the profiles may be recommended to", policies)
+}
+
+// subscriptionNamespaceCondition reports if the PtpEventSubscription namespace is allowed to author
+// PtpEventSubscriptions. The condition is nil for the PtpEventSubscriptions of the operator namespace.
+func subscriptionNamespaceCondition(subscription *ptpv1.PtpEventSubscription, policies namespacePolicies) *metav1.Condition {
+	return namespacePolicyCondition(subscription.Namespace, "PtpEventSubscription", "the subscriptions may target", policies)
+}
+
+// namespacePolicyCondition reports if the namespace of an object of the kind is allowed by the namespace policies,
+// and which nodes the object may target
+func namespacePolicyCondition(namespace, kind, target string, policies namespacePolicies) *metav1.Condition {
+	if namespace == names.Namespace {
+		return nil
+	}
+	selector, ok := policies[namespace]
+	if !ok {
+		return &metav1.Condition{
+			Type:   namespaceAllowedConditionType,
+			Status: metav1.ConditionFalse,
+			Reason: reasonNamespaceNotAllowed,
+			Message: fmt.Sprintf("namespace %s is not listed in the PtpOperatorConfig ptpConfigNamespaces, the %s is ignored",
+				namespace, kind),
+		}
+	}
+	message := target + " every node"
+	if len(selector) > 0 {
+		message = fmt.Sprintf("%s the nodes matching %s", target, labels.SelectorFromSet(selector).String())
+	}
+	return &metav1.Condition{
+		Type:    namespaceAllowedConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonNamespaceAllowed,
+		Message: message,
+	}
+}
+
+// syncIgnoredPtpConfigs reports the PtpConfigs of the namespaces that are not allowed, and clears their node status
+func (r *PtpConfigReconciler) syncIgnoredPtpConfigs(ctx context.Context, ignored []ptpv1.PtpConfig, policies namespacePolicies) {
+	for i := range ignored {
+		ptpConfig := &ignored[i]
+		changed := setOrRemoveStatusCondition(&ptpConfig.Status.Conditions, namespaceAllowedConditionType,
+			namespaceAllowedCondition(ptpConfig, policies))
+		if !changed && ptpConfig.Status.MatchList == nil && ptpConfig.Status.Authentication == nil {
+			continue
+		}
+		ptpConfig.Status.MatchList = nil
+		ptpConfig.Status.Authentication = nil
+		if err := r.Status().Update(ctx, ptpConfig); err != nil {
+			glog.Errorf("failed to update PTP config status for %s/%s: %v", ptpConfig.Namespace, ptpConfig.Name, err)
+		}
+	}
+}
+
+// getSecrets returns the secrets of a namespace by name. The secrets of other namespaces than the operator
+// namespace are not cached, they are read from the API server with the permissions granted in the namespace.
+func (r *PtpConfigReconciler) getSecrets(ctx context.Context, namespace string) (map[string]*corev1.Secret, error) {
+	var reader client.Reader = r.Client
+	if namespace != names.Namespace {
+		reader = r.APIReader
+	}
+	secretList := &corev1.SecretList{}
+	if err := reader.List(ctx, secretList, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list secrets of namespace %s: %v", namespace, err)
+	}
+	secrets := make(map[string]*corev1.Secret)
+	for i := range secretList.Items {
+		secrets[secretList.Items[i].Name] = &secretList.Items[i]
+	}
+	return secrets, nil
+}
+
+// getNamespaceSecrets returns the secrets of the namespaces of the PtpConfigs by namespace. A namespace whose
+// secrets cannot be read has no secret, the PtpConfig conditions report the missing secrets.
+func (r *PtpConfigReconciler) getNamespaceSecrets(ctx context.Context, ptpConfigList *ptpv1.PtpConfigList) (map[string]map[string]*corev1.Secret, error) {
+	secrets, err := r.getSecrets(ctx, names.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	namespaceSecrets := map[string]map[string]*corev1.Secret{names.Namespace: secrets}
+	for _, cfg := range ptpConfigList.Items {
+		if _, ok := namespaceSecrets[cfg.Namespace]; ok || len(getReferencedSecrets(&cfg)) == 0 {
+			continue
+		}
+		secrets, err := r.getSecrets(ctx, cfg.Namespace)
+		if err != nil {
+			glog.Errorf("failed to read the secrets referenced by the PtpConfigs of namespace %s: %v", cfg.Namespace, err)
+			secrets = map[string]*corev1.Secret{}
+		}
+		namespaceSecrets[cfg.Namespace] = secrets
+	}
+	return namespaceSecrets, nil
+}
+
+// getReferencedSecrets returns the names of the secrets referenced by the profiles of the PtpConfig
+func getReferencedSecrets(ptpConfig *ptpv1.PtpConfig) []string {
+	referenced := make(map[string]bool)
+	for i := range ptpConfig.Spec.Profile {
+		if secretName, _, ok := saFileSecret(ptpv1.ProfileSaFilePath(&ptpConfig.Spec.Profile[i])); ok {
+			referenced[secretName] = true
+		}
+	}
+	return sortedKeys(referenced)
+}
+
+// mirroredSecretName returns the name of the copy in the operator namespace of a secret of another namespace
+func mirroredSecretName(namespace, secretName string) string {
+	return limitNameLength(mirroredSecretPrefix + namespace + "." + secretName)
+}
+
+// mirrorProfileSecret makes the profile of a PtpConfig of another namespace than the operator namespace
+// reference the copy of its secret in the operator namespace
+func mirrorProfileSecret(profile *ptpv1.PtpProfile, namespace string) {
+	if namespace == names.Namespace {
+		return
+	}
+	if profile.Authentication != nil {
+		profile.Authentication.SecretRef.Name = mirroredSecretName(namespace, profile.Authentication.SecretRef.Name)
+		return
+	}
+	secretName, secretKey, ok := saFileSecret(ptpv1.ProfileSaFilePath(profile))
+	if !ok || profile.Ptp4lConf == nil {
+		return
+	}
+	saFilePath := PTP_SEC_FOLDER + mirroredSecretName(namespace, secretName) + "/" + secretKey
+	conf := replaceGlobalOption(*profile.Ptp4lConf, "sa_file", saFilePath)
+	profile.Ptp4lConf = &conf
+}
+
+// replaceGlobalOption replaces the value of an option of the [global] section of a ptp4lConf
+func replaceGlobalOption(conf, option, value string) string {
+	lines := strings.Split(conf, "\n")
+	global := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			global = trimmed == "[global]"
+			continue
+		}
+		if fields := strings.Fields(trimmed); global && len(fields) > 0 && fields[0] == option {
+			lines[i] = option + " " + value
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// getMirroredSecrets returns the copies of the secrets of other namespaces than the operator namespace referenced by
+// the PtpConfigs, by name. Secrets that do not exist are not mirrored.
+func getMirroredSecrets(ptpConfigList *ptpv1.PtpConfigList, namespaceSecrets map[string]map[string]*corev1.Secret) map[string]*corev1.Secret {
+	mirrored := make(map[string]*corev1.Secret)
+	for _, cfg := range ptpConfigList.Items {
+		if cfg.Namespace == names.Namespace {
+			continue
+		}
+		for _, secretName := range getReferencedSecrets(&cfg) {
+			source, ok := namespaceSecrets[cfg.Namespace][secretName]
+			if !ok {
+				continue
+			}
+			name := mirroredSecretName(cfg.Namespace, secretName)
+			mirrored[name] = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Namespace:   names.Namespace,
+					Labels:      map[string]string{mirroredSecretLabel: mirroredSecretLabelTrue},
+					Annotations: map[string]string{mirroredFromAnnotation: cfg.Namespace + "/" + secretName},
+				},
+				Type: source.Type,
+				Data: source.Data,
+			}
+		}
+	}
+	return mirrored
+}
+
+// syncMirroredSecrets creates and updates the copies in the operator namespace of the secrets referenced by the
+// PtpConfigs of other namespaces, and deletes the copies no longer referenced. The operator does not watch the
+// secrets of other namespaces, it returns the period after which they are read again.
+func (r *PtpConfigReconciler) syncMirroredSecrets(ctx context.Context, ptpConfigList *ptpv1.PtpConfigList, namespaceSecrets map[string]map[string]*corev1.Secret) (time.Duration, error) {
+	mirrored := getMirroredSecrets(ptpConfigList, namespaceSecrets)
+	secrets := namespaceSecrets[names.Namespace]
+
+	for name, secret := range secrets {
+		if secret.Labels[mirroredSecretLabel] != mirroredSecretLabelTrue {
+			continue
+		}
+		if _, wanted := mirrored[name]; wanted {
+			continue
+		}
+		glog.Infof("Deleting mirrored secret %s of %s", name, secret.Annotations[mirroredFromAnnotation])
+		if err := r.Delete(ctx, secret); err != nil && !errors.IsNotFound(err) {
+			return 0, fmt.Errorf("failed to delete mirrored secret %s: %v", name, err)
+		}
+	}
+
+	for _, name := range sortedSecretNames(mirrored) {
+		desired := mirrored[name]
+		current, exists := secrets[name]
+		switch {
+		case !exists:
+			glog.Infof("Mirroring secret %s to %s", desired.Annotations[mirroredFromAnnotation], name)
+			if err := r.Create(ctx, desired); err != nil && !errors.IsAlreadyExists(err) {
+				return 0, fmt.Errorf("failed to create mirrored secret %s: %v", name, err)
+			}
+		case current.Labels[mirroredSecretLabel] != mirroredSecretLabelTrue:
+			glog.Errorf("secret %s/%s is not a mirrored secret, cannot mirror %s", names.Namespace, name,
+				desired.Annotations[mirroredFromAnnotation])
+		case !reflect.DeepEqual(current.Data, desired.Data) || current.Annotations[mirroredFromAnnotation] != desired.Annotations[mirroredFromAnnotation]:
+			updated := current.DeepCopy()
+			updated.Data = desired.Data
+			if updated.Annotations == nil {
+				updated.Annotations = map[string]string{}
+			}
+			updated.Annotations[mirroredFromAnnotation] = desired.Annotations[mirroredFromAnnotation]
+			glog.Infof("Updating mirrored secret %s of %s", name, desired.Annotations[mirroredFromAnnotation])
+			if err := r.Update(ctx, updated); err != nil {
+				return 0, fmt.Errorf("failed to update mirrored secret %s: %v", name, err)
+			}
+		}
+	}
+
+	for _, cfg := range ptpConfigList.Items {
+		if cfg.Namespace != names.Namespace && len(getReferencedSecrets(&cfg)) > 0 {
+			return mirroredSecretsResync, nil
+		}
+	}
+	return 0, nil
+}
+
+func sortedSecretNames(secrets map[string]*corev1.Secret) []string {
+	set := make(map[string]bool, len(secrets))
+	for name := range secrets {
+		set[name] = true
+	}
+	return sortedKeys(set)
+}
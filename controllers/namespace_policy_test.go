@@ -0,0 +1,219 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+)
+
+func makeNamespacePolicies() namespacePolicies {
+	return newNamespacePolicies(&ptpv1.PtpOperatorConfig{
+		Spec: ptpv1.PtpOperatorConfigSpec{
+			PtpConfigNamespaces: []ptpv1.PtpConfigNamespacePolicy{
+				{Namespace: "site-a", NodeSelector: map[string]string{"site": "a"}},
+				{Namespace: "site-any"},
+			},
+		},
+	})
+}
+
+func makeTenantPtpConfig(namespace string, profiles []ptpv1.PtpProfile, recommends []ptpv1.PtpRecommend) ptpv1.PtpConfig {
+	cfg := makePtpConfig(namespace+"-cfg", profiles, recommends)
+	cfg.Namespace = namespace
+	return cfg
+}
+
+func TestNamespacePolicies(t *testing.T) {
+	policies := makeNamespacePolicies()
+	siteA := makeNode("node-a", map[string]string{"site": "a"})
+	siteB := makeNode("node-b", map[string]string{"site": "b"})
+
+	tests := []struct {
+		namespace string
+		allowed   bool
+		nodeA     bool
+		nodeB     bool
+	}{
+		{namespace: "openshift-ptp", allowed: true, nodeA: true, nodeB: true},
+		{namespace: "site-a", allowed: true, nodeA: true, nodeB: false},
+		{namespace: "site-any", allowed: true, nodeA: true, nodeB: true},
+		{namespace: "other", allowed: false, nodeA: false, nodeB: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.namespace, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, policies.allowed(tt.namespace))
+			assert.Equal(t, tt.nodeA, policies.targetsNode(tt.namespace, &siteA))
+			assert.Equal(t, tt.nodeB, policies.targetsNode(tt.namespace, &siteB))
+		})
+	}
+}
+
+func TestNamespacePolicies_IgnoresOperatorNamespace(t *testing.T) {
+	policies := newNamespacePolicies(&ptpv1.PtpOperatorConfig{
+		Spec: ptpv1.PtpOperatorConfigSpec{
+			PtpConfigNamespaces: []ptpv1.PtpConfigNamespacePolicy{
+				{Namespace: "openshift-ptp", NodeSelector: map[string]string{"site": "a"}},
+			},
+		},
+	})
+	node := makeNode("node-b", map[string]string{"site": "b"})
+	assert.True(t, policies.targetsNode("openshift-ptp", &node))
+}
+
+func TestFilterPtpConfigs(t *testing.T) {
+	policies := makeNamespacePolicies()
+	list := makePtpConfigList(
+		makePtpConfig("cfg", nil, nil),
+		makeTenantPtpConfig("site-a", nil, nil),
+		makeTenantPtpConfig("other", nil, nil),
+	)
+
+	allowed, ignored := policies.filterPtpConfigs(list)
+	assert.Len(t, allowed.Items, 2)
+	assert.Equal(t, "openshift-ptp", allowed.Items[0].Namespace)
+	assert.Equal(t, "site-a", allowed.Items[1].Namespace)
+	if assert.Len(t, ignored, 1) {
+		assert.Equal(t, "other", ignored[0].Namespace)
+	}
+}
+
+func TestNodePtpConfigs_RestrictsRecommendations(t *testing.T) {
+	policies := makeNamespacePolicies()
+	list := makePtpConfigList(
+		makeTenantPtpConfig("site-a",
+			[]ptpv1.PtpProfile{makeProfile("tenant", nil)},
+			[]ptpv1.PtpRecommend{makeRecommend("tenant", 0, "ptp")}),
+		makePtpConfig("cfg",
+			[]ptpv1.PtpProfile{makeProfile("default", nil)},
+			[]ptpv1.PtpRecommend{makeRecommend("default", 10, "ptp")}),
+	)
+
+	nodeA := makeNode("node-a", map[string]string{"site": "a", "ptp": ""})
+	profiles, err := getRecommendNodePtpProfiles(policies.nodePtpConfigs(list, &nodeA), nodeA)
+	assert.NoError(t, err)
+	if assert.Len(t, profiles, 1) {
+		assert.Equal(t, "site-a/site-a-cfg_tenant", *profiles[0].Name)
+	}
+
+	nodeB := makeNode("node-b", map[string]string{"site": "b", "ptp": ""})
+	profiles, err = getRecommendNodePtpProfiles(policies.nodePtpConfigs(list, &nodeB), nodeB)
+	assert.NoError(t, err)
+	if assert.Len(t, profiles, 1) {
+		assert.Equal(t, "cfg_default", *profiles[0].Name)
+	}
+}
+
+func TestNamespaceAllowedCondition(t *testing.T) {
+	policies := makeNamespacePolicies()
+
+	cfg := makePtpConfig("cfg", nil, nil)
+	assert.Nil(t, namespaceAllowedCondition(&cfg, policies))
+
+	cfg = makeTenantPtpConfig("site-a", nil, nil)
+	cond := namespaceAllowedCondition(&cfg, policies)
+	if assert.NotNil(t, cond) {
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Contains(t, cond.Message, "site=a")
+	}
+
+	cfg = makeTenantPtpConfig("other", nil, nil)
+	cond = namespaceAllowedCondition(&cfg, policies)
+	if assert.NotNil(t, cond) {
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+		assert.Equal(t, reasonNamespaceNotAllowed, cond.Reason)
+	}
+}
+
+func TestSubscriptionNamespaceCondition(t *testing.T) {
+	policies := makeNamespacePolicies()
+
+	subscription := &ptpv1.PtpEventSubscription{ObjectMeta: metav1.ObjectMeta{Name: "sub", Namespace: "openshift-ptp"}}
+	assert.Nil(t, subscriptionNamespaceCondition(subscription, policies))
+
+	subscription.Namespace = "site-a"
+	cond := subscriptionNamespaceCondition(subscription, policies)
+	if assert.NotNil(t, cond) {
+		assert.Equal(t, metav1.ConditionTrue, cond.Status)
+		assert.Equal(t, "the subscriptions may target the nodes matching site=a", cond.Message)
+	}
+
+	subscription.Namespace = "other"
+	cond = subscriptionNamespaceCondition(subscription, policies)
+	if assert.NotNil(t, cond) {
+		assert.Equal(t, metav1.ConditionFalse, cond.Status)
+		assert.Equal(t, reasonNamespaceNotAllowed, cond.Reason)
+		assert.Contains(t, cond.Message, "the PtpEventSubscription is ignored")
+	}
+}
+
+func TestMirroredSecretName(t *testing.T) {
+	assert.Equal(t, "ptp-auth.site-a.ptp-sa", mirroredSecretName("site-a", "ptp-sa"))
+
+	long := mirroredSecretName("site-a", strings.Repeat("s", 253))
+	assert.Len(t, long, 253)
+	assert.NotEqual(t, long, mirroredSecretName("site-b", strings.Repeat("s", 253)))
+}
+
+func TestMirrorProfileSecret(t *testing.T) {
+	profile := makeSaFileProfile("tenant", "ptp-sa")
+	mirrorProfileSecret(&profile, "site-a")
+	assert.Equal(t, "/etc/ptp-secret-mount/ptp-auth.site-a.ptp-sa/sa_file", ptpv1.ProfileSaFilePath(&profile))
+	assert.Contains(t, *profile.Ptp4lConf, "[ens1f0]\nspp 1")
+
+	profile = makeAuthPtpConfig(1).Spec.Profile[0]
+	secretName := profile.Authentication.SecretRef.Name
+	mirrorProfileSecret(&profile, "site-a")
+	assert.Equal(t, "ptp-auth.site-a."+secretName, profile.Authentication.SecretRef.Name)
+
+	profile = makeSaFileProfile("default", "ptp-sa")
+	mirrorProfileSecret(&profile, "openshift-ptp")
+	assert.Equal(t, "/etc/ptp-secret-mount/ptp-sa/sa_file", ptpv1.ProfileSaFilePath(&profile))
+}
+
+func TestGetMirroredSecrets(t *testing.T) {
+	list := makePtpConfigList(
+		makePtpConfig("cfg", []ptpv1.PtpProfile{makeSaFileProfile("default", "ptp-sa")}, nil),
+		makeTenantPtpConfig("site-a", []ptpv1.PtpProfile{
+			makeSaFileProfile("tenant", "ptp-sa"),
+			makeSaFileProfile("missing", "missing-sa"),
+		}, nil),
+	)
+	namespaceSecrets := map[string]map[string]*corev1.Secret{
+		"openshift-ptp": {"ptp-sa": {ObjectMeta: metav1.ObjectMeta{Name: "ptp-sa"}}},
+		"site-a": {"ptp-sa": {
+			ObjectMeta: metav1.ObjectMeta{Name: "ptp-sa", Namespace: "site-a"},
+			Data:       map[string][]byte{"sa_file": []byte("content")},
+		}},
+	}
+
+	mirrored := getMirroredSecrets(list, namespaceSecrets)
+	assert.Len(t, mirrored, 1)
+	secret := mirrored["ptp-auth.site-a.ptp-sa"]
+	if assert.NotNil(t, secret) {
+		assert.Equal(t, "openshift-ptp", secret.Namespace)
+		assert.Equal(t, "true", secret.Labels[mirroredSecretLabel])
+		assert.Equal(t, "site-a/ptp-sa", secret.Annotations[mirroredFromAnnotation])
+		assert.Equal(t, []byte("content"), secret.Data["sa_file"])
+	}
+}
+
+func TestGetNodeSecrets_TenantNamespace(t *testing.T) {
+	list := makePtpConfigList(makeTenantPtpConfig("site-a",
+		[]ptpv1.PtpProfile{makeSaFileProfile("tenant", "ptp-sa")},
+		[]ptpv1.PtpRecommend{makeRecommend("tenant", 0, "ptp")}))
+	nodes := &corev1.NodeList{Items: []corev1.Node{
+		makeNode("node-a", map[string]string{"site": "a", "ptp": ""}),
+		makeNode("node-b", map[string]string{"site": "b", "ptp": ""}),
+	}}
+	secrets := map[string]*corev1.Secret{
+		"ptp-auth.site-a.ptp-sa": makeSaFileSecret("ptp-auth.site-a.ptp-sa", "sa_file"),
+	}
+
+	nodeSecrets := getNodeSecrets(list, nodes, secrets, makeNamespacePolicies())
+	assert.Equal(t, map[string]map[string][]string{"node-a": {"ptp-auth.site-a.ptp-sa": {"sa_file"}}}, nodeSecrets)
+}
@@ -0,0 +1,199 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// nodePluginsConfigMapName is the ConfigMap holding the plugins loaded on each node, keyed by node name, when the
+// plugins are selected from the node hardware
+const nodePluginsConfigMapName = "ptp-plugins"
+
+// nicPluginDevices lists the PCI vendor:device identifiers of the NICs driven by each NIC plugin
+var nicPluginDevices = map[string][]string{
+	"e810": {"8086:1591", "8086:1592", "8086:1593", "8086:1599", "8086:159a", "8086:159b"},
+	"e825": {"8086:579c", "8086:579d", "8086:579e", "8086:579f"},
+	"e830": {"8086:12d1", "8086:12d2", "8086:12d3", "8086:12d5", "8086:12d8", "8086:12da", "8086:12dc", "8086:12dd", "8086:12de"},
+}
+
+// pluginNames returns the sorted names of the plugins enabled in the pool, the default plugins when not set
+func (p *daemonPool) pluginNames() []string {
+	if p.enabledPlugins == nil {
+		return slices.Clone(ptpv1.DefaultPlugins)
+	}
+	plugins := make([]string, 0, len(*p.enabledPlugins))
+	for name := range *p.enabledPlugins {
+		plugins = append(plugins, name)
+	}
+	sort.Strings(plugins)
+	return plugins
+}
+
+// selectNodePlugins returns the enabled plugins loaded on a node. With hardware selection, the NIC plugins are only
+// kept when a device of the node matches them; nodes without reported hardware keep every enabled plugin.
+func selectNodePlugins(enabled []string, selection ptpv1.PluginSelection, nodeDevice *ptpv1.NodePtpDevice) []string {
+	if selection != ptpv1.PluginSelectionHardware || nodeDevice == nil {
+		return enabled
+	}
+	detected := map[string]bool{}
+	reported := false
+	for _, device := range nodeDevice.Status.Devices {
+		if device.HardwareInfo == nil {
+			continue
+		}
+		reported = true
+		id := normalizePCIID(device.HardwareInfo.VendorID) + ":" + normalizePCIID(device.HardwareInfo.DeviceID)
+		for plugin, ids := range nicPluginDevices {
+			if slices.Contains(ids, id) {
+				detected[plugin] = true
+			}
+		}
+	}
+	if !reported {
+		return enabled
+	}
+	plugins := []string{}
+	for _, plugin := range enabled {
+		if _, nic := nicPluginDevices[plugin]; nic && !detected[plugin] {
+			continue
+		}
+		plugins = append(plugins, plugin)
+	}
+	return plugins
+}
+
+// getNodePlugins returns the plugins loaded on each node selected by DaemonNodeSelector, sorted by node name
+func getNodePlugins(
+	defaultCfg *ptpv1.PtpOperatorConfig,
+	pools []daemonPool,
+	nodeList *corev1.NodeList,
+	nodeDevices map[string]*ptpv1.NodePtpDevice,
+) []ptpv1.NodePlugins {
+	poolPlugins := make(map[string][]string, len(pools))
+	for i := range pools {
+		poolPlugins[pools[i].name] = pools[i].pluginNames()
+	}
+	daemonSelector := labels.SelectorFromSet(defaultCfg.Spec.DaemonNodeSelector)
+	nodePlugins := []ptpv1.NodePlugins{}
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if !daemonSelector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		pool := nodeDaemonPool(defaultCfg, node)
+		nodePlugins = append(nodePlugins, ptpv1.NodePlugins{
+			Node:       node.Name,
+			DaemonPool: pool,
+			Plugins:    selectNodePlugins(poolPlugins[pool], defaultCfg.Spec.PluginSelection, nodeDevices[node.Name]),
+		})
+	}
+	sort.Slice(nodePlugins, func(i, j int) bool { return nodePlugins[i].Node < nodePlugins[j].Node })
+	return nodePlugins
+}
+
+// nodePluginsConfigMapData returns the content of the ptp-plugins ConfigMap and the hash of the content of the nodes
+// of each pool, keyed by pool name. The hash is set on the linuxptp daemon pods of the pool so they reload their
+// plugins when the plugins of one of their nodes change, without restarting the pods of the other pools.
+func nodePluginsConfigMapData(pools []daemonPool, nodePlugins []ptpv1.NodePlugins) (map[string]string, map[string]string) {
+	data := make(map[string]string, len(nodePlugins))
+	hashes := make(map[string]hash.Hash, len(pools))
+	for i := range pools {
+		hashes[pools[i].name] = sha256.New()
+	}
+	for _, node := range nodePlugins {
+		data[node.Node] = strings.Join(node.Plugins, ",")
+		if poolHash, ok := hashes[node.DaemonPool]; ok {
+			fmt.Fprintf(poolHash, "%s=%s\n", node.Node, data[node.Node])
+		}
+	}
+	poolHashes := make(map[string]string, len(hashes))
+	for pool, poolHash := range hashes {
+		poolHashes[pool] = fmt.Sprintf("%x", poolHash.Sum(nil))[:16]
+	}
+	return data, poolHashes
+}
+
+// getNodePtpDevices returns the NodePtpDevice of each node, by node name
+func (r *PtpOperatorConfigReconciler) getNodePtpDevices(ctx context.Context) (map[string]*ptpv1.NodePtpDevice, error) {
+	nodeDeviceList := &ptpv1.NodePtpDeviceList{}
+	if err := r.List(ctx, nodeDeviceList, client.InNamespace(names.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list node ptp devices: %v", err)
+	}
+	nodeDevices := make(map[string]*ptpv1.NodePtpDevice, len(nodeDeviceList.Items))
+	for i := range nodeDeviceList.Items {
+		nodeDevices[nodeDeviceList.Items[i].Name] = &nodeDeviceList.Items[i]
+	}
+	return nodeDevices, nil
+}
+
+// syncNodePlugins computes the plugins loaded on each node, stores them in the ptp-plugins ConfigMap when they are
+// selected from the node hardware, and sets them in the PtpOperatorConfig status. It returns the hash of the
+// ptp-plugins ConfigMap content of the nodes of each pool, nil when the plugins are not selected from the node
+// hardware.
+func (r *PtpOperatorConfigReconciler) syncNodePlugins(
+	ctx context.Context,
+	defaultCfg *ptpv1.PtpOperatorConfig,
+	pools []daemonPool,
+	nodeList *corev1.NodeList,
+) (map[string]string, error) {
+	nodeDevices, err := r.getNodePtpDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nodePlugins := getNodePlugins(defaultCfg, pools, nodeList, nodeDevices)
+
+	var poolHashes map[string]string
+	cm := &corev1.ConfigMap{}
+	err = r.Get(ctx, types.NamespacedName{Namespace: names.Namespace, Name: nodePluginsConfigMapName}, cm)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get %s config map: %v", nodePluginsConfigMapName, err)
+	}
+	exists := err == nil
+	if defaultCfg.Spec.PluginSelection == ptpv1.PluginSelectionHardware {
+		var data map[string]string
+		data, poolHashes = nodePluginsConfigMapData(pools, nodePlugins)
+		switch {
+		case !exists:
+			cm.Name = nodePluginsConfigMapName
+			cm.Namespace = names.Namespace
+			cm.Data = data
+			if err = controllerutil.SetControllerReference(defaultCfg, cm, r.Scheme); err != nil {
+				return nil, fmt.Errorf("failed to set owner reference: %v", err)
+			}
+			if err = r.Create(ctx, cm); err != nil {
+				return nil, fmt.Errorf("failed to create %s config map: %v", nodePluginsConfigMapName, err)
+			}
+		case !equality.Semantic.DeepEqual(cm.Data, data):
+			cm.Data = data
+			if err = r.Update(ctx, cm); err != nil {
+				return nil, fmt.Errorf("failed to update %s config map: %v", nodePluginsConfigMapName, err)
+			}
+			glog.Infof("node plugins updated: %v", data)
+		}
+	} else if exists {
+		if err = r.Delete(ctx, cm); err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to delete %s config map: %v", nodePluginsConfigMapName, err)
+		}
+	}
+
+	defaultCfg.Status.Plugins = pools[0].pluginNames()
+	defaultCfg.Status.PluginsDefaulted = defaultCfg.Spec.EnabledPlugins == nil
+	defaultCfg.Status.NodePlugins = nodePlugins
+	return poolHashes, nil
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+)
+
+func nodePtpDevicePtr(nodePtpDevice ptpv1.NodePtpDevice) *ptpv1.NodePtpDevice {
+	return &nodePtpDevice
+}
+
+func TestDaemonPoolPluginNames(t *testing.T) {
+	pool := &daemonPool{}
+	assert.Equal(t, []string{"e810", "e825", "e830", "ntpfailover"}, pool.pluginNames())
+
+	plugins := map[string]*apiextensions.JSON{"ntpfailover": nil, "e810": nil}
+	pool.enabledPlugins = &plugins
+	assert.Equal(t, []string{"e810", "ntpfailover"}, pool.pluginNames())
+
+	none := map[string]*apiextensions.JSON{}
+	pool.enabledPlugins = &none
+	assert.Empty(t, pool.pluginNames())
+}
+
+func TestSelectNodePlugins(t *testing.T) {
+	enabled := []string{"e810", "e825", "e830", "ntpfailover"}
+	tests := []struct {
+		name       string
+		selection  ptpv1.PluginSelection
+		nodeDevice *ptpv1.NodePtpDevice
+		expected   []string
+	}{
+		{name: "all", selection: ptpv1.PluginSelectionAll, nodeDevice: nodePtpDevicePtr(makeNodePtpDevice("node", e810Info)), expected: enabled},
+		{name: "not set", nodeDevice: nodePtpDevicePtr(makeNodePtpDevice("node", e810Info)), expected: enabled},
+		{name: "e810 node", selection: ptpv1.PluginSelectionHardware, nodeDevice: nodePtpDevicePtr(makeNodePtpDevice("node", e810Info)), expected: []string{"e810", "ntpfailover"}},
+		{name: "e810 and e830 node", selection: ptpv1.PluginSelectionHardware, nodeDevice: nodePtpDevicePtr(makeNodePtpDevice("node", &ptpv1.HardwareInfo{VendorID: "8086", DeviceID: "159B"}, e830Info)), expected: []string{"e810", "e830", "ntpfailover"}},
+		{name: "other nic", selection: ptpv1.PluginSelectionHardware, nodeDevice: nodePtpDevicePtr(makeNodePtpDevice("node", &ptpv1.HardwareInfo{VendorID: "0x15b3", DeviceID: "0x101d"})), expected: []string{"ntpfailover"}},
+		{name: "no node device", selection: ptpv1.PluginSelectionHardware, expected: enabled},
+		{name: "hardware not reported", selection: ptpv1.PluginSelectionHardware, nodeDevice: nodePtpDevicePtr(makeNodePtpDevice("node", nil)), expected: enabled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, selectNodePlugins(enabled, tt.selection, tt.nodeDevice))
+		})
+	}
+}
+
+func TestGetNodePlugins(t *testing.T) {
+	cfg := makeDaemonPoolsConfig()
+	cfg.Spec.DaemonNodeSelector = map[string]string{"ptp": ""}
+	cfg.Spec.PluginSelection = ptpv1.PluginSelectionHardware
+	pools := getDaemonPools(cfg)
+	nodes := &corev1.NodeList{Items: []corev1.Node{
+		makeNode("worker-1", map[string]string{"ptp": "", "node-role.kubernetes.io/worker": ""}),
+		makeNode("gm-1", map[string]string{"ptp": "", "ptp/role": "gm", "node-role.kubernetes.io/worker": ""}),
+		makeNode("master-1", map[string]string{"ptp": ""}),
+		makeNode("infra-1", nil),
+	}}
+	nodeDevices := map[string]*ptpv1.NodePtpDevice{
+		"gm-1":     nodePtpDevicePtr(makeNodePtpDevice("gm-1", e810Info)),
+		"worker-1": nodePtpDevicePtr(makeNodePtpDevice("worker-1", e830Info)),
+	}
+
+	assert.Equal(t, []ptpv1.NodePlugins{
+		{Node: "gm-1", DaemonPool: "gm", Plugins: []string{"e810", "ntpfailover"}},
+		{Node: "master-1", Plugins: []string{"e810"}},
+		{Node: "worker-1", DaemonPool: "worker", Plugins: []string{}},
+	}, getNodePlugins(cfg, pools, nodes, nodeDevices))
+}
+
+func TestNodePluginsConfigMapData(t *testing.T) {
+	pools := getDaemonPools(makeDaemonPoolsConfig())
+	nodePlugins := []ptpv1.NodePlugins{
+		{Node: "gm-1", DaemonPool: "gm", Plugins: []string{"e810", "ntpfailover"}},
+		{Node: "master-1", Plugins: []string{"e810"}},
+		{Node: "worker-1", DaemonPool: "worker", Plugins: []string{}},
+	}
+	data, hashes := nodePluginsConfigMapData(pools, nodePlugins)
+	assert.Equal(t, map[string]string{"gm-1": "e810,ntpfailover", "master-1": "e810", "worker-1": ""}, data)
+	assert.Len(t, hashes, len(pools))
+	for _, pool := range pools {
+		assert.Len(t, hashes[pool.name], 16)
+	}
+
+	// only the hash of the pool of the changed node changes
+	nodePlugins[2].Plugins = []string{"e830"}
+	_, changed := nodePluginsConfigMapData(pools, nodePlugins)
+	assert.NotEqual(t, hashes["worker"], changed["worker"])
+	assert.Equal(t, hashes["gm"], changed["gm"])
+	assert.Equal(t, hashes[""], changed[""])
+}
+
+func TestRenderDaemonSetNodePlugins(t *testing.T) {
+	data := makeTestRenderData()
+	data.Data["PluginsHash"] = "0123456789abcdef"
+	ds := renderTestDaemonSet(t, data)
+
+	assert.Equal(t, "0123456789abcdef", ds.Spec.Template.Annotations["ptp.openshift.io/plugins-hash"])
+	container := getContainer(ds, linuxptpDaemonContainer)
+	if assert.NotNil(t, container) {
+		assert.True(t, strings.HasPrefix(container.Args[0], "PLUGINS=$(cat /etc/ptp-plugins/$NODE_NAME 2>/dev/null || echo $PLUGINS) /usr/local/bin/ptp"))
+		assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "ptp-plugins", MountPath: "/etc/ptp-plugins", ReadOnly: true})
+	}
+	var configMap *corev1.ConfigMapVolumeSource
+	for _, volume := range ds.Spec.Template.Spec.Volumes {
+		if volume.Name == "ptp-plugins" {
+			configMap = volume.ConfigMap
+		}
+	}
+	if assert.NotNil(t, configMap) {
+		assert.Equal(t, nodePluginsConfigMapName, configMap.Name)
+		assert.True(t, *configMap.Optional)
+	}
+
+	ds = renderTestDaemonSet(t, makeTestRenderData())
+	assert.NotContains(t, ds.Spec.Template.Annotations, "ptp.openshift.io/plugins-hash")
+	container = getContainer(ds, linuxptpDaemonContainer)
+	if assert.NotNil(t, container) {
+		assert.True(t, strings.HasPrefix(container.Args[0], "/usr/local/bin/ptp"))
+	}
+}
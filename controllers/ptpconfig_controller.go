@@ -30,6 +30,7 @@ import (
 	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -40,6 +41,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -50,14 +52,17 @@ const TLV_AUTH_SUFFIX = "-tlv-auth"
 // PtpConfigReconciler reconciles a PtpConfig object
 type PtpConfigReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	// APIReader reads the secrets of the PtpConfig namespaces, which are not cached
+	APIReader client.Reader
+	Log       logr.Logger
+	Scheme    *runtime.Scheme
 }
 
 //+kubebuilder:rbac:groups=ptp.openshift.io,resources=ptpconfigs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=ptp.openshift.io,resources=ptpconfigs/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=ptp.openshift.io,resources=ptpconfigs/finalizers,verbs=update
 //+kubebuilder:rbac:groups=config.openshift.io,resources=infrastructures,verbs=get;list;watch
+//+kubebuilder:rbac:groups=ptp.openshift.io,resources=nodeptpdevices,verbs=get;list;watch
 
 func (r *PtpConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconcile.Result, error) {
 	reqLogger := r.Log.WithValues("Request.Namespace", req.Namespace, "Request.Name", req.Name)
@@ -79,20 +84,49 @@ func (r *PtpConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return reconcile.Result{}, err
 	}
 
-	if err = r.syncPtpConfig(ctx, instances, nodeList); err != nil {
+	// Ignore the PtpConfigs of the namespaces that are not allowed to author PtpConfigs
+	policies, err := r.getNamespacePolicies(ctx)
+	if err != nil {
 		return reconcile.Result{}, err
 	}
+	instances, ignored := policies.filterPtpConfigs(instances)
+	r.syncIgnoredPtpConfigs(ctx, ignored, policies)
 
-	// After syncing ConfigMap, update DaemonSet with secret mounts
-	if err = r.syncLinuxptpDaemonSecrets(ctx, instances); err != nil {
+	// Mirror the secrets referenced by the PtpConfigs of other namespaces to the operator namespace
+	namespaceSecrets, err := r.getNamespaceSecrets(ctx, instances)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	mirrorResync, err := r.syncMirroredSecrets(ctx, instances, namespaceSecrets)
+	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{}, nil
+	// Rotate the keys of the operator-managed secrets and render their active keys into the profiles
+	activeKeys, requeueAfter, err := r.syncKeyRotation(ctx, instances)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	instances = applyManagedActiveKeys(instances, activeKeys)
+
+	if err = r.syncPtpConfig(ctx, instances, nodeList, policies, namespaceSecrets); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// After syncing ConfigMap, mount the secrets in the DaemonSets
+	if err = r.syncLinuxptpDaemonSecrets(ctx, instances, nodeList, policies); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if mirrorResync > 0 && (requeueAfter == 0 || mirrorResync < requeueAfter) {
+		requeueAfter = mirrorResync
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // syncPtpConfig synchronizes PtpConfig CR
-func (r *PtpConfigReconciler) syncPtpConfig(ctx context.Context, ptpConfigList *ptpv1.PtpConfigList, nodeList *corev1.NodeList) error {
+func (r *PtpConfigReconciler) syncPtpConfig(ctx context.Context, ptpConfigList *ptpv1.PtpConfigList, nodeList *corev1.NodeList,
+	policies namespacePolicies, namespaceSecrets map[string]map[string]*corev1.Secret) error {
 	var err error
 
 	nodePtpConfigMap := &corev1.ConfigMap{}
@@ -100,11 +134,31 @@ func (r *PtpConfigReconciler) syncPtpConfig(ctx context.Context, ptpConfigList *
 	nodePtpConfigMap.Namespace = names.Namespace
 	nodePtpConfigMap.Data = make(map[string]string)
 
+	// The profiles delivered to each node, with their settings rendered and their profile references qualified
+	nodeProfiles := make(map[string][]ptpv1.PtpProfile, len(nodeList.Items))
+	var nodeProfilesErr error
+	for _, node := range nodeList.Items {
+		nodePtpProfiles, err := getRecommendNodePtpProfiles(policies.nodePtpConfigs(ptpConfigList, &node), node)
+		if err != nil {
+			if nodeProfilesErr == nil {
+				nodeProfilesErr = fmt.Errorf("failed to get recommended node PtpConfig: %v", err)
+			}
+			continue
+		}
+		nodeProfiles[node.Name] = nodePtpProfiles
+	}
+	haStates := r.getHighAvailabilityStates(ctx)
+
 	// Also update PTP config status with match list
 	for _, ptpConfig := range ptpConfigList.Items {
 		var matchList []ptpv1.NodeMatchList
+		var authStatus []ptpv1.NodeAuthenticationStatus
+		var haStatus []ptpv1.NodeHighAvailabilityStatus
 
 		for _, node := range nodeList.Items {
+			if !policies.targetsNode(ptpConfig.Namespace, &node) {
+				continue
+			}
 			nodePtpProfiles, err := getRecommendNodePtpProfilesForConfig(&ptpConfig, node)
 			if err != nil {
 				glog.Errorf("failed to get recommended profiles for node %s: %v", node.Name, err)
@@ -119,12 +173,34 @@ func (r *PtpConfigReconciler) syncPtpConfig(ctx context.Context, ptpConfigList *
 						Profile:  profile.Name,
 					})
 				}
+				authStatus = append(authStatus, getNodeAuthenticationStatus(node.Name, nodePtpProfiles)...)
+				haStatus = append(haStatus, getNodeHighAvailabilityStatus(node.Name, &ptpConfig, nodePtpProfiles,
+					nodeProfiles[node.Name], haStates[node.Name])...)
 			}
 		}
 
+		// Validate the security association files referenced by the profiles
+		conditionsChanged := setOrRemoveStatusCondition(&ptpConfig.Status.Conditions, securityAssociationConditionType,
+			securityAssociationCondition(&ptpConfig, namespaceSecrets[ptpConfig.Namespace]))
+		if setOrRemoveStatusCondition(&ptpConfig.Status.Conditions, namespaceAllowedConditionType,
+			namespaceAllowedCondition(&ptpConfig, policies)) {
+			conditionsChanged = true
+		}
+		if setOrRemoveStatusCondition(&ptpConfig.Status.Conditions, profilesRenderedConditionType,
+			profilesRenderedCondition(&ptpConfig)) {
+			conditionsChanged = true
+		}
+		if setOrRemoveStatusCondition(&ptpConfig.Status.Conditions, highAvailabilityConditionType,
+			highAvailabilityCondition(haStatus)) {
+			conditionsChanged = true
+		}
+
 		// Update PTP config status if it has changed
-		if !reflect.DeepEqual(ptpConfig.Status.MatchList, matchList) {
+		if conditionsChanged || !reflect.DeepEqual(ptpConfig.Status.MatchList, matchList) || !reflect.DeepEqual(ptpConfig.Status.Authentication, authStatus) ||
+			!reflect.DeepEqual(ptpConfig.Status.HighAvailability, haStatus) {
 			ptpConfig.Status.MatchList = matchList
+			ptpConfig.Status.Authentication = authStatus
+			ptpConfig.Status.HighAvailability = haStatus
 			err = r.Status().Update(ctx, &ptpConfig)
 			if err != nil {
 				glog.Errorf("failed to update PTP config status for %s: %v", ptpConfig.Name, err)
@@ -134,13 +210,11 @@ func (r *PtpConfigReconciler) syncPtpConfig(ctx context.Context, ptpConfigList *
 		}
 	}
 
+	if nodeProfilesErr != nil {
+		return nodeProfilesErr
+	}
 	for _, node := range nodeList.Items {
-		nodePtpProfiles, err := getRecommendNodePtpProfiles(ptpConfigList, node)
-		if err != nil {
-			return fmt.Errorf("failed to get recommended node PtpConfig: %v", err)
-		}
-
-		data, err := json.Marshal(nodePtpProfiles)
+		data, err := json.Marshal(nodeProfiles[node.Name])
 		if err != nil {
 			return fmt.Errorf("failed to Marshal nodePtpProfiles: %v", err)
 		}
@@ -244,9 +318,16 @@ func getRecommendProfilesNamesForConfig(ptpConfig *ptpv1.PtpConfig, node corev1.
 
 func (r *PtpConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&ptpv1.PtpConfig{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
-			return object.GetNamespace() == names.Namespace
-		}))).
+		// PtpConfigs of every namespace are watched, the ones of the namespaces that are not allowed are reported
+		For(&ptpv1.PtpConfig{}).
+		Watches(
+			&ptpv1.PtpOperatorConfig{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+				// The Reconcile loop processes all PtpConfigs, a single request applies the namespace policies
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: names.Namespace, Name: object.GetName()}}}
+			}),
+			builder.WithPredicates(namespacePoliciesPredicate()),
+		).
 		Watches(
 			&corev1.Secret{},
 			&secretEventHandler{client: mgr.GetClient()},
@@ -255,6 +336,22 @@ func (r *PtpConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				return object.GetNamespace() == names.Namespace
 			})),
 		).
+		Watches(
+			&ptpv1.NodePtpDevice{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+				// The Reconcile loop processes all PtpConfigs, a single request reports the selected sources
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: names.Namespace, Name: object.GetName()}}}
+			}),
+			builder.WithPredicates(highAvailabilityStatePredicate()),
+		).
+		Watches(
+			&appsv1.DaemonSet{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
+				// The Reconcile loop processes all PtpConfigs, a single request mounts the secrets of the new DaemonSet
+				return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: names.Namespace, Name: object.GetName()}}}
+			}),
+			builder.WithPredicates(linuxptpDaemonSetPredicate()),
+		).
 		Complete(r)
 }
 
@@ -267,20 +364,26 @@ type secretEventHandler struct {
 func (h *secretEventHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
 	secret := evt.Object.(*corev1.Secret)
 	glog.Infof("Secret created: %s", secret.Name)
-	h.enqueuePtpConfigReconcile(ctx, secret.Name, q)
+	h.enqueuePtpConfigReconcile(ctx, secret, q)
 }
 
-// Update handles Secret update events (we don't need to reconcile on updates)
+// Update handles Secret update events. The daemon picks up the new content with fsnotify,
+// the reconciliation validates the security association files and applies key rotation policy changes.
 func (h *secretEventHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
-	// Secret content updates are handled by linuxptp-daemon fsnotify
-	// No need to trigger reconciliation here
+	oldSecret := evt.ObjectOld.(*corev1.Secret)
+	newSecret := evt.ObjectNew.(*corev1.Secret)
+	if reflect.DeepEqual(oldSecret.Data, newSecret.Data) && !keyRotationPolicyChanged(oldSecret, newSecret) {
+		return
+	}
+	glog.Infof("Secret updated: %s", newSecret.Name)
+	h.enqueuePtpConfigReconcile(ctx, newSecret, q)
 }
 
 // Delete handles Secret deletion events
 func (h *secretEventHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
 	secret := evt.Object.(*corev1.Secret)
 	glog.Infof("Secret deleted: %s", secret.Name)
-	h.enqueuePtpConfigReconcile(ctx, secret.Name, q)
+	h.enqueuePtpConfigReconcile(ctx, secret, q)
 }
 
 // Generic handles generic events
@@ -288,9 +391,17 @@ func (h *secretEventHandler) Generic(ctx context.Context, evt event.GenericEvent
 	// Not needed for our use case
 }
 
-// enqueuePtpConfigReconcile enqueues a single reconciliation request if the secret is referenced
+// enqueuePtpConfigReconcile enqueues a single reconciliation request if the secret is referenced or mirrored
 // Note: The Reconcile function processes ALL PtpConfigs, so we only need to trigger it once
-func (h *secretEventHandler) enqueuePtpConfigReconcile(ctx context.Context, secretName string, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+func (h *secretEventHandler) enqueuePtpConfigReconcile(ctx context.Context, secret *corev1.Secret, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	secretName := secret.Name
+	if secret.Labels[mirroredSecretLabel] == mirroredSecretLabelTrue {
+		// The DaemonSet secret volumes are synced with the mirrored secrets
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: names.Namespace, Name: secretName}})
+		glog.Infof("Mirrored secret '%s' changed, triggering reconciliation", secretName)
+		return
+	}
+
 	ptpConfigs := &ptpv1.PtpConfigList{}
 	if err := h.client.List(ctx, ptpConfigs, &client.ListOptions{Namespace: names.Namespace}); err != nil {
 		glog.Errorf("Failed to list PtpConfigs for secret event: %v", err)
@@ -300,18 +411,8 @@ func (h *secretEventHandler) enqueuePtpConfigReconcile(ctx context.Context, secr
 	// Check if any PtpConfig references this secret
 	for _, cfg := range ptpConfigs.Items {
 		for _, profile := range cfg.Spec.Profile {
-			if profile.Ptp4lConf == nil {
-				continue
-			}
-
-			// Parse ptp4lConf to get sa_file
-			conf := &ptpv1.Ptp4lConf{}
-			if err := conf.PopulatePtp4lConf(profile.Ptp4lConf, profile.Ptp4lOpts); err != nil {
-				continue
-			}
-
-			// Get sa_file from [global] section
-			saFilePath := conf.GetOption("[global]", "sa_file")
+			// Get sa_file from the authentication block or the [global] section
+			saFilePath := ptpv1.ProfileSaFilePath(&profile)
 			if saFilePath == "" {
 				continue
 			}
@@ -337,126 +438,51 @@ func (h *secretEventHandler) enqueuePtpConfigReconcile(ctx context.Context, secr
 	glog.Infof("Secret '%s' not referenced by any PtpConfig, skipping reconciliation", secretName)
 }
 
-// secretMount represents a secret and sa_file pair for a profile
-type secretMount struct {
-	secretName string
-	saFilePath string
-	secretKey  string // The actual key name in the secret
-}
-
-// syncLinuxptpDaemonSecrets updates the linuxptp-daemon DaemonSet with secret volume mounts
-func (r *PtpConfigReconciler) syncLinuxptpDaemonSecrets(ctx context.Context, ptpConfigList *ptpv1.PtpConfigList) error {
-	// 1. Collect {secretName, sa_file} pairs from each profile
-	var mounts []secretMount
-
-	glog.Info("Scanning PtpConfigs for {secretName, sa_file} pairs...")
-	for _, cfg := range ptpConfigList.Items {
-		for _, profile := range cfg.Spec.Profile {
-			profileName := "unknown"
-			if profile.Name != nil {
-				profileName = *profile.Name
-			}
-
-			if profile.Ptp4lConf == nil {
-				continue
-			}
-			// Parse ptp4lConf to get sa_file
-			conf := &ptpv1.Ptp4lConf{}
-			if err := conf.PopulatePtp4lConf(profile.Ptp4lConf, profile.Ptp4lOpts); err != nil {
-				glog.Warningf("Failed to parse ptp4lConf for profile %s: %v", profileName, err)
-				continue
-			}
-
-			// Get sa_file from [global] section
-			saFilePath := conf.GetOption("[global]", "sa_file")
-			if saFilePath == "" {
-				continue
-			}
-			secretName := ptpv1.GetSecretNameFromSaFilePath(saFilePath)
-			glog.Infof("Found {secret, sa_file} pair in PtpConfig %s, profile %s: {%s, %s}",
-				cfg.Name, profileName, secretName, saFilePath)
-
-			// extract the secret key from the sa_file path
-			secretKey := ptpv1.GetSecretKeyFromSaFilePath(saFilePath)
-
-			mounts = append(mounts, secretMount{
-				secretName: secretName,
-				saFilePath: saFilePath,
-				secretKey:  secretKey,
-			})
-		}
+// syncLinuxptpDaemonSecrets mounts the secret keys referenced by the profiles recommended to the nodes of each daemon
+// pool in the DaemonSet of the pool, and removes the security volumes of the DaemonSets whose nodes need no secret
+func (r *PtpConfigReconciler) syncLinuxptpDaemonSecrets(ctx context.Context, ptpConfigList *ptpv1.PtpConfigList, nodeList *corev1.NodeList, policies namespacePolicies) error {
+	// 1. Collect the existing secret keys referenced by the profiles of each daemon pool
+	cfg, err := getOperatorConfig(ctx, r.Client)
+	if err != nil {
+		return err
 	}
-
-	uniqueSecrets := make(map[string]secretMount)
-	for _, mount := range mounts {
-		if _, exists := uniqueSecrets[mount.secretName]; !exists {
-			uniqueSecrets[mount.secretName] = mount
-			glog.Infof("Adding unique secret '%s'", mount.secretName)
-		} else {
-			glog.Infof("Skipping duplicate secret '%s'", mount.secretName)
-		}
+	secrets, err := r.getSecrets(ctx, names.Namespace)
+	if err != nil {
+		return err
 	}
-
-	glog.Infof("Found %d unique secret(s) to mount", len(uniqueSecrets))
-
-	// 2. Filter out secrets that don't exist in the cluster
-	existingSecrets := make(map[string]secretMount)
-	for secretName, mount := range uniqueSecrets {
-		secret := &corev1.Secret{}
-		err := r.Get(ctx, types.NamespacedName{
-			Namespace: names.Namespace,
-			Name:      secretName,
-		}, secret)
+	daemonSetSecrets := getDaemonSetSecrets(cfg, nodeList, getNodeSecrets(ptpConfigList, nodeList, secrets, policies))
+	glog.Infof("Found %d DaemonSet(s) with secrets to mount", len(daemonSetSecrets))
+
+	// 2. Update the security volume of the DaemonSet of each pool. A DaemonSet not created yet gets its volume when
+	// its creation triggers a reconciliation.
+	for _, pool := range getDaemonPools(cfg) {
+		daemonSet := &appsv1.DaemonSet{}
+		err = r.Get(ctx, types.NamespacedName{Namespace: names.Namespace, Name: pool.daemonSetName()}, daemonSet)
+		if errors.IsNotFound(err) {
+			glog.Infof("DaemonSet %s not found yet, its secrets are mounted once it is created", pool.daemonSetName())
+			continue
+		}
 		if err != nil {
-			if errors.IsNotFound(err) {
-				glog.Warningf("Secret '%s' referenced in PtpConfig but not found in cluster - skipping mount", secretName)
-				continue
-			}
-			return fmt.Errorf("failed to check existence of secret '%s': %v", secretName, err)
+			return fmt.Errorf("failed to get DaemonSet %s: %v", pool.daemonSetName(), err)
 		}
-		glog.Infof("Secret '%s' exists in cluster, will mount", secretName)
-		existingSecrets[secretName] = mount
-	}
-
-	glog.Infof("Found %d existing secret(s) out of %d referenced", len(existingSecrets), len(uniqueSecrets))
-
-	// 3. Get the linuxptp-daemon DaemonSet
-	daemonSet := &appsv1.DaemonSet{}
-	err := r.Get(ctx, types.NamespacedName{
-		Namespace: names.Namespace,
-		Name:      "linuxptp-daemon",
-	}, daemonSet)
-	if err != nil {
-		if errors.IsNotFound(err) {
-			glog.Info("linuxptp-daemon DaemonSet not found yet - will retry in 10 seconds")
-			return fmt.Errorf("DaemonSet not found, will retry")
+		updatedDaemonSet := daemonSet.DeepCopy()
+		setAuthSecretsVolume(updatedDaemonSet, daemonSetSecrets[daemonSet.Name])
+		if equality.Semantic.DeepEqual(daemonSet.Spec.Template.Spec, updatedDaemonSet.Spec.Template.Spec) {
+			continue
 		}
-		return fmt.Errorf("failed to get linuxptp-daemon DaemonSet: %v", err)
-	}
-	// 4. Remove all old security volumes first
-	removeSecurityVolumesFromDaemonSet(daemonSet)
-
-	// 5. Add volumes - iterate over existing secrets only
-	for _, mount := range existingSecrets {
-		glog.Infof("Injecting security volume for secret '%s'", mount.secretName)
-		injectPtpSecurityVolume(daemonSet, mount.secretName)
-	}
-	// 6. Convert to Unstructured and apply with merge (like PtpOperatorConfig does)
-	scheme := kscheme.Scheme
-	updated := &uns.Unstructured{}
-	if err := scheme.Convert(daemonSet, updated, nil); err != nil {
-		return fmt.Errorf("failed to convert DaemonSet to Unstructured: %v", err)
-	}
 
-	// 7. Use apply.ApplyObject which will call MergeObjectForUpdate
-	// Set context to indicate this update is from PtpConfig controller
-	// This allows the merge logic to use security volumes from updated (even if empty)
-	ctxWithSource := context.WithValue(ctx, apply.ControllerSourceKey, apply.SourcePtpConfig)
-	if err := apply.ApplyObject(ctxWithSource, r.Client, updated); err != nil {
-		return fmt.Errorf("failed to apply DaemonSet: %v", err)
+		// 3. Convert to Unstructured and apply with merge (like PtpOperatorConfig does)
+		updated := &uns.Unstructured{}
+		if err := kscheme.Scheme.Convert(updatedDaemonSet, updated, nil); err != nil {
+			return fmt.Errorf("failed to convert DaemonSet to Unstructured: %v", err)
+		}
+		// The PtpConfig source makes the merge logic use the security volumes of the updated DaemonSet
+		ctxWithSource := context.WithValue(ctx, apply.ControllerSourceKey, apply.SourcePtpConfig)
+		if err := apply.ApplyObject(ctxWithSource, r.Client, updated); err != nil {
+			return fmt.Errorf("failed to apply DaemonSet %s: %v", daemonSet.Name, err)
+		}
+		glog.Infof("Updated the secrets mounted by DaemonSet %s to %v", daemonSet.Name, daemonSetSecrets[daemonSet.Name])
 	}
-
-	glog.Info("Successfully updated linuxptp-daemon DaemonSet with security mounts")
 	return nil
 }
 
@@ -477,7 +503,7 @@ func removeSecurityVolumesFromDaemonSet(ds *appsv1.DaemonSet) {
 
 	// Remove security volume mounts from linuxptp-daemon-container
 	for i := range ds.Spec.Template.Spec.Containers {
-		if ds.Spec.Template.Spec.Containers[i].Name == "linuxptp-daemon-container" {
+		if ds.Spec.Template.Spec.Containers[i].Name == linuxptpDaemonContainer {
 			var filteredMounts []corev1.VolumeMount
 			for _, mount := range ds.Spec.Template.Spec.Containers[i].VolumeMounts {
 				// Skip mounts ending with "-tlv-auth" (9 characters)
@@ -492,36 +518,3 @@ func removeSecurityVolumesFromDaemonSet(ds *appsv1.DaemonSet) {
 		}
 	}
 }
-
-// injectPtpSecurityVolume adds a single secret volume and mount to the DaemonSet
-func injectPtpSecurityVolume(ds *appsv1.DaemonSet, secretName string) {
-	volumeName := secretName + TLV_AUTH_SUFFIX
-	mountDir := PTP_SEC_FOLDER + secretName
-
-	// Add volume - mount ENTIRE secret (all keys become files)
-	ds.Spec.Template.Spec.Volumes = append(ds.Spec.Template.Spec.Volumes, corev1.Volume{
-		Name: volumeName,
-		VolumeSource: corev1.VolumeSource{
-			Secret: &corev1.SecretVolumeSource{
-				SecretName: secretName,
-			},
-		},
-	})
-
-	// Add volume mount
-	for i := range ds.Spec.Template.Spec.Containers {
-		if ds.Spec.Template.Spec.Containers[i].Name == "linuxptp-daemon-container" {
-			ds.Spec.Template.Spec.Containers[i].VolumeMounts = append(
-				ds.Spec.Template.Spec.Containers[i].VolumeMounts,
-				corev1.VolumeMount{
-					Name:      volumeName,
-					MountPath: mountDir,
-					ReadOnly:  true,
-				},
-			)
-			break
-		}
-	}
-
-	glog.Infof("Mounted secret '%s' to %s", secretName, mountDir)
-}
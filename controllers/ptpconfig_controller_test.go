@@ -109,39 +109,24 @@ func makeDaemonSet() *appsv1.DaemonSet {
 	}
 }
 
-func TestInjectPtpSecurityVolume(t *testing.T) {
-	ds := makeDaemonSet()
-	injectPtpSecurityVolume(ds, "my-secret")
-
-	assert.Len(t, ds.Spec.Template.Spec.Volumes, 2)
-	vol := ds.Spec.Template.Spec.Volumes[1]
-	assert.Equal(t, "my-secret-tlv-auth", vol.Name)
-	assert.Equal(t, "my-secret", vol.VolumeSource.Secret.SecretName)
-
-	mounts := ds.Spec.Template.Spec.Containers[0].VolumeMounts
-	assert.Len(t, mounts, 2)
-	assert.Equal(t, "my-secret-tlv-auth", mounts[1].Name)
-	assert.Equal(t, "/etc/ptp-secret-mount/my-secret", mounts[1].MountPath)
-	assert.True(t, mounts[1].ReadOnly)
-}
-
-func TestInjectPtpSecurityVolume_NoMatchingContainer(t *testing.T) {
-	ds := makeDaemonSet()
-	ds.Spec.Template.Spec.Containers[0].Name = "other-container"
-	injectPtpSecurityVolume(ds, "my-secret")
-
-	assert.Len(t, ds.Spec.Template.Spec.Volumes, 2, "volume should still be added")
-	assert.Len(t, ds.Spec.Template.Spec.Containers[0].VolumeMounts, 1,
-		"mount should not be added to non-matching container")
+// addLegacySecurityVolume adds a per-secret volume and mount as injected by previous versions
+func addLegacySecurityVolume(ds *appsv1.DaemonSet) {
+	ds.Spec.Template.Spec.Volumes = append(ds.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: "ptp-security" + TLV_AUTH_SUFFIX,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: "ptp-security"},
+		},
+	})
+	ds.Spec.Template.Spec.Containers[0].VolumeMounts = append(ds.Spec.Template.Spec.Containers[0].VolumeMounts,
+		corev1.VolumeMount{Name: "ptp-security" + TLV_AUTH_SUFFIX, MountPath: "/etc/ptp-secret-mount/ptp-security"})
 }
 
 func TestRemoveSecurityVolumesFromDaemonSet(t *testing.T) {
 	ds := makeDaemonSet()
-	injectPtpSecurityVolume(ds, "secret-a")
-	injectPtpSecurityVolume(ds, "secret-b")
+	addLegacySecurityVolume(ds)
 
-	assert.Len(t, ds.Spec.Template.Spec.Volumes, 3)
-	assert.Len(t, ds.Spec.Template.Spec.Containers[0].VolumeMounts, 3)
+	assert.Len(t, ds.Spec.Template.Spec.Volumes, 2)
+	assert.Len(t, ds.Spec.Template.Spec.Containers[0].VolumeMounts, 2)
 
 	removeSecurityVolumesFromDaemonSet(ds)
 
@@ -163,12 +148,12 @@ func TestRemoveSecurityVolumesFromDaemonSet_NonePresent(t *testing.T) {
 func TestRemoveSecurityVolumesFromDaemonSet_NoMatchingContainer(t *testing.T) {
 	ds := makeDaemonSet()
 	ds.Spec.Template.Spec.Containers[0].Name = "other-container"
-	injectPtpSecurityVolume(ds, "secret-a")
+	addLegacySecurityVolume(ds)
 
 	removeSecurityVolumesFromDaemonSet(ds)
 
 	assert.Len(t, ds.Spec.Template.Spec.Volumes, 1)
-	assert.Len(t, ds.Spec.Template.Spec.Containers[0].VolumeMounts, 1,
+	assert.Len(t, ds.Spec.Template.Spec.Containers[0].VolumeMounts, 2,
 		"non-matching container mounts should remain untouched")
 }
 
@@ -0,0 +1,305 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
+	ptpEvent "github.com/redhat-cne/sdk-go/pkg/event/ptp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// PtpEventSubscriptionReconciler reconciles a PtpEventSubscription object
+type PtpEventSubscriptionReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+
+	// publisher calls the event publishers, the O-RAN notification REST API by default
+	publisher eventPublisherClient
+}
+
+//+kubebuilder:rbac:groups=ptp.openshift.io,resources=ptpeventsubscriptions,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=ptp.openshift.io,resources=ptpeventsubscriptions/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=ptp.openshift.io,resources=ptpeventsubscriptions/finalizers,verbs=update
+
+const (
+	// eventSubscriptionFinalizer deletes the subscriptions from the event publishers before the PtpEventSubscription
+	eventSubscriptionFinalizer = "ptp.openshift.io/event-subscriptions"
+
+	eventSubscriptionReadyConditionType = "Ready"
+
+	reasonNodesSubscribed    = "NodesSubscribed"
+	reasonNodesNotSubscribed = "NodesNotSubscribed"
+	reasonNoEventPublishers  = "NoEventPublishers"
+)
+
+// eventResourcePaths are the O-RAN resource paths of the event resources, relative to the node resource address
+var eventResourcePaths = map[ptpv1.PtpEventResource]ptpEvent.EventResource{
+	ptpv1.PtpEventResourceSyncState:        ptpEvent.SyncStatusState,
+	ptpv1.PtpEventResourceOsClockSyncState: ptpEvent.OsClockSyncState,
+	ptpv1.PtpEventResourceLockState:        ptpEvent.PtpLockState,
+	ptpv1.PtpEventResourceClockClass:       ptpEvent.PtpClockClass,
+	ptpv1.PtpEventResourceGnssStatus:       ptpEvent.GnssSyncStatus,
+}
+
+// eventResourceAddress returns the O-RAN resource address of an event resource of a node
+func eventResourceAddress(node string, resource ptpv1.PtpEventResource) string {
+	return "/cluster/node/" + node + string(eventResourcePaths[resource])
+}
+
+func (r *PtpEventSubscriptionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconcile.Result, error) {
+	reqLogger := r.Log.WithValues("Request.Namespace", req.Namespace, "Request.Name", req.Name)
+	reqLogger.Info("Reconciling PtpEventSubscription")
+
+	subscription := &ptpv1.PtpEventSubscription{}
+	err := r.Get(ctx, req.NamespacedName, subscription)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	defaultCfg, err := getOperatorConfig(ctx, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	policies := newNamespacePolicies(defaultCfg)
+	publishers, err := r.getEventPublishers(ctx, defaultCfg)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !subscription.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(subscription, eventSubscriptionFinalizer) {
+			return reconcile.Result{}, nil
+		}
+		// the subscriptions are deleted on a best effort basis: the subscriptions of a node whose event publisher
+		// is unreachable must not block the deletion
+		for _, node := range subscription.Status.Nodes {
+			if problem := r.deleteNodeSubscriptions(ctx, publishers, node.Node, node.Subscriptions); problem != "" {
+				reqLogger.Info("Failed to delete event subscriptions", "node", node.Node, "error", problem)
+			}
+		}
+		controllerutil.RemoveFinalizer(subscription, eventSubscriptionFinalizer)
+		return reconcile.Result{}, r.Update(ctx, subscription)
+	}
+
+	if controllerutil.AddFinalizer(subscription, eventSubscriptionFinalizer) {
+		if err = r.Update(ctx, subscription); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to add finalizer to PtpEventSubscription: %v", err)
+		}
+	}
+
+	status := subscription.Status.DeepCopy()
+	previous := make(map[string][]ptpv1.EventResourceSubscription, len(status.Nodes))
+	for _, node := range status.Nodes {
+		previous[node.Node] = node.Subscriptions
+	}
+
+	nodes, err := r.getSubscriptionNodes(ctx, subscription, publishers, policies)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	status.Nodes = nil
+	for _, node := range nodes {
+		status.Nodes = append(status.Nodes,
+			syncNodeSubscriptions(ctx, r.publisher, publishers[node], node, &subscription.Spec, previous[node]))
+		delete(previous, node)
+	}
+	// nodes no longer selected keep their subscriptions in the status until they are deleted, unless their event
+	// publisher is gone with its subscriptions
+	for _, node := range subscription.Status.Nodes {
+		if _, ok := previous[node.Node]; !ok {
+			continue
+		}
+		if _, ok := publishers[node.Node]; !ok {
+			continue
+		}
+		if problem := r.deleteNodeSubscriptions(ctx, publishers, node.Node, node.Subscriptions); problem != "" {
+			node.State = ptpv1.EventSubscriptionFailed
+			node.Message = "node is no longer selected: " + problem
+			status.Nodes = append(status.Nodes, node)
+		}
+	}
+	setOrRemoveStatusCondition(&status.Conditions, namespaceAllowedConditionType,
+		subscriptionNamespaceCondition(subscription, policies))
+	setOrRemoveStatusCondition(&status.Conditions, eventSubscriptionReadyConditionType,
+		eventSubscriptionReadyCondition(status.Nodes))
+
+	if !equality.Semantic.DeepEqual(status, &subscription.Status) {
+		subscription.Status = *status
+		if err = r.Status().Update(ctx, subscription); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to update PtpEventSubscription status: %v", err)
+		}
+	}
+
+	// the subscriptions of an event publisher are lost when it restarts with non persistent storage
+	return reconcile.Result{RequeueAfter: ResyncPeriod}, nil
+}
+
+// getEventPublishers returns the URL of the O-RAN notification API of each node running an event publisher
+func (r *PtpEventSubscriptionReconciler) getEventPublishers(ctx context.Context, defaultCfg *ptpv1.PtpOperatorConfig) (map[string]string, error) {
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
+	}
+	serviceNames := eventPublisherServiceNames(getEventPublisherNodes(defaultCfg, getDaemonPools(defaultCfg), nodeList))
+	publishers := make(map[string]string, len(serviceNames))
+	for node, serviceName := range serviceNames {
+		publishers[node] = eventPublisherURL(names.Namespace, serviceName)
+	}
+	return publishers, nil
+}
+
+// getSubscriptionNodes returns the sorted names of the nodes running an event publisher selected by the
+// PtpEventSubscription, among the nodes the namespace policy of the PtpEventSubscription allows
+func (r *PtpEventSubscriptionReconciler) getSubscriptionNodes(ctx context.Context, subscription *ptpv1.PtpEventSubscription,
+	publishers map[string]string, policies namespacePolicies) ([]string, error) {
+	if len(publishers) == 0 || !policies.allowed(subscription.Namespace) {
+		return nil, nil
+	}
+	nodeList := &corev1.NodeList{}
+	err := r.List(ctx, nodeList, client.MatchingLabelsSelector{Selector: labels.SelectorFromSet(subscription.Spec.NodeSelector)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
+	}
+	var nodes []string
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		if _, ok := publishers[node.Name]; ok && policies.targetsNode(subscription.Namespace, node) {
+			nodes = append(nodes, node.Name)
+		}
+	}
+	slices.Sort(nodes)
+	return nodes, nil
+}
+
+// deleteNodeSubscriptions deletes subscriptions from the event publisher of a node. It returns an empty string when
+// they are deleted, the problem found otherwise.
+func (r *PtpEventSubscriptionReconciler) deleteNodeSubscriptions(ctx context.Context, publishers map[string]string,
+	node string, subscriptions []ptpv1.EventResourceSubscription) string {
+	publisherURL, ok := publishers[node]
+	if !ok {
+		return ""
+	}
+	var problems []string
+	for _, sub := range subscriptions {
+		if sub.SubscriptionID == "" {
+			continue
+		}
+		if err := r.publisher.DeleteSubscription(ctx, publisherURL, sub.SubscriptionID); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	return strings.Join(problems, "; ")
+}
+
+// syncNodeSubscriptions subscribes to the resources of the spec in the event publisher of the node, reusing the
+// matching subscriptions of the publisher, and deletes the previous subscriptions no longer needed
+func syncNodeSubscriptions(ctx context.Context, publisher eventPublisherClient, publisherURL string, node string,
+	spec *ptpv1.PtpEventSubscriptionSpec, previous []ptpv1.EventResourceSubscription) ptpv1.NodeEventSubscription {
+	status := ptpv1.NodeEventSubscription{Node: node, State: ptpv1.EventSubscriptionSubscribed}
+	existing, err := publisher.ListSubscriptions(ctx, publisherURL)
+	if err != nil {
+		status.State = ptpv1.EventSubscriptionFailed
+		status.Message = fmt.Sprintf("failed to list the subscriptions of the event publisher: %v", err)
+		status.Subscriptions = previous
+		return status
+	}
+
+	var problems []string
+	for _, resource := range spec.Resources {
+		sub := ptpv1.EventResourceSubscription{Resource: resource, ResourceAddress: eventResourceAddress(node, resource)}
+		for _, e := range existing {
+			if e.ResourceAddress == sub.ResourceAddress && e.EndpointURI == spec.EndpointURI {
+				sub.SubscriptionID = e.ID
+				break
+			}
+		}
+		if sub.SubscriptionID == "" {
+			created, err := publisher.CreateSubscription(ctx, publisherURL,
+				eventSubscription{EndpointURI: spec.EndpointURI, ResourceAddress: sub.ResourceAddress})
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("failed to subscribe to %s: %v", resource, err))
+			}
+			sub.SubscriptionID = created.ID
+		}
+		status.Subscriptions = append(status.Subscriptions, sub)
+	}
+
+	for _, sub := range previous {
+		if sub.SubscriptionID == "" || slices.ContainsFunc(status.Subscriptions, func(s ptpv1.EventResourceSubscription) bool {
+			return s.SubscriptionID == sub.SubscriptionID
+		}) {
+			continue
+		}
+		if err = publisher.DeleteSubscription(ctx, publisherURL, sub.SubscriptionID); err != nil {
+			problems = append(problems, fmt.Sprintf("failed to delete subscription %s to %s: %v", sub.SubscriptionID, sub.Resource, err))
+			// keep the subscription to retry the deletion
+			status.Subscriptions = append(status.Subscriptions, sub)
+		}
+	}
+
+	if len(problems) > 0 {
+		status.State = ptpv1.EventSubscriptionFailed
+		status.Message = strings.Join(problems, "; ")
+	}
+	return status
+}
+
+// eventSubscriptionReadyCondition reports whether every selected node is subscribed
+func eventSubscriptionReadyCondition(nodes []ptpv1.NodeEventSubscription) *metav1.Condition {
+	if len(nodes) == 0 {
+		return &metav1.Condition{
+			Type:    eventSubscriptionReadyConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  reasonNoEventPublishers,
+			Message: "no selected node runs an event publisher",
+		}
+	}
+	var failed []string
+	for _, node := range nodes {
+		if node.State != ptpv1.EventSubscriptionSubscribed {
+			failed = append(failed, node.Node)
+		}
+	}
+	if len(failed) > 0 {
+		return &metav1.Condition{
+			Type:   eventSubscriptionReadyConditionType,
+			Status: metav1.ConditionFalse,
+			Reason: reasonNodesNotSubscribed,
+			Message: fmt.Sprintf("%d of %d nodes are not subscribed: %s",
+				len(failed), len(nodes), strings.Join(failed, ", ")),
+		}
+	}
+	return &metav1.Condition{
+		Type:    eventSubscriptionReadyConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonNodesSubscribed,
+		Message: fmt.Sprintf("%d nodes are subscribed", len(nodes)),
+	}
+}
+
+func (r *PtpEventSubscriptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.publisher == nil {
+		r.publisher = newHTTPEventPublisherClient()
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ptpv1.PtpEventSubscription{}).
+		Complete(r)
+}
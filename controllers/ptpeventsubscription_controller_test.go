@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+)
+
+// fakeEventPublisher is an event publisher holding its subscriptions in memory
+type fakeEventPublisher struct {
+	subscriptions []eventSubscription
+	nextID        int
+	err           error
+	rejected      string
+}
+
+func (p *fakeEventPublisher) ListSubscriptions(_ context.Context, _ string) ([]eventSubscription, error) {
+	return p.subscriptions, p.err
+}
+
+func (p *fakeEventPublisher) CreateSubscription(_ context.Context, _ string, sub eventSubscription) (eventSubscription, error) {
+	if sub.ResourceAddress == p.rejected {
+		return eventSubscription{}, errors.New("resource not found")
+	}
+	p.nextID++
+	sub.ID = fmt.Sprintf("id-%d", p.nextID)
+	p.subscriptions = append(p.subscriptions, sub)
+	return sub, nil
+}
+
+func (p *fakeEventPublisher) DeleteSubscription(_ context.Context, _ string, id string) error {
+	for i := range p.subscriptions {
+		if p.subscriptions[i].ID == id {
+			p.subscriptions = append(p.subscriptions[:i], p.subscriptions[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func TestSyncNodeSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	publisher := &fakeEventPublisher{subscriptions: []eventSubscription{{
+		ID:              "existing",
+		EndpointURI:     "http://consumer:9043/event",
+		ResourceAddress: "/cluster/node/worker-0/sync/ptp-status/lock-state",
+	}}}
+	spec := &ptpv1.PtpEventSubscriptionSpec{
+		EndpointURI: "http://consumer:9043/event",
+		Resources:   []ptpv1.PtpEventResource{ptpv1.PtpEventResourceLockState, ptpv1.PtpEventResourceClockClass},
+	}
+
+	// the matching subscription of the publisher is reused
+	status := syncNodeSubscriptions(ctx, publisher, "", "worker-0", spec, nil)
+	assert.Equal(t, ptpv1.EventSubscriptionSubscribed, status.State)
+	assert.Equal(t, []ptpv1.EventResourceSubscription{
+		{Resource: ptpv1.PtpEventResourceLockState,
+			ResourceAddress: "/cluster/node/worker-0/sync/ptp-status/lock-state", SubscriptionID: "existing"},
+		{Resource: ptpv1.PtpEventResourceClockClass,
+			ResourceAddress: "/cluster/node/worker-0/sync/ptp-status/clock-class", SubscriptionID: "id-1"},
+	}, status.Subscriptions)
+	assert.Len(t, publisher.subscriptions, 2)
+
+	// the subscriptions lost by a publisher restart are created again
+	publisher.subscriptions = nil
+	status = syncNodeSubscriptions(ctx, publisher, "", "worker-0", spec, status.Subscriptions)
+	assert.Equal(t, ptpv1.EventSubscriptionSubscribed, status.State)
+	assert.Equal(t, "id-2", status.Subscriptions[0].SubscriptionID)
+	assert.Equal(t, "id-3", status.Subscriptions[1].SubscriptionID)
+
+	// removed resources and subscriptions of another endpoint are deleted
+	spec = &ptpv1.PtpEventSubscriptionSpec{
+		EndpointURI: "http://consumer-2:9043/event",
+		Resources:   []ptpv1.PtpEventResource{ptpv1.PtpEventResourceClockClass},
+	}
+	status = syncNodeSubscriptions(ctx, publisher, "", "worker-0", spec, status.Subscriptions)
+	assert.Equal(t, ptpv1.EventSubscriptionSubscribed, status.State)
+	assert.Equal(t, []eventSubscription{{
+		ID:              "id-4",
+		EndpointURI:     "http://consumer-2:9043/event",
+		ResourceAddress: "/cluster/node/worker-0/sync/ptp-status/clock-class",
+	}}, publisher.subscriptions)
+
+	// a rejected subscription fails the node
+	spec.Resources = append(spec.Resources, ptpv1.PtpEventResourceGnssStatus)
+	publisher.rejected = "/cluster/node/worker-0/sync/gnss-status/gnss-sync-status"
+	status = syncNodeSubscriptions(ctx, publisher, "", "worker-0", spec, status.Subscriptions)
+	assert.Equal(t, ptpv1.EventSubscriptionFailed, status.State)
+	assert.Equal(t, "failed to subscribe to gnss-status: resource not found", status.Message)
+
+	// an unreachable publisher keeps the previous subscriptions
+	publisher.err = errors.New("connection refused")
+	previous := status.Subscriptions
+	status = syncNodeSubscriptions(ctx, publisher, "", "worker-0", spec, previous)
+	assert.Equal(t, ptpv1.EventSubscriptionFailed, status.State)
+	assert.Contains(t, status.Message, "connection refused")
+	assert.Equal(t, previous, status.Subscriptions)
+}
+
+func TestEventSubscriptionReadyCondition(t *testing.T) {
+	cond := eventSubscriptionReadyCondition(nil)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, reasonNoEventPublishers, cond.Reason)
+
+	nodes := []ptpv1.NodeEventSubscription{
+		{Node: "worker-0", State: ptpv1.EventSubscriptionSubscribed},
+		{Node: "worker-1", State: ptpv1.EventSubscriptionFailed},
+	}
+	cond = eventSubscriptionReadyCondition(nodes)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, reasonNodesNotSubscribed, cond.Reason)
+	assert.Equal(t, "1 of 2 nodes are not subscribed: worker-1", cond.Message)
+
+	nodes[1].State = ptpv1.EventSubscriptionSubscribed
+	cond = eventSubscriptionReadyCondition(nodes)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, reasonNodesSubscribed, cond.Reason)
+}
+
+func TestHTTPEventPublisherClient(t *testing.T) {
+	subscriptions := map[string]eventSubscription{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == eventPublisherAPIPath+"/subscriptions":
+			list := []eventSubscription{}
+			for _, sub := range subscriptions {
+				list = append(list, sub)
+			}
+			_ = json.NewEncoder(w).Encode(list)
+		case r.Method == http.MethodPost && r.URL.Path == eventPublisherAPIPath+"/subscriptions":
+			sub := eventSubscription{}
+			_ = json.NewDecoder(r.Body).Decode(&sub)
+			sub.ID = "id-1"
+			subscriptions[sub.ID] = sub
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(sub)
+		case r.Method == http.MethodDelete && r.URL.Path == eventPublisherAPIPath+"/subscriptions/id-1":
+			delete(subscriptions, "id-1")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	c := newHTTPEventPublisherClient()
+	publisherURL := server.URL + eventPublisherAPIPath
+	created, err := c.CreateSubscription(ctx, publisherURL,
+		eventSubscription{EndpointURI: "http://consumer:9043/event", ResourceAddress: "/cluster/node/worker-0/sync/ptp-status/lock-state"})
+	assert.NoError(t, err)
+	assert.Equal(t, "id-1", created.ID)
+
+	subs, err := c.ListSubscriptions(ctx, publisherURL)
+	assert.NoError(t, err)
+	assert.Equal(t, []eventSubscription{created}, subs)
+
+	assert.NoError(t, c.DeleteSubscription(ctx, publisherURL, "id-1"))
+	// deleting a subscription that no longer exists succeeds
+	assert.NoError(t, c.DeleteSubscription(ctx, publisherURL, "id-1"))
+
+	_, err = c.ListSubscriptions(ctx, server.URL+"/api/ocloudNotifications/v1")
+	assert.ErrorContains(t, err, "returned 404 Not Found: not found")
+
+	assert.Equal(t, "http://ptp-event-publisher-service-worker-0.openshift-ptp.svc:9043/api/ocloudNotifications/v2",
+		eventPublisherURL("openshift-ptp", "ptp-event-publisher-service-worker-0"))
+}
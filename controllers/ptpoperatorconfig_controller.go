@@ -20,10 +20,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/url"
 	"os"
 	"path/filepath"
-	"sort"
 	"strings"
 	"time"
 
@@ -38,6 +36,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -58,6 +57,8 @@ type PtpOperatorConfigReconciler struct {
 	// TLSProfileSpec is the cluster-wide TLS profile to apply to kube-rbac-proxy.
 	// When nil, legacy hardcoded cipher suites are used (pre-TLS adherence behavior).
 	TLSProfileSpec *configv1.TLSProfileSpec
+	// APIReader lists the linuxptp daemon pods, which are not cached
+	APIReader client.Reader
 }
 
 func DefaultTransportHost() string {
@@ -66,8 +67,8 @@ func DefaultTransportHost() string {
 
 const (
 	ResyncPeriod       = 2 * time.Minute
-	DefaultStorageType = "emptyDir"
-	DefaultApiVersion  = "2.0"
+	DefaultStorageType = string(ptpv1.DefaultEventStorageType)
+	DefaultApiVersion  = ptpv1.DefaultEventApiVersion
 )
 
 // +kubebuilder:rbac:groups=ptp.openshift.io,resources=ptpoperatorconfigs,verbs=get;list;watch;create;update;patch;delete
@@ -76,6 +77,7 @@ const (
 // +kubebuilder:rbac:groups=config.openshift.io,resources=infrastructures,verbs=get;list;watch
 // +kubebuilder:rbac:groups=config.openshift.io,resources=apiservers,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch;create;update;patch;delete
 
 func (r *PtpOperatorConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconcile.Result, error) {
 	reqLogger := r.Log.WithValues("Request.Namespace", req.Namespace, "Request.Name", req.Name)
@@ -106,6 +108,8 @@ func (r *PtpOperatorConfigReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return reconcile.Result{}, err
 	}
 
+	status := defaultCfg.Status.DeepCopy()
+
 	nodeList := &corev1.NodeList{}
 	err = r.List(ctx, nodeList, &client.ListOptions{})
 	if err != nil {
@@ -128,6 +132,11 @@ func (r *PtpOperatorConfigReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return reconcile.Result{}, err
 	}
 
+	if err = r.syncLeapSeconds(ctx, defaultCfg, nodeList); err != nil {
+		glog.Errorf("failed to sync leap seconds: %v", err)
+		return reconcile.Result{}, err
+	}
+
 	if err = r.applyNetworkPoliciesFromYaml(ctx, filepath.Join(names.ManifestDir, "linuxptp/network-policy.yaml"), defaultCfg); err != nil {
 		glog.Errorf("failed to apply NetworkPolicy %v", err)
 		return reconcile.Result{}, err
@@ -138,6 +147,13 @@ func (r *PtpOperatorConfigReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return reconcile.Result{}, err
 	}
 
+	if !equality.Semantic.DeepEqual(status, &defaultCfg.Status) {
+		if err = r.Status().Update(ctx, defaultCfg); err != nil {
+			glog.Errorf("failed to update PtpOperatorConfig status: %v", err)
+			return reconcile.Result{}, err
+		}
+	}
+
 	return reconcile.Result{RequeueAfter: ResyncPeriod}, nil
 }
 
@@ -222,11 +238,75 @@ func (r *PtpOperatorConfigReconciler) setDaemonNodeSelector(
 
 // syncLinuxptpDaemon synchronizes Linuxptp DaemonSet
 func (r *PtpOperatorConfigReconciler) syncLinuxptpDaemon(ctx context.Context, defaultCfg *ptpv1.PtpOperatorConfig, nodeList *corev1.NodeList) error {
-	var err error
-	objs := []*uns.Unstructured{}
+	// Set context to indicate this update is from PtpOperatorConfig controller
+	// This allows the merge logic to preserve security volumes from current DaemonSet
+	ctxWithSource := context.WithValue(ctx, apply.ControllerSourceKey, apply.SourcePtpOperatorConfig)
+
+	pools := getDaemonPools(defaultCfg)
+	pluginsHashes, err := r.syncNodePlugins(ctx, defaultCfg, pools, nodeList)
+	if err != nil {
+		return err
+	}
+
+	for i := range pools {
+		pool := &pools[i]
+		data, err := r.makeLinuxptpDaemonRenderData(pool)
+		if err != nil {
+			return err
+		}
+		data.Data["PluginsHash"] = pluginsHashes[pool.name]
+
+		objs, err := render.RenderTemplate(filepath.Join(names.ManifestDir, "linuxptp/ptp-daemon.yaml"), &data)
+		if err != nil {
+			return fmt.Errorf("failed to render linuxptp daemon manifest: %v", err)
+		}
+
+		for _, obj := range objs {
+			// the objects shared by the pools are applied with the linuxptp-daemon DaemonSet
+			if pool.name != "" && obj.GetKind() != "DaemonSet" {
+				continue
+			}
+
+			obj, err = r.setDaemonNodeSelector(defaultCfg, obj)
+			if err != nil {
+				return err
+			}
+
+			obj, err = r.setDaemonPool(defaultCfg, pool, obj)
+			if err != nil {
+				return err
+			}
+
+			obj, err = r.setDaemonSetConfig(pool.daemonSet, obj)
+			if err != nil {
+				return err
+			}
+
+			if err = controllerutil.SetControllerReference(defaultCfg, obj, r.Scheme); err != nil {
+				return fmt.Errorf("failed to set owner reference for daemon: %v", err)
+			}
+			if err = apply.ApplyObject(ctxWithSource, r.Client, obj); err != nil {
+				return fmt.Errorf("failed to apply object %v with err: %v", obj, err)
+			}
+		}
+	}
+
+	if err := r.deleteStaleDaemonPools(ctx, pools); err != nil {
+		return err
+	}
 
+	eventServices, err := r.syncEventPublisherServices(ctx, defaultCfg, pools, nodeList)
+	if err != nil {
+		return err
+	}
+
+	return r.syncEventTransportStatus(ctx, defaultCfg, pools, eventServices)
+}
+
+// makeLinuxptpDaemonRenderData returns the data the linuxptp daemon manifest of the pool is rendered with
+func (r *PtpOperatorConfigReconciler) makeLinuxptpDaemonRenderData(pool *daemonPool) (render.RenderData, error) {
 	data := render.MakeRenderData()
-	data.Data["Image"] = os.Getenv("LINUXPTP_DAEMON_IMAGE")
+	data.Data["Image"] = pool.image
 	data.Data["ImagePullPolicy"] = "IfNotPresent"
 	if overridePolicy, ok := os.LookupEnv("IMAGE_PULL_POLICY"); ok {
 		data.Data["ImagePullPolicy"] = overridePolicy
@@ -236,93 +316,41 @@ func (r *PtpOperatorConfigReconciler) syncLinuxptpDaemon(ctx context.Context, de
 	data.Data["KubeRbacProxy"] = os.Getenv("KUBE_RBAC_PROXY_IMAGE")
 	data.Data["SideCar"] = os.Getenv("SIDECAR_EVENT_IMAGE")
 	data.Data["NodeName"] = os.Getenv("NODE_NAME")
-	data.Data["StorageType"] = DefaultStorageType
 	data.Data["EventApiVersion"] = DefaultApiVersion
+	data.Data["LogLevel"] = daemonLogLevel(pool.daemonSet)
+	data.Data["DaemonSetName"] = pool.daemonSetName()
+	data.Data["DaemonPool"] = pool.name
 	// configure EventConfig
-	if defaultCfg.Spec.EventConfig == nil {
+	if pool.eventConfig == nil {
 		data.Data["EnableEventPublisher"] = false
 	} else {
-		data.Data["EnableEventPublisher"] = defaultCfg.Spec.EventConfig.EnableEventPublisher
-		data.Data["EventTransportHost"] = defaultCfg.Spec.EventConfig.TransportHost
+		data.Data["EnableEventPublisher"] = pool.eventConfig.EnableEventPublisher
+		data.Data["EventTransportHost"] = pool.eventConfig.TransportHost
 
-		if defaultCfg.Spec.EventConfig.EnableEventPublisher {
-			transportHost, e := r.EventTransportHostAvailabilityCheck(defaultCfg.Spec.EventConfig.TransportHost)
+		if pool.eventConfig.EnableEventPublisher {
+			transportHost, e := r.EventTransportHostAvailabilityCheck(pool.eventConfig.TransportHost)
 			if e != nil {
-				return e
+				return data, e
 			}
 			data.Data["EventTransportHost"] = transportHost
-			if defaultCfg.Spec.EventConfig.StorageType != "" {
-				data.Data["StorageType"] = defaultCfg.Spec.EventConfig.StorageType
-			}
-			if defaultCfg.Spec.EventConfig.ApiVersion != data.Data["EventApiVersion"] {
+			if pool.eventConfig.ApiVersion != data.Data["EventApiVersion"] {
 				glog.Infof("Event API version is '%s', using version %s.",
-					defaultCfg.Spec.EventConfig.ApiVersion,
+					pool.eventConfig.ApiVersion,
 					data.Data["EventApiVersion"])
 			}
 		}
 	}
 
-	var pluginList []string
+	setEventStorageTemplateData(&data, pool.eventConfig)
 
-	if defaultCfg.Spec.EnabledPlugins != nil {
-		for k := range *defaultCfg.Spec.EnabledPlugins {
-			pluginList = append(pluginList, k)
-		}
-	} else {
-		pluginList = []string{"e810", "e825", "e830", "ntpfailover"} // Enable e810 by default if plugins not specified
-	}
-	sort.Strings(pluginList)
-	enabledPlugins := strings.Join(pluginList, ",")
+	enabledPlugins := strings.Join(pool.pluginNames(), ",")
 	data.Data["EnabledPlugins"] = enabledPlugins
 	if enabledPlugins != "" {
-		glog.Infof("ptp operator enabled plugins: %s", enabledPlugins)
+		glog.Infof("ptp operator %s enabled plugins: %s", pool.daemonSetName(), enabledPlugins)
 	}
 
 	r.setTLSTemplateData(&data)
-
-	objs, err = render.RenderTemplate(filepath.Join(names.ManifestDir, "linuxptp/ptp-daemon.yaml"), &data)
-	if err != nil {
-		return fmt.Errorf("failed to render linuxptp daemon manifest: %v", err)
-	}
-
-	// Set context to indicate this update is from PtpOperatorConfig controller
-	// This allows the merge logic to preserve security volumes from current DaemonSet
-	ctxWithSource := context.WithValue(ctx, apply.ControllerSourceKey, apply.SourcePtpOperatorConfig)
-
-	for _, obj := range objs {
-		obj, err = r.setDaemonNodeSelector(defaultCfg, obj)
-		if err != nil {
-			return err
-		}
-
-		if err = controllerutil.SetControllerReference(defaultCfg, obj, r.Scheme); err != nil {
-			return fmt.Errorf("failed to set owner reference for daemon: %v", err)
-		}
-		if err = apply.ApplyObject(ctxWithSource, r.Client, obj); err != nil {
-			return fmt.Errorf("failed to apply object %v with err: %v", obj, err)
-		}
-	}
-
-	if defaultCfg.Spec.EventConfig == nil {
-		return nil
-	}
-
-	if defaultCfg.Spec.EventConfig.EnableEventPublisher {
-		for _, node := range nodeList.Items {
-			data.Data["NodeName"] = strings.Split(node.Name, ".")[0]
-			objs, err = render.RenderTemplate(filepath.Join(names.ManifestDir, "linuxptp/event-service.yaml"), &data)
-			if err != nil {
-				return fmt.Errorf("failed to render event service manifest: %v", err)
-			}
-			for _, obj := range objs {
-				if err = apply.ApplyObject(ctx, r.Client, obj); err != nil {
-					return fmt.Errorf("failed to apply service object %v with err: %v", obj, err)
-				}
-			}
-		}
-	}
-
-	return nil
+	return data, nil
 }
 
 // applyEventNetworkPolicy applies the NetworkPolicy for cloud-event-proxy
@@ -465,18 +493,10 @@ func (r *PtpOperatorConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-// EventTransportHostAvailabilityCheck ... check availability for transporthost
+// EventTransportHostAvailabilityCheck returns the transport host the event publisher uses: the configured one when
+// valid, DefaultTransportHost() otherwise. Invalid transport hosts are reported by the EventTransportValid condition.
 func (r *PtpOperatorConfigReconciler) EventTransportHostAvailabilityCheck(transportHost string) (string, error) {
-	if transportHost == "" {
-		glog.Warningf("ptp operator config Spec, ptpEventConfig.transportHost=%v is not valid, proceed as %s",
-			transportHost, DefaultTransportHost())
-		return DefaultTransportHost(), nil
-	}
-
-	_, err := url.Parse(transportHost)
-	if err != nil {
-		glog.Warningf("ptp operator config Spec, ptpEventConfig.transportHost=%v is not valid, proceed as %s",
-			transportHost, DefaultTransportHost())
+	if transportHost == "" || validateTransportHost(transportHost) != "" {
 		return DefaultTransportHost(), nil
 	}
 	return transportHost, nil
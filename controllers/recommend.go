@@ -13,51 +13,19 @@ import (
 	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
 )
 
-const ProfileNameSeperator = "_"
+// profilesRenderedConditionType reports whether the profiles of a PtpConfig are rendered for the linuxptp daemon
+const profilesRenderedConditionType = "ProfilesRendered"
 
-// create a node-unique profile name by prepending the PtpConfig CR name
-func qualifyProfileName(crName, profileName string) string {
-	return crName + ProfileNameSeperator + profileName
-}
-
-// check if a profile with the given name exists in the specified CR
-func profileExistsInCR(crName, profileName string, ptpConfigList *ptpv1.PtpConfigList) bool {
-	for _, cfg := range ptpConfigList.Items {
-		if cfg.Name != crName || cfg.Spec.Profile == nil {
-			continue
-		}
-		for _, p := range cfg.Spec.Profile {
-			if p.Name != nil && *p.Name == profileName {
-				return true
-			}
-		}
-	}
-	return false
-}
-
-// check if the user has already added a valid prefix to the profile name, if not add a prefix
+// check if the user has already added a valid prefix to the profile name, if not add a prefix. The reference is
+// resolved in the PtpConfigs of the namespace of the PtpConfig, and returned with the node profile name.
 func resolveProfileReference(value, settingName string, ptpConfig *ptpv1.PtpConfig, ptpConfigList *ptpv1.PtpConfigList) string {
-	// check if the user already added a valid prefix to the profile name
-	if parts := strings.SplitN(value, "_", 2); len(parts) == 2 {
-		if profileExistsInCR(parts[0], parts[1], ptpConfigList) {
-			return value
-		}
-	}
-
-	// search for the profile by its full name across all CRs if the user did not add a valid prefix
-	for _, cfg := range ptpConfigList.Items {
-		if cfg.Spec.Profile == nil {
-			continue
-		}
-		for _, p := range cfg.Spec.Profile {
-			if p.Name != nil && *p.Name == value {
-				return qualifyProfileName(cfg.Name, value)
-			}
-		}
+	if qualified, ok := ptpv1.ResolveProfileReference(value, ptpConfig.Namespace, ptpConfigList.Items); ok {
+		return ptpv1.NodeProfileName(ptpConfig.Namespace, qualified)
 	}
 
 	// profile not found anywhere -- warn and set condition on the PtpConfig
-	msg := fmt.Sprintf("profile '%s' referenced in %s not found in any PtpConfig CR", value, settingName)
+	msg := fmt.Sprintf("profile '%s' referenced in %s not found in any PtpConfig CR of namespace %s", value, settingName,
+		ptpConfig.Namespace)
 	glog.Warningf("PtpConfig %s: %s", ptpConfig.Name, msg)
 	meta.SetStatusCondition(&ptpConfig.Status.Conditions, metav1.Condition{
 		Type:               "ProfileReferenceValid",
@@ -83,6 +51,67 @@ func qualifyCrossProfileReferences(settings map[string]string, ptpConfig *ptpv1.
 	}
 }
 
+// renderProfile renders the typed settings, the high availability sources and the authentication of a profile into
+// the ptpSettings and ptp4lConf read by the linuxptp daemon
+func renderProfile(profile *ptpv1.PtpProfile) error {
+	settings, err := ptpv1.RenderPtpSettings(profile)
+	if err != nil {
+		return fmt.Errorf("failed to render settings: %v", err)
+	}
+	profile.PtpSettings = settings
+
+	if profile.Authentication != nil {
+		ptp4lConf, err := ptpv1.RenderPtp4lAuthentication(profile)
+		if err != nil {
+			return fmt.Errorf("failed to render authentication: %v", err)
+		}
+		profile.Ptp4lConf = &ptp4lConf
+	}
+	return nil
+}
+
+// profilesRenderedCondition reports whether the profiles of the PtpConfig with typed settings, a high availability
+// policy or authentication can be rendered. The condition is nil when no profile needs rendering.
+func profilesRenderedCondition(ptpConfig *ptpv1.PtpConfig) *metav1.Condition {
+	var problems []string
+	rendered := false
+	for i := range ptpConfig.Spec.Profile {
+		profile := &ptpConfig.Spec.Profile[i]
+		if profile.Settings == nil && profile.HighAvailability == nil && profile.Authentication == nil {
+			continue
+		}
+		rendered = true
+
+		profileName := "unknown"
+		if profile.Name != nil {
+			profileName = *profile.Name
+		}
+		profileCopy := profile.DeepCopy()
+		mirrorProfileSecret(profileCopy, ptpConfig.Namespace)
+		if err := renderProfile(profileCopy); err != nil {
+			problems = append(problems, fmt.Sprintf("profile %s: %v", profileName, err))
+		}
+	}
+
+	if !rendered {
+		return nil
+	}
+	if len(problems) > 0 {
+		return &metav1.Condition{
+			Type:    profilesRenderedConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  "RenderFailed",
+			Message: "profiles are not delivered to their nodes: " + strings.Join(problems, "; "),
+		}
+	}
+	return &metav1.Condition{
+		Type:    profilesRenderedConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Rendered",
+		Message: "the profiles are rendered for the linuxptp daemon",
+	}
+}
+
 func printWhenNotNil(p interface{}, description string) {
 	switch v := p.(type) {
 	case *string:
@@ -151,8 +180,16 @@ func getRecommendProfiles(ptpConfigList *ptpv1.PtpConfigList, node corev1.Node)
 			}
 			foundNames[*profile.Name] = true
 			profileCopy := profile.DeepCopy()
-			qualifiedName := qualifyProfileName(cfg.Name, *profile.Name)
+			qualifiedName := ptpv1.NodeProfileName(cfg.Namespace, ptpv1.QualifyProfileName(cfg.Name, *profile.Name))
 			profileCopy.Name = &qualifiedName
+			mirrorProfileSecret(profileCopy, cfg.Namespace)
+
+			// a profile that cannot be rendered is withheld rather than delivered without its settings or
+			// authentication, the failure is reported by the ProfilesRendered condition of the PtpConfig
+			if err := renderProfile(profileCopy); err != nil {
+				glog.Errorf("PtpConfig %s profile %s is not delivered to node %s: %v", cfg.Name, *profile.Name, node.Name, err)
+				continue
+			}
 
 			if profileCopy.PtpSettings != nil {
 				qualifyCrossProfileReferences(profileCopy.PtpSettings, cfg, ptpConfigList)
@@ -42,39 +42,6 @@ func makeNode(name string, labels map[string]string) corev1.Node {
 	}
 }
 
-func TestQualifyProfileName(t *testing.T) {
-	tests := []struct {
-		crName, profileName, expected string
-	}{
-		{"tbc", "maestro", "tbc_maestro"},
-		{"T-BC", "01-tbc-tr", "T-BC_01-tbc-tr"},
-		{"config-alpha", "my-profile", "config-alpha_my-profile"},
-	}
-	for _, tt := range tests {
-		result := qualifyProfileName(tt.crName, tt.profileName)
-		assert.Equal(t, tt.expected, result, "qualifyProfileName(%s, %s)", tt.crName, tt.profileName)
-	}
-}
-
-func TestProfileExistsInCR(t *testing.T) {
-	list := makePtpConfigList(
-		makePtpConfig("alpha", []ptpv1.PtpProfile{makeProfile("maestro", nil)}, nil),
-		makePtpConfig("beta", []ptpv1.PtpProfile{makeProfile("maestro", nil)}, nil),
-	)
-
-	assert.True(t, profileExistsInCR("alpha", "maestro", list), "profile exists in alpha")
-	assert.True(t, profileExistsInCR("beta", "maestro", list), "profile exists in beta")
-	assert.False(t, profileExistsInCR("alpha", "nonexistent", list), "profile does not exist")
-	assert.False(t, profileExistsInCR("gamma", "maestro", list), "CR does not exist")
-}
-
-func TestProfileExistsInCR_NilProfile(t *testing.T) {
-	list := makePtpConfigList(
-		ptpv1.PtpConfig{ObjectMeta: metav1.ObjectMeta{Name: "empty"}, Spec: ptpv1.PtpConfigSpec{}},
-	)
-	assert.False(t, profileExistsInCR("empty", "anything", list))
-}
-
 func TestResolveProfileReference_UserQualified(t *testing.T) {
 	// Case B: user already qualified "alpha_maestro" and alpha CR has profile "maestro"
 	list := makePtpConfigList(
@@ -201,6 +168,46 @@ func TestGetRecommendProfiles_Collision(t *testing.T) {
 	assert.Contains(t, names, "config-beta_maestro")
 }
 
+func TestGetRecommendProfiles_Namespaces(t *testing.T) {
+	// two tenants deliver same-named PtpConfigs and profiles to the same node
+	node := makeNode("worker-1", map[string]string{"ptp/tbc": ""})
+	makeTenantConfig := func(namespace string) ptpv1.PtpConfig {
+		tt := makeProfile("tbc-tt", map[string]string{"controllingProfile": "tbc-tr"})
+		cfg := makePtpConfig("tbc", []ptpv1.PtpProfile{tt, makeProfile("tbc-tr", nil)},
+			[]ptpv1.PtpRecommend{makeRecommend("tbc-tt", 5, "ptp/tbc"), makeRecommend("tbc-tr", 5, "ptp/tbc")})
+		cfg.Namespace = namespace
+		return cfg
+	}
+	list := makePtpConfigList(makeTenantConfig("tenant-a"), makeTenantConfig("tenant-b"))
+
+	profiles, err := getRecommendProfiles(list, node)
+	assert.NoError(t, err)
+	settings := map[string]map[string]string{}
+	for _, profile := range profiles {
+		settings[*profile.Name] = profile.PtpSettings
+	}
+	assert.Equal(t, map[string]map[string]string{
+		"tenant-a/tbc_tbc-tr": nil,
+		"tenant-a/tbc_tbc-tt": {"controllingProfile": "tenant-a/tbc_tbc-tr"},
+		"tenant-b/tbc_tbc-tr": nil,
+		"tenant-b/tbc_tbc-tt": {"controllingProfile": "tenant-b/tbc_tbc-tr"},
+	}, settings, "each tenant profile references the profile of its own namespace")
+
+	// the profiles of another tenant are not resolved
+	other := makeTenantConfig("tenant-c")
+	other.Spec.Profile = other.Spec.Profile[:1]
+	other.Spec.Recommend = other.Spec.Recommend[:1]
+	list = makePtpConfigList(makeTenantConfig("tenant-a"), other)
+	profiles, err = getRecommendProfiles(list, node)
+	assert.NoError(t, err)
+	for _, profile := range profiles {
+		if *profile.Name == "tenant-c/tbc_tbc-tt" {
+			assert.Equal(t, "tbc-tr", profile.PtpSettings["controllingProfile"])
+		}
+	}
+	assert.Equal(t, "UnresolvedProfileReference", list.Items[1].Status.Conditions[0].Reason)
+}
+
 func TestGetRecommendProfiles_OriginalNamePreserved(t *testing.T) {
 	// Case H: getRecommendNodePtpProfilesForConfig returns original names (for status.matchList)
 	node := makeNode("worker-1", map[string]string{"ptp/test": ""})
@@ -261,3 +268,91 @@ func TestGetRecommendProfiles_CrossCR_HAProfiles(t *testing.T) {
 		}
 	}
 }
+
+func TestGetRecommendProfiles_RendersAuthentication(t *testing.T) {
+	node := makeNode("worker-1", map[string]string{"ptp/tbc": ""})
+	profile := makeProfile("tbc", nil)
+	profile.Ptp4lConf = strPtr("[ens1f0]\nmasterOnly 0\n[global]\ndomainNumber 24")
+	profile.Authentication = &ptpv1.PtpAuthentication{
+		SecretRef:   corev1.LocalObjectReference{Name: "ptp-security"},
+		Key:         "sa_file.conf",
+		SPP:         1,
+		ActiveKeyID: 2,
+	}
+	list := makePtpConfigList(makePtpConfig("tbc-config", []ptpv1.PtpProfile{profile},
+		[]ptpv1.PtpRecommend{makeRecommend("tbc", 5, "ptp/tbc")}))
+
+	profiles, err := getRecommendProfiles(list, node)
+	assert.NoError(t, err)
+	assert.Len(t, profiles, 1)
+	assert.Equal(t, "[ens1f0]\nspp 1\nactive_key_id 2\nmasterOnly 0\n"+
+		"[global]\nsa_file /etc/ptp-secret-mount/ptp-security/sa_file.conf\nspp -1\ndomainNumber 24", *profiles[0].Ptp4lConf)
+	assert.Equal(t, "[ens1f0]\nmasterOnly 0\n[global]\ndomainNumber 24", *list.Items[0].Spec.Profile[0].Ptp4lConf,
+		"the PtpConfig must not be modified")
+}
+
+func TestGetRecommendProfiles_RendersSettings(t *testing.T) {
+	node := makeNode("worker-1", map[string]string{"ptp/tbc": ""})
+	tt := makeProfile("01-tbc-tt", map[string]string{"clockType": "T-BC"})
+	tt.Settings = &ptpv1.PtpProfileSettings{
+		ControllingProfile: "01-tbc-tr",
+		LogReduce:          &ptpv1.PtpLogReduce{Mode: ptpv1.LogReduceBasic},
+	}
+	list := makePtpConfigList(
+		makePtpConfig("tbc-config", []ptpv1.PtpProfile{tt, makeProfile("01-tbc-tr", nil)},
+			[]ptpv1.PtpRecommend{makeRecommend("01-tbc-tt", 5, "ptp/tbc"), makeRecommend("01-tbc-tr", 5, "ptp/tbc")}),
+	)
+
+	profiles, err := getRecommendProfiles(list, node)
+	assert.NoError(t, err)
+	assert.Len(t, profiles, 2)
+	assert.Equal(t, map[string]string{
+		"clockType":          "T-BC",
+		"controllingProfile": "tbc-config_01-tbc-tr",
+		"logReduce":          "basic",
+	}, profiles[1].PtpSettings)
+	assert.Equal(t, map[string]string{"clockType": "T-BC"}, list.Items[0].Spec.Profile[0].PtpSettings,
+		"the PtpConfig must not be modified")
+}
+
+func TestGetRecommendProfiles_WithholdsUnrenderedProfiles(t *testing.T) {
+	node := makeNode("worker-1", map[string]string{"ptp/tbc": ""})
+	conflicting := makeProfile("conflicting", map[string]string{"clockType": "T-BC"})
+	conflicting.Settings = &ptpv1.PtpProfileSettings{ClockType: "T-GM"}
+	unauthenticated := makeProfile("unauthenticated", nil)
+	unauthenticated.Authentication = &ptpv1.PtpAuthentication{
+		SecretRef: corev1.LocalObjectReference{Name: "ptp-security"},
+		SPP:       1,
+	}
+	cfg := makePtpConfig("tbc-config", []ptpv1.PtpProfile{conflicting, unauthenticated, makeProfile("plain", nil)},
+		[]ptpv1.PtpRecommend{makeRecommend("conflicting", 5, "ptp/tbc"), makeRecommend("unauthenticated", 5, "ptp/tbc"),
+			makeRecommend("plain", 5, "ptp/tbc")})
+
+	profiles, err := getRecommendProfiles(makePtpConfigList(cfg), node)
+	assert.NoError(t, err)
+	if assert.Len(t, profiles, 1, "profiles that cannot be rendered must not be delivered") {
+		assert.Equal(t, "tbc-config_plain", *profiles[0].Name)
+	}
+
+	condition := profilesRenderedCondition(&cfg)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, "RenderFailed", condition.Reason)
+		assert.Contains(t, condition.Message, "profile conflicting: failed to render settings: settings.clockType and ptpSettings clockType must not both be set")
+		assert.Contains(t, condition.Message, "profile unauthenticated: failed to render authentication: invalid authentication: key must be set")
+	}
+}
+
+func TestProfilesRenderedCondition(t *testing.T) {
+	plain := makePtpConfig("plain", []ptpv1.PtpProfile{makeProfile("plain", nil)}, nil)
+	assert.Nil(t, profilesRenderedCondition(&plain))
+
+	typed := makeProfile("typed", nil)
+	typed.Settings = &ptpv1.PtpProfileSettings{ClockType: "T-GM"}
+	cfg := makePtpConfig("typed", []ptpv1.PtpProfile{typed}, nil)
+	condition := profilesRenderedCondition(&cfg)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, "Rendered", condition.Reason)
+	}
+}
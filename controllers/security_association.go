@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	securityAssociationConditionType = "SecurityAssociationValid"
+
+	reasonSecurityAssociationValid   = "SecurityAssociationValid"
+	reasonInvalidSecurityAssociation = "InvalidSecurityAssociation"
+)
+
+// securityAssociationCondition validates the security association file of every authenticated profile
+// of the PtpConfig. The condition is nil when no profile is authenticated.
+func securityAssociationCondition(ptpConfig *ptpv1.PtpConfig, secrets map[string]*corev1.Secret) *metav1.Condition {
+	var problems []string
+	authenticated := false
+	for i := range ptpConfig.Spec.Profile {
+		profile := &ptpConfig.Spec.Profile[i]
+		saFilePath := ptpv1.ProfileSaFilePath(profile)
+		if saFilePath == "" {
+			continue
+		}
+		authenticated = true
+
+		profileName := "unknown"
+		if profile.Name != nil {
+			profileName = *profile.Name
+		}
+		secretName, secretKey, ok := saFileSecret(saFilePath)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("profile %s: invalid sa_file path '%s'", profileName, saFilePath))
+			continue
+		}
+
+		secret, exists := secrets[secretName]
+		if !exists {
+			problems = append(problems, fmt.Sprintf("profile %s: secret '%s' not found", profileName, secretName))
+			continue
+		}
+		saFile, exists := secret.Data[secretKey]
+		if !exists {
+			problems = append(problems, fmt.Sprintf("profile %s: key '%s' not found in secret '%s'", profileName, secretKey, secretName))
+			continue
+		}
+		if err := ptpv1.ValidateProfileSaFile(profile, string(saFile)); err != nil {
+			problems = append(problems, fmt.Sprintf("profile %s: key '%s' of secret '%s': %v", profileName, secretKey, secretName, err))
+		}
+	}
+
+	if !authenticated {
+		return nil
+	}
+	if len(problems) > 0 {
+		return &metav1.Condition{
+			Type:    securityAssociationConditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  reasonInvalidSecurityAssociation,
+			Message: strings.Join(problems, "; "),
+		}
+	}
+	return &metav1.Condition{
+		Type:    securityAssociationConditionType,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonSecurityAssociationValid,
+		Message: "security association files define the spp and active key of every authenticated port",
+	}
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"testing"
+
+	ptpv1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func makeAuthPtpConfig(activeKeyID int64) ptpv1.PtpConfig {
+	profile := makeProfile("tbc", nil)
+	profile.Ptp4lConf = strPtr("[global]\n[ens1f0]")
+	profile.Authentication = &ptpv1.PtpAuthentication{
+		SecretRef:   corev1.LocalObjectReference{Name: "ptp-sa"},
+		Key:         "sa_file",
+		SPP:         1,
+		ActiveKeyID: activeKeyID,
+	}
+	return makePtpConfig("cfg", []ptpv1.PtpProfile{profile}, nil)
+}
+
+func TestSecurityAssociationCondition(t *testing.T) {
+	secrets := map[string]*corev1.Secret{
+		"ptp-sa": {
+			ObjectMeta: metav1.ObjectMeta{Name: "ptp-sa"},
+			Data:       map[string][]byte{"sa_file": []byte("[security_association]\nspp 1\n1 SHA256-128 HEX:0011\n")},
+		},
+	}
+
+	// no authenticated profile
+	plain := makePtpConfig("plain", []ptpv1.PtpProfile{makeProfile("oc", nil)}, nil)
+	assert.Nil(t, securityAssociationCondition(&plain, secrets))
+
+	valid := makeAuthPtpConfig(1)
+	condition := securityAssociationCondition(&valid, secrets)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, reasonSecurityAssociationValid, condition.Reason)
+	}
+
+	missingKey := makeAuthPtpConfig(2)
+	condition = securityAssociationCondition(&missingKey, secrets)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, reasonInvalidSecurityAssociation, condition.Reason)
+		assert.Equal(t, "profile tbc: key 'sa_file' of secret 'ptp-sa': interface [ens1f0]: active key ID 2 is not defined for spp 1 in the security association file", condition.Message)
+	}
+
+	secrets["ptp-sa"].Data["sa_file"] = []byte("[security_association]\nspp 1\n1 AES128 HEX:0011\n")
+	condition = securityAssociationCondition(&valid, secrets)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, "profile tbc: key 'sa_file' of secret 'ptp-sa': line 3: key ID 1: AES128 key must be 16 bytes, got 2", condition.Message)
+	}
+
+	condition = securityAssociationCondition(&valid, map[string]*corev1.Secret{})
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, "profile tbc: secret 'ptp-sa' not found", condition.Message)
+	}
+}
@@ -73,7 +73,13 @@ func makeTestRenderData() *render.RenderData {
 	data.Data["EnableEventPublisher"] = false
 	data.Data["EnabledPlugins"] = "e810"
 	data.Data["StorageType"] = "emptyDir"
+	data.Data["StorageHostPath"] = defaultEventStorageHostPath
+	data.Data["StorageClaimName"] = ""
 	data.Data["EventApiVersion"] = "2.0"
+	data.Data["LogLevel"] = defaultDaemonLogLevel
+	data.Data["DaemonSetName"] = "linuxptp-daemon"
+	data.Data["DaemonPool"] = ""
+	data.Data["PluginsHash"] = ""
 	return &data
 }
 
@@ -33,6 +33,7 @@ import (
 
 	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/names"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -55,6 +56,7 @@ import (
 	"github.com/k8snetworkplumbingwg/ptp-operator/controllers"
 	"github.com/k8snetworkplumbingwg/ptp-operator/pkg/leaderelection"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -173,6 +175,13 @@ func main() {
 						names.Namespace: {},
 					},
 				},
+				// Only cache the endpoints of the event publisher services, which carry the labels of their service
+				&discoveryv1.EndpointSlice{}: {
+					Namespaces: map[string]cache.Config{
+						names.Namespace: {},
+					},
+					Label: labels.SelectorFromSet(labels.Set{"app": "linuxptp-daemon"}),
+				},
 			}
 			return cache.New(config, opts)
 		}
@@ -186,6 +195,13 @@ func main() {
 						names.Namespace: {},
 					},
 				},
+				// Only cache the endpoints of the event publisher services, which carry the labels of their service
+				&discoveryv1.EndpointSlice{}: {
+					Namespaces: map[string]cache.Config{
+						names.Namespace: {},
+					},
+					Label: labels.SelectorFromSet(labels.Set{"app": "linuxptp-daemon"}),
+				},
 			},
 		}
 		setupLog.Info("Restricting Secret watching to openshift-ptp namespace only")
@@ -202,15 +218,17 @@ func main() {
 		Log:            ctrl.Log.WithName("controllers").WithName("PtpOperatorConfig"),
 		Scheme:         mgr.GetScheme(),
 		TLSProfileSpec: tlsProfileSpecPtr,
+		APIReader:      mgr.GetAPIReader(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PtpOperatorConfig")
 		os.Exit(1)
 	}
 
 	if err = (&controllers.PtpConfigReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("PtpConfig"),
-		Scheme: mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		APIReader: mgr.GetAPIReader(),
+		Log:       ctrl.Log.WithName("controllers").WithName("PtpConfig"),
+		Scheme:    mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "PtpConfig")
 		os.Exit(1)
@@ -225,6 +243,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&controllers.PtpEventSubscriptionReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("PtpEventSubscription"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PtpEventSubscription")
+		os.Exit(1)
+	}
+
 	// Set up the TLS security profile watcher to detect profile changes at runtime.
 	// When the APIServer TLS profile changes, the watcher triggers a graceful shutdown
 	// so the operator restarts with the new TLS configuration.
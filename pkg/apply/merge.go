@@ -92,8 +92,8 @@ func MergeDeploymentForUpdate(current, updated *uns.Unstructured) error {
 func MergeDaemonSetForUpdate(ctx context.Context, current, updated *uns.Unstructured) error {
 	gvk := updated.GroupVersionKind()
 	if gvk.Group == "apps" && gvk.Kind == "DaemonSet" {
-		// Only apply to linuxptp-daemon DaemonSet
-		if updated.GetName() != "linuxptp-daemon" {
+		// Only apply to the linuxptp-daemon DaemonSet and the DaemonSets of the daemon pools
+		if _, pool := updated.GetLabels()["ptp.openshift.io/daemon-pool"]; updated.GetName() != "linuxptp-daemon" && !pool {
 			return nil
 		}
 
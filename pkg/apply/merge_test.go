@@ -261,6 +261,134 @@ metadata:
 	g.Expect(s).To(ConsistOf("foo"))
 }
 
+// TestMergeDaemonSetCustomizations makes sure the customized volumes, mounts and pod
+// annotations of the desired linuxptp-daemon are kept while the security items of the
+// current DaemonSet are preserved for PtpOperatorConfig updates
+func TestMergeDaemonSetCustomizations(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cur := UnstructuredFromYaml(t, `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: linuxptp-daemon
+spec:
+  template:
+    metadata:
+      annotations:
+        ptp.openshift.io/secret-hash-ptp-sa: cur
+        custom: cur
+    spec:
+      containers:
+      - name: linuxptp-daemon-container
+        volumeMounts:
+        - name: config-volume
+          mountPath: /etc/linuxptp
+        - name: ptp-sa-tlv-auth
+          mountPath: /etc/ptp-secret-mount/ptp-sa
+      volumes:
+      - name: config-volume
+      - name: ptp-sa-tlv-auth`)
+
+	upd := UnstructuredFromYaml(t, `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: linuxptp-daemon
+spec:
+  template:
+    metadata:
+      annotations:
+        custom: upd
+    spec:
+      containers:
+      - name: linuxptp-daemon-container
+        volumeMounts:
+        - name: config-volume
+          mountPath: /etc/linuxptp
+        - name: gnss
+          mountPath: /dev/gnss0
+      volumes:
+      - name: config-volume
+      - name: gnss`)
+
+	ctx := context.WithValue(context.Background(), ControllerSourceKey, SourcePtpOperatorConfig)
+	err := MergeObjectForUpdate(ctx, cur, upd)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	volumes, _, _ := uns.NestedSlice(upd.Object, "spec", "template", "spec", "volumes")
+	var volumeNames []string
+	for _, vol := range volumes {
+		volumeNames = append(volumeNames, vol.(map[string]interface{})["name"].(string))
+	}
+	g.Expect(volumeNames).To(ConsistOf("config-volume", "gnss", "ptp-sa-tlv-auth"))
+
+	containers, _, _ := uns.NestedSlice(upd.Object, "spec", "template", "spec", "containers")
+	mounts, _, _ := uns.NestedSlice(containers[0].(map[string]interface{}), "volumeMounts")
+	var mountNames []string
+	for _, mount := range mounts {
+		mountNames = append(mountNames, mount.(map[string]interface{})["name"].(string))
+	}
+	g.Expect(mountNames).To(ConsistOf("config-volume", "gnss", "ptp-sa-tlv-auth"))
+
+	annotations, _, _ := uns.NestedStringMap(upd.Object, "spec", "template", "metadata", "annotations")
+	g.Expect(annotations).To(Equal(map[string]string{
+		"ptp.openshift.io/secret-hash-ptp-sa": "cur",
+		"custom":                              "upd",
+	}))
+}
+
+// TestMergeDaemonPoolSecurityVolumes makes sure the security volumes of the DaemonSet of a daemon pool are
+// preserved for PtpOperatorConfig updates
+func TestMergeDaemonPoolSecurityVolumes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cur := UnstructuredFromYaml(t, `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: linuxptp-daemon-gm
+  labels:
+    ptp.openshift.io/daemon-pool: gm
+spec:
+  template:
+    spec:
+      containers:
+      - name: linuxptp-daemon-container
+        volumeMounts:
+        - name: ptp-secrets-tlv-auth
+          mountPath: /etc/ptp-secret-mount
+      volumes:
+      - name: config-volume
+      - name: ptp-secrets-tlv-auth`)
+
+	upd := UnstructuredFromYaml(t, `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: linuxptp-daemon-gm
+  labels:
+    ptp.openshift.io/daemon-pool: gm
+spec:
+  template:
+    spec:
+      containers:
+      - name: linuxptp-daemon-container
+      volumes:
+      - name: config-volume`)
+
+	ctx := context.WithValue(context.Background(), ControllerSourceKey, SourcePtpOperatorConfig)
+	err := MergeObjectForUpdate(ctx, cur, upd)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	volumes, _, _ := uns.NestedSlice(upd.Object, "spec", "template", "spec", "volumes")
+	var volumeNames []string
+	for _, vol := range volumes {
+		volumeNames = append(volumeNames, vol.(map[string]interface{})["name"].(string))
+	}
+	g.Expect(volumeNames).To(ConsistOf("config-volume", "ptp-secrets-tlv-auth"))
+}
+
 // UnstructuredFromYaml creates an unstructured object from a raw yaml string
 func UnstructuredFromYaml(t *testing.T, obj string) *uns.Unstructured {
 	t.Helper()
@@ -0,0 +1,177 @@
+package clocksim
+
+import (
+	"fmt"
+	"strings"
+
+	ptpv2alpha1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v2alpha1"
+)
+
+// sourceStates are the states a source can be in while the simulator evaluates supporting triggers
+var sourceStates = []string{"", ptpv2alpha1.ConditionTypeInit, ptpv2alpha1.ConditionTypeLocked, ptpv2alpha1.ConditionTypeLost}
+
+// Finding describes a condition that can never be triggered
+type Finding struct {
+	// Condition is the name of the unreachable condition
+	Condition string
+	// Reason explains why the condition can never be triggered
+	Reason string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%q: %s", f.Condition, f.Reason)
+}
+
+// Analysis is the result of the static analysis of a clock chain behavior
+type Analysis struct {
+	// Unreachable lists the conditions that no sequence of events can trigger
+	Unreachable []Finding
+	// Conflicts lists hardware settings assigned different values by a single condition,
+	// or by conditions that are triggered together by the same event
+	Conflicts []Conflict
+}
+
+// HasIssues returns true if any unreachable condition or conflicting action was found
+func (a *Analysis) HasIssues() bool {
+	return len(a.Unreachable) > 0 || len(a.Conflicts) > 0
+}
+
+func (a *Analysis) String() string {
+	var sb strings.Builder
+	for _, finding := range a.Unreachable {
+		sb.WriteString(fmt.Sprintf("unreachable condition %s\n", finding))
+	}
+	for _, conflict := range a.Conflicts {
+		sb.WriteString(fmt.Sprintf("conflicting actions %s\n", conflict))
+	}
+	return sb.String()
+}
+
+// Analyze detects unreachable conditions and conflicting actions without running any event
+func Analyze(chain *ptpv2alpha1.ClockChain) *Analysis {
+	analysis := &Analysis{}
+	if chain == nil || chain.Behavior == nil {
+		return analysis
+	}
+
+	sources := make(map[string]bool)
+	for _, source := range chain.Behavior.Sources {
+		sources[source.Name] = true
+	}
+
+	var reachable []ptpv2alpha1.Condition
+	for _, condition := range chain.Behavior.Conditions {
+		if reason := unreachableReason(condition, sources); reason != "" {
+			analysis.Unreachable = append(analysis.Unreachable, Finding{Condition: condition.Name, Reason: reason})
+			continue
+		}
+		reachable = append(reachable, condition)
+	}
+
+	// A single condition assigning a setting twice
+	for _, condition := range reachable {
+		values := make(map[string]string)
+		for _, setting := range desiredSettings(condition.DesiredStates) {
+			if previous, ok := values[setting.target]; ok && previous != setting.value {
+				analysis.Conflicts = append(analysis.Conflicts, Conflict{
+					Target:     setting.target,
+					Conditions: []string{condition.Name, condition.Name},
+					Values:     []string{previous, setting.value},
+				})
+			}
+			values[setting.target] = setting.value
+		}
+	}
+
+	// Two conditions that can be triggered by the same event assigning a setting differently
+	for i := range reachable {
+		for j := i + 1; j < len(reachable); j++ {
+			if !canTriggerTogether(reachable[i], reachable[j]) {
+				continue
+			}
+			first := finalValues(reachable[i])
+			second := finalValues(reachable[j])
+			for _, target := range sortedKeys(first) {
+				if value, ok := second[target]; ok && value != first[target] {
+					analysis.Conflicts = append(analysis.Conflicts, Conflict{
+						Target:     target,
+						Conditions: []string{reachable[i].Name, reachable[j].Name},
+						Values:     []string{first[target], value},
+					})
+				}
+			}
+		}
+	}
+	return analysis
+}
+
+// unreachableReason returns why a condition can never be triggered, or an empty string if it can
+func unreachableReason(condition ptpv2alpha1.Condition, sources map[string]bool) string {
+	if len(condition.Triggers) == 0 {
+		return "no triggers"
+	}
+	for _, trigger := range condition.Triggers {
+		if !isKnownConditionType(trigger.ConditionType) {
+			return fmt.Sprintf("unknown condition type %q for source %q", trigger.ConditionType, trigger.SourceName)
+		}
+		if trigger.SourceName != ptpv2alpha1.DefaultSourceName && !sources[trigger.SourceName] {
+			return fmt.Sprintf("unknown source %q", trigger.SourceName)
+		}
+	}
+	if !satisfiable(condition.Triggers[0], condition.Triggers[1:]) {
+		return "supporting triggers can not hold while the primary trigger fires"
+	}
+	return ""
+}
+
+// canTriggerTogether checks if a single event can trigger both conditions
+func canTriggerTogether(a, b ptpv2alpha1.Condition) bool {
+	pa, pb := a.Triggers[0], b.Triggers[0]
+	if pa.SourceName != pb.SourceName || normalizeConditionType(pa.ConditionType) != normalizeConditionType(pb.ConditionType) {
+		return false
+	}
+	supporting := append(append([]ptpv2alpha1.SourceState{}, a.Triggers[1:]...), b.Triggers[1:]...)
+	return satisfiable(pa, supporting)
+}
+
+// satisfiable checks if there is a state of every source that satisfies all supporting
+// triggers, given the state the primary trigger puts its own source in
+func satisfiable(primary ptpv2alpha1.SourceState, supporting []ptpv2alpha1.SourceState) bool {
+	bySource := make(map[string][]string)
+	for _, trigger := range supporting {
+		bySource[trigger.SourceName] = append(bySource[trigger.SourceName], trigger.ConditionType)
+	}
+	for source, conditionTypes := range bySource {
+		candidates := sourceStates
+		if source == primary.SourceName {
+			candidates = []string{primary.ConditionType}
+		}
+		found := false
+		for _, state := range candidates {
+			all := true
+			for _, conditionType := range conditionTypes {
+				if !stateSatisfies(state, conditionType) {
+					all = false
+					break
+				}
+			}
+			if all {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// finalValues returns the value each setting has after all desired states of the condition are applied
+func finalValues(condition ptpv2alpha1.Condition) map[string]string {
+	values := make(map[string]string)
+	for _, setting := range desiredSettings(condition.DesiredStates) {
+		values[setting.target] = setting.value
+	}
+	return values
+}
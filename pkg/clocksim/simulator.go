@@ -0,0 +1,399 @@
+// Package clocksim is an offline simulator for the HardwareConfig behavior section.
+//
+// The conditions of a ClockChain behavior form a state machine: source events
+// (PTP locked, GNSS lost, ...) trigger conditions, and triggered conditions apply
+// desired DPLL pin, PTP pin and PTP period states. The simulator replays a
+// scripted sequence of source events against a ClockChain and records the
+// resulting sequence of hardware changes, so clock chains can be verified in
+// unit tests without hardware.
+package clocksim
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	ptpv2alpha1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v2alpha1"
+)
+
+// Event is a single source state change fed into the simulator
+type Event struct {
+	// Source is the name of the source from behavior.sources[].name
+	Source string
+	// Condition is the new state of the source: "init", "default", "locked" or "lost"
+	Condition string
+}
+
+func (e Event) String() string {
+	return e.Condition + " " + e.Source
+}
+
+// Action is a single hardware change applied by a triggered condition
+type Action struct {
+	// Condition is the name of the condition that applied the change
+	Condition string
+	// Target identifies the changed hardware setting, e.g. "dpll leader/CVL-SDP22 pps priority"
+	Target string
+	// Previous is the value before the change, empty if the setting was never applied
+	Previous string
+	// Value is the value after the change
+	Value string
+}
+
+func (a Action) String() string {
+	previous := a.Previous
+	if previous == "" {
+		previous = "<unset>"
+	}
+	return fmt.Sprintf("%s: %s -> %s (%s)", a.Target, previous, a.Value, a.Condition)
+}
+
+// Conflict reports a hardware setting that is assigned different values by the
+// conditions triggered by the same event
+type Conflict struct {
+	// Conditions are the names of the conditions assigning the setting
+	Conditions []string
+	// Target identifies the hardware setting
+	Target string
+	// Values are the values assigned by each condition, in the same order as Conditions
+	Values []string
+}
+
+func (c Conflict) String() string {
+	assignments := make([]string, len(c.Conditions))
+	for i := range c.Conditions {
+		assignments[i] = fmt.Sprintf("%q sets %s", c.Conditions[i], c.Values[i])
+	}
+	return fmt.Sprintf("%s: %s", c.Target, strings.Join(assignments, ", "))
+}
+
+// Step is the outcome of a single simulated event
+type Step struct {
+	Event Event
+	// Triggered are the names of the conditions triggered by the event, in evaluation order
+	Triggered []string
+	// Actions are the hardware changes applied by the triggered conditions.
+	// Desired states that do not change the current value are not reported.
+	Actions []Action
+	// Conflicts are settings assigned different values while handling the event
+	Conflicts []Conflict
+}
+
+// Report is the outcome of a simulation run
+type Report struct {
+	Steps []Step
+	// NeverTriggered are the names of the conditions that no event of the run triggered
+	NeverTriggered []string
+	// Analysis is the static analysis of the clock chain behavior
+	Analysis *Analysis
+}
+
+// String renders the report in a human readable form
+func (r *Report) String() string {
+	var sb strings.Builder
+	for i, step := range r.Steps {
+		sb.WriteString(fmt.Sprintf("[%d] %s\n", i+1, step.Event))
+		if len(step.Triggered) == 0 {
+			sb.WriteString("    no condition triggered\n")
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("    triggered: %s\n", strings.Join(step.Triggered, ", ")))
+		for _, action := range step.Actions {
+			sb.WriteString(fmt.Sprintf("    %s\n", action))
+		}
+		for _, conflict := range step.Conflicts {
+			sb.WriteString(fmt.Sprintf("    CONFLICT %s\n", conflict))
+		}
+	}
+	if len(r.NeverTriggered) > 0 {
+		sb.WriteString(fmt.Sprintf("never triggered: %s\n", strings.Join(r.NeverTriggered, ", ")))
+	}
+	if r.Analysis != nil {
+		sb.WriteString(r.Analysis.String())
+	}
+	return sb.String()
+}
+
+// Simulator replays source events against a ClockChain behavior
+type Simulator struct {
+	chain *ptpv2alpha1.ClockChain
+	// sourceStates holds the last reported condition of each source
+	sourceStates map[string]string
+	// settings holds the current value of every hardware setting applied so far
+	settings  map[string]string
+	triggered map[string]bool
+}
+
+// NewSimulator validates the clock chain and returns a simulator in its initial state
+func NewSimulator(chain *ptpv2alpha1.ClockChain) (*Simulator, error) {
+	if chain == nil {
+		return nil, fmt.Errorf("clock chain must not be nil")
+	}
+	if err := chain.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid clock chain: %w", err)
+	}
+	if chain.Behavior == nil {
+		return nil, fmt.Errorf("clock chain has no behavior section to simulate")
+	}
+	s := &Simulator{chain: chain}
+	s.Reset()
+	return s, nil
+}
+
+// Reset returns the simulator to its initial state, with no source reported and no setting applied
+func (s *Simulator) Reset() {
+	s.sourceStates = make(map[string]string)
+	s.settings = make(map[string]string)
+	s.triggered = make(map[string]bool)
+}
+
+// Settings returns a copy of the current value of every hardware setting applied so far
+func (s *Simulator) Settings() map[string]string {
+	out := make(map[string]string, len(s.settings))
+	for k, v := range s.settings {
+		out[k] = v
+	}
+	return out
+}
+
+// Step applies a single event and returns the resulting changes
+func (s *Simulator) Step(event Event) (Step, error) {
+	step := Step{Event: event}
+	if !isKnownConditionType(event.Condition) {
+		return step, fmt.Errorf("event %q: unknown condition type %q", event, event.Condition)
+	}
+	if event.Source != ptpv2alpha1.DefaultSourceName && !s.hasSource(event.Source) {
+		return step, fmt.Errorf("event %q: unknown source %q", event, event.Source)
+	}
+	s.sourceStates[event.Source] = event.Condition
+
+	// assigned tracks the first assignment of each setting while handling this event
+	type assignment struct {
+		condition string
+		value     string
+	}
+	assigned := make(map[string]assignment)
+	conflicts := make(map[string]*Conflict)
+	var conflictOrder []string
+
+	for _, condition := range s.chain.Behavior.Conditions {
+		if !s.isTriggered(condition, event) {
+			continue
+		}
+		step.Triggered = append(step.Triggered, condition.Name)
+		s.triggered[condition.Name] = true
+
+		for _, setting := range desiredSettings(condition.DesiredStates) {
+			if first, ok := assigned[setting.target]; ok && first.value != setting.value {
+				conflict, exists := conflicts[setting.target]
+				if !exists {
+					conflict = &Conflict{
+						Target:     setting.target,
+						Conditions: []string{first.condition},
+						Values:     []string{first.value},
+					}
+					conflicts[setting.target] = conflict
+					conflictOrder = append(conflictOrder, setting.target)
+				}
+				conflict.Conditions = append(conflict.Conditions, condition.Name)
+				conflict.Values = append(conflict.Values, setting.value)
+			} else if !ok {
+				assigned[setting.target] = assignment{condition: condition.Name, value: setting.value}
+			}
+
+			previous := s.settings[setting.target]
+			if previous == setting.value {
+				continue
+			}
+			s.settings[setting.target] = setting.value
+			step.Actions = append(step.Actions, Action{
+				Condition: condition.Name,
+				Target:    setting.target,
+				Previous:  previous,
+				Value:     setting.value,
+			})
+		}
+	}
+
+	for _, target := range conflictOrder {
+		step.Conflicts = append(step.Conflicts, *conflicts[target])
+	}
+	return step, nil
+}
+
+// Run resets the simulator, replays all events and returns the report of the run
+func (s *Simulator) Run(events []Event) (*Report, error) {
+	s.Reset()
+	report := &Report{Analysis: Analyze(s.chain)}
+	for _, event := range events {
+		step, err := s.Step(event)
+		if err != nil {
+			return nil, err
+		}
+		report.Steps = append(report.Steps, step)
+	}
+	for _, condition := range s.chain.Behavior.Conditions {
+		if !s.triggered[condition.Name] {
+			report.NeverTriggered = append(report.NeverTriggered, condition.Name)
+		}
+	}
+	return report, nil
+}
+
+// ParseScript reads a sequence of events, one per line, in the form "<condition> <source>",
+// e.g. "locked PTP". Empty lines and lines starting with '#' are ignored.
+func ParseScript(r io.Reader) ([]Event, error) {
+	var events []Event
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, " ", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("line %d: expected '<condition> <source>', got %q", lineNumber, line)
+		}
+		condition := strings.ToLower(parts[0])
+		if !isKnownConditionType(condition) {
+			return nil, fmt.Errorf("line %d: unknown condition type %q", lineNumber, parts[0])
+		}
+		events = append(events, Event{Source: strings.TrimSpace(parts[1]), Condition: condition})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (s *Simulator) hasSource(name string) bool {
+	for _, source := range s.chain.Behavior.Sources {
+		if source.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isTriggered checks if the event matches the primary trigger of the condition
+// and the current source states satisfy all supporting triggers
+func (s *Simulator) isTriggered(condition ptpv2alpha1.Condition, event Event) bool {
+	if len(condition.Triggers) == 0 {
+		return false
+	}
+	primary := condition.Triggers[0]
+	if primary.SourceName != event.Source || normalizeConditionType(primary.ConditionType) != normalizeConditionType(event.Condition) {
+		return false
+	}
+	for _, trigger := range condition.Triggers[1:] {
+		if !stateSatisfies(s.sourceStates[trigger.SourceName], trigger.ConditionType) {
+			return false
+		}
+	}
+	return true
+}
+
+// stateSatisfies checks a supporting trigger against the current state of a source.
+// A source that never reported, or is still initializing, is not locked and
+// therefore satisfies a "lost" trigger.
+func stateSatisfies(state, conditionType string) bool {
+	conditionType = normalizeConditionType(conditionType)
+	state = normalizeConditionType(state)
+	if conditionType == ptpv2alpha1.ConditionTypeLost {
+		return state != ptpv2alpha1.ConditionTypeLocked
+	}
+	return state == conditionType
+}
+
+// normalizeConditionType maps condition type aliases to a single value
+func normalizeConditionType(conditionType string) string {
+	conditionType = strings.ToLower(conditionType)
+	if conditionType == ptpv2alpha1.ConditionTypeDefault {
+		return ptpv2alpha1.ConditionTypeInit
+	}
+	return conditionType
+}
+
+func isKnownConditionType(conditionType string) bool {
+	switch strings.ToLower(conditionType) {
+	case ptpv2alpha1.ConditionTypeInit, ptpv2alpha1.ConditionTypeDefault,
+		ptpv2alpha1.ConditionTypeLocked, ptpv2alpha1.ConditionTypeLost:
+		return true
+	default:
+		return false
+	}
+}
+
+// setting is a single hardware setting assigned by a desired state
+type setting struct {
+	target string
+	value  string
+}
+
+// desiredSettings flattens desired states into individual hardware settings, in the order they are applied
+func desiredSettings(states []ptpv2alpha1.DesiredState) []setting {
+	var out []setting
+	for _, state := range states {
+		if state.DPLL != nil {
+			pin := fmt.Sprintf("dpll %s/%s", state.DPLL.Subsystem, state.DPLL.BoardLabel)
+			out = append(out, pinSettings(pin+" eec", state.DPLL.EEC)...)
+			out = append(out, pinSettings(pin+" pps", state.DPLL.PPS)...)
+		}
+		if state.PTPPin != nil {
+			out = append(out, setting{
+				target: fmt.Sprintf("ptpPin %s%s", state.PTPPin.Name, sourceSuffix(state.PTPPin.SourceName)),
+				value:  fmt.Sprintf("func=%s chan=%d", state.PTPPin.Func, state.PTPPin.Chan),
+			})
+		}
+		if state.PTPPeriod != nil {
+			out = append(out, setting{
+				target: fmt.Sprintf("ptpPeriod %d%s", state.PTPPeriod.Index, sourceSuffix(state.PTPPeriod.SourceName)),
+				value: fmt.Sprintf("start=%s period=%s",
+					formatTimeSpec(state.PTPPeriod.Start), formatTimeSpec(state.PTPPeriod.Period)),
+			})
+		}
+	}
+	return out
+}
+
+func pinSettings(target string, pin *ptpv2alpha1.PinState) []setting {
+	if pin == nil {
+		return nil
+	}
+	var out []setting
+	if pin.Priority != nil {
+		out = append(out, setting{target: target + " priority", value: fmt.Sprintf("%d", *pin.Priority)})
+	}
+	if pin.State != "" {
+		out = append(out, setting{target: target + " state", value: pin.State})
+	}
+	return out
+}
+
+func sourceSuffix(sourceName string) string {
+	if sourceName == "" {
+		return ""
+	}
+	return " (" + sourceName + ")"
+}
+
+func formatTimeSpec(ts *ptpv2alpha1.PTPTimeSpec) string {
+	if ts == nil {
+		return "0.000000000"
+	}
+	return fmt.Sprintf("%d.%09d", ts.Sec, ts.Nsec)
+}
+
+// sortedKeys returns the keys of a map in lexical order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
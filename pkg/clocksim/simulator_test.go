@@ -0,0 +1,261 @@
+package clocksim
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	ptpv2alpha1 "github.com/k8snetworkplumbingwg/ptp-operator/api/v2alpha1"
+)
+
+func loadClockChain(t *testing.T, name string) *ptpv2alpha1.ClockChain {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read test file %s: %v", name, err)
+	}
+	var hwConfig ptpv2alpha1.HardwareConfig
+	if err := yaml.Unmarshal(data, &hwConfig); err != nil {
+		t.Fatalf("failed to unmarshal %s: %v", name, err)
+	}
+	return hwConfig.Spec.Profile.ClockChain
+}
+
+func int64Ptr(i int64) *int64 { return &i }
+
+func actionValues(step Step) map[string]string {
+	out := make(map[string]string)
+	for _, action := range step.Actions {
+		out[action.Target] = action.Value
+	}
+	return out
+}
+
+func TestTBCSimulation(t *testing.T) {
+	sim, err := NewSimulator(loadClockChain(t, "tbc.yaml"))
+	assert.NoError(t, err)
+
+	events, err := ParseScript(strings.NewReader(`
+# T-BC time receiver locks, loses and recovers its PTP source
+init PTP
+locked PTP
+lost PTP
+locked PTP
+`))
+	assert.NoError(t, err)
+	assert.Len(t, events, 4)
+
+	report, err := sim.Run(events)
+	assert.NoError(t, err)
+	assert.Len(t, report.Steps, 4)
+	assert.Empty(t, report.NeverTriggered)
+	assert.False(t, report.Analysis.HasIssues(), report.Analysis.String())
+
+	initStep := report.Steps[0]
+	assert.Equal(t, []string{"Initialize T-BC"}, initStep.Triggered)
+	values := actionValues(initStep)
+	assert.Equal(t, "func=TX chan=2", values["ptpPin SMA2 (PTP)"])
+	assert.Equal(t, "start=0.000000000 period=1.000000000", values["ptpPeriod 2 (PTP)"])
+	assert.Equal(t, "255", values["dpll leader/CVL-SDP22 pps priority"])
+	assert.Equal(t, "disconnected", values["dpll leader/CVL-SDP23 eec state"])
+
+	// only the PPS priority of the PTP input changes when PTP locks
+	lockedStep := report.Steps[1]
+	assert.Equal(t, []string{"PTP Source Active"}, lockedStep.Triggered)
+	assert.Equal(t, []Action{{
+		Condition: "PTP Source Active",
+		Target:    "dpll leader/CVL-SDP22 pps priority",
+		Previous:  "255",
+		Value:     "0",
+	}}, lockedStep.Actions)
+
+	lostValues := actionValues(report.Steps[2])
+	assert.Equal(t, "255", lostValues["dpll leader/CVL-SDP22 pps priority"])
+	assert.Equal(t, "connected", lostValues["dpll leader/CVL-SDP23 eec state"])
+	assert.Equal(t, "connected", lostValues["dpll leader/CVL-SDP23 pps state"])
+
+	recoveredValues := actionValues(report.Steps[3])
+	assert.Equal(t, "0", recoveredValues["dpll leader/CVL-SDP22 pps priority"])
+	assert.Equal(t, "disconnected", recoveredValues["dpll leader/CVL-SDP23 pps state"])
+
+	assert.Contains(t, report.String(), "[2] locked PTP")
+	assert.Contains(t, report.String(), "dpll leader/CVL-SDP22 pps priority: 255 -> 0 (PTP Source Active)")
+}
+
+func TestWPCSimulation(t *testing.T) {
+	sim, err := NewSimulator(loadClockChain(t, "wpc-gm.yaml"))
+	assert.NoError(t, err)
+
+	report, err := sim.Run([]Event{
+		{Source: "GNSS", Condition: "init"},
+		{Source: "GNSS", Condition: "locked"},
+		{Source: "GNSS", Condition: "lost"},
+		{Source: "External", Condition: "locked"},
+		{Source: "GNSS", Condition: "locked"},
+	})
+	assert.NoError(t, err)
+	assert.False(t, report.Analysis.HasIssues(), report.Analysis.String())
+
+	// GNSS lost without an external reference goes to holdover
+	assert.Equal(t, []string{"GNSS Lost - Holdover"}, report.Steps[2].Triggered)
+	assert.Empty(t, report.Steps[2].Actions, "SMA1 is already disabled")
+
+	// the external reference locking does not trigger anything by itself
+	assert.Empty(t, report.Steps[3].Triggered)
+
+	assert.Equal(t, "255", sim.Settings()["dpll wpc/SMA1 pps priority"])
+	assert.Equal(t, []string{"GNSS Lost - External Backup"}, report.NeverTriggered)
+
+	// GNSS lost while the external reference is locked selects the backup
+	sim.Reset()
+	for _, event := range []Event{
+		{Source: "GNSS", Condition: "init"},
+		{Source: "External", Condition: "locked"},
+	} {
+		_, err = sim.Step(event)
+		assert.NoError(t, err)
+	}
+	step, err := sim.Step(Event{Source: "GNSS", Condition: "lost"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GNSS Lost - External Backup"}, step.Triggered)
+	assert.Equal(t, "1", actionValues(step)["dpll wpc/SMA1 pps priority"])
+}
+
+func TestStepErrors(t *testing.T) {
+	sim, err := NewSimulator(loadClockChain(t, "tbc.yaml"))
+	assert.NoError(t, err)
+
+	_, err = sim.Step(Event{Source: "GNSS", Condition: "locked"})
+	assert.ErrorContains(t, err, "unknown source")
+
+	_, err = sim.Step(Event{Source: "PTP", Condition: "degraded"})
+	assert.ErrorContains(t, err, "unknown condition type")
+}
+
+func TestNewSimulatorErrors(t *testing.T) {
+	_, err := NewSimulator(nil)
+	assert.Error(t, err)
+
+	_, err = NewSimulator(&ptpv2alpha1.ClockChain{})
+	assert.ErrorContains(t, err, "invalid clock chain")
+
+	_, err = NewSimulator(&ptpv2alpha1.ClockChain{
+		Structure: []ptpv2alpha1.Subsystem{{Name: "leader"}},
+	})
+	assert.ErrorContains(t, err, "no behavior")
+}
+
+func TestParseScriptErrors(t *testing.T) {
+	_, err := ParseScript(strings.NewReader("locked"))
+	assert.ErrorContains(t, err, "line 1")
+
+	_, err = ParseScript(strings.NewReader("init PTP\nflapping PTP"))
+	assert.ErrorContains(t, err, "line 2: unknown condition type")
+
+	events, err := ParseScript(strings.NewReader("default Default on profile (re)load"))
+	assert.NoError(t, err)
+	assert.Equal(t, []Event{{Source: ptpv2alpha1.DefaultSourceName, Condition: "default"}}, events)
+}
+
+func dpllState(subsystem, label string, eecPriority, ppsPriority int64) ptpv2alpha1.DesiredState {
+	return ptpv2alpha1.DesiredState{DPLL: &ptpv2alpha1.DPLLDesiredState{
+		Subsystem:  subsystem,
+		BoardLabel: label,
+		EEC:        &ptpv2alpha1.PinState{Priority: int64Ptr(eecPriority)},
+		PPS:        &ptpv2alpha1.PinState{Priority: int64Ptr(ppsPriority)},
+	}}
+}
+
+func TestAnalyze(t *testing.T) {
+	chain := &ptpv2alpha1.ClockChain{
+		Structure: []ptpv2alpha1.Subsystem{{Name: "leader"}},
+		Behavior: &ptpv2alpha1.Behavior{
+			Sources: []ptpv2alpha1.SourceConfig{
+				{Name: "PTP", Subsystem: "leader", SourceType: ptpv2alpha1.SourceTypeDPLL},
+				{Name: "GNSS", Subsystem: "leader", SourceType: ptpv2alpha1.SourceTypeDPLL},
+			},
+			Conditions: []ptpv2alpha1.Condition{
+				{
+					Name:          "PTP locked",
+					Triggers:      []ptpv2alpha1.SourceState{{SourceName: "PTP", ConditionType: "locked"}},
+					DesiredStates: []ptpv2alpha1.DesiredState{dpllState("leader", "SDP22", 255, 0)},
+				},
+				{
+					Name: "PTP locked while GNSS lost",
+					Triggers: []ptpv2alpha1.SourceState{
+						{SourceName: "PTP", ConditionType: "locked"},
+						{SourceName: "GNSS", ConditionType: "lost"},
+					},
+					DesiredStates: []ptpv2alpha1.DesiredState{dpllState("leader", "SDP22", 255, 3)},
+				},
+				{
+					Name: "PTP locked while GNSS locked",
+					Triggers: []ptpv2alpha1.SourceState{
+						{SourceName: "PTP", ConditionType: "locked"},
+						{SourceName: "GNSS", ConditionType: "locked"},
+						{SourceName: "GNSS", ConditionType: "lost"},
+					},
+					DesiredStates: []ptpv2alpha1.DesiredState{dpllState("leader", "SDP22", 255, 4)},
+				},
+				{
+					Name: "PTP lost while PTP locked",
+					Triggers: []ptpv2alpha1.SourceState{
+						{SourceName: "PTP", ConditionType: "lost"},
+						{SourceName: "PTP", ConditionType: "locked"},
+					},
+				},
+				{
+					Name:     "flapping",
+					Triggers: []ptpv2alpha1.SourceState{{SourceName: "PTP", ConditionType: "flapping"}},
+				},
+				{
+					Name: "twice",
+					Triggers: []ptpv2alpha1.SourceState{
+						{SourceName: "GNSS", ConditionType: "locked"},
+					},
+					DesiredStates: []ptpv2alpha1.DesiredState{
+						dpllState("leader", "GNSS", 0, 0),
+						dpllState("leader", "GNSS", 0, 1),
+					},
+				},
+			},
+		},
+	}
+
+	analysis := Analyze(chain)
+	assert.True(t, analysis.HasIssues())
+
+	unreachable := make(map[string]string)
+	for _, finding := range analysis.Unreachable {
+		unreachable[finding.Condition] = finding.Reason
+	}
+	assert.Len(t, unreachable, 3)
+	assert.Contains(t, unreachable, "PTP locked while GNSS locked")
+	assert.Contains(t, unreachable, "PTP lost while PTP locked")
+	assert.Contains(t, unreachable["flapping"], "unknown condition type")
+
+	assert.Len(t, analysis.Conflicts, 2)
+	assert.Equal(t, Conflict{
+		Target:     "dpll leader/GNSS pps priority",
+		Conditions: []string{"twice", "twice"},
+		Values:     []string{"0", "1"},
+	}, analysis.Conflicts[0])
+	assert.Equal(t, Conflict{
+		Target:     "dpll leader/SDP22 pps priority",
+		Conditions: []string{"PTP locked", "PTP locked while GNSS lost"},
+		Values:     []string{"0", "3"},
+	}, analysis.Conflicts[1])
+
+	// the same conflict is reported at run time
+	sim, err := NewSimulator(chain)
+	assert.NoError(t, err)
+	step, err := sim.Step(Event{Source: "PTP", Condition: "locked"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"PTP locked", "PTP locked while GNSS lost"}, step.Triggered)
+	assert.Len(t, step.Conflicts, 1)
+	assert.Equal(t, "3", sim.Settings()["dpll leader/SDP22 pps priority"], "the last condition wins")
+}
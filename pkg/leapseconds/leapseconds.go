@@ -0,0 +1,221 @@
+// Package leapseconds parses and validates IERS leap-seconds.list files.
+//
+// A leap-seconds.list file holds the time of its last update (#$ line), its
+// expiration time (#@ line), one line per leap second with the NTP time it
+// takes effect and the TAI-UTC offset from then on, and the SHA-1 hash of
+// those values (#h line). Times are NTP seconds since 1900-01-01 UTC.
+package leapseconds
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ntpEpochOffset is the number of seconds between the NTP epoch, 1900-01-01, and the Unix epoch
+const ntpEpochOffset = 2208988800
+
+// AnnounceWindow is how long before a leap second grandmasters set the leap61 or leap59 flag of their announce
+// messages. linuxptp sets the flags at most 12 hours before the leap second.
+const AnnounceWindow = 12 * time.Hour
+
+// LeapSecond is a change of the TAI-UTC offset
+type LeapSecond struct {
+	// Time is when the offset takes effect
+	Time time.Time
+	// Offset is the TAI-UTC offset in seconds from Time on
+	Offset int
+}
+
+// File is a parsed leap-seconds.list file
+type File struct {
+	// Updated is the time of the last update of the file
+	Updated time.Time
+	// Expires is the time after which the file must not be used
+	Expires time.Time
+	// LeapSeconds are the offset changes, in time order
+	LeapSeconds []LeapSecond
+}
+
+// NTPToTime converts NTP seconds to a time
+func NTPToTime(seconds uint64) time.Time {
+	return time.Unix(int64(seconds)-ntpEpochOffset, 0).UTC()
+}
+
+// TimeToNTP converts a time to NTP seconds
+func TimeToNTP(t time.Time) uint64 {
+	return uint64(t.Unix() + ntpEpochOffset)
+}
+
+// Parse parses a leap-seconds.list file and checks its hash
+func Parse(content string) (*File, error) {
+	f := &File{}
+	var updated, expires string
+	var hashWords []string
+	var data []string
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "#$"):
+			fields := strings.Fields(line[2:])
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("line %d: missing last update time", lineNumber)
+			}
+			updated = fields[0]
+		case strings.HasPrefix(line, "#@"):
+			fields := strings.Fields(line[2:])
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("line %d: missing expiration time", lineNumber)
+			}
+			expires = fields[0]
+		case strings.HasPrefix(line, "#h"):
+			hashWords = strings.Fields(line[2:])
+		case line == "" || strings.HasPrefix(line, "#"):
+		default:
+			fields := strings.Fields(strings.SplitN(line, "#", 2)[0])
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("line %d: expected leap second time and offset", lineNumber)
+			}
+			seconds, err := strconv.ParseUint(fields[0], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid leap second time '%s'", lineNumber, fields[0])
+			}
+			offset, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid offset '%s'", lineNumber, fields[1])
+			}
+			leap := LeapSecond{Time: NTPToTime(seconds), Offset: offset}
+			if n := len(f.LeapSeconds); n > 0 && !leap.Time.After(f.LeapSeconds[n-1].Time) {
+				return nil, fmt.Errorf("line %d: leap seconds are not in time order", lineNumber)
+			}
+			f.LeapSeconds = append(f.LeapSeconds, leap)
+			data = append(data, fields[0], fields[1])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if updated == "" {
+		return nil, fmt.Errorf("missing last update time (#$ line)")
+	}
+	if expires == "" {
+		return nil, fmt.Errorf("missing expiration time (#@ line)")
+	}
+	if len(f.LeapSeconds) == 0 {
+		return nil, fmt.Errorf("no leap second")
+	}
+	updatedSeconds, err := strconv.ParseUint(updated, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid last update time '%s'", updated)
+	}
+	expiresSeconds, err := strconv.ParseUint(expires, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiration time '%s'", expires)
+	}
+	f.Updated = NTPToTime(updatedSeconds)
+	f.Expires = NTPToTime(expiresSeconds)
+
+	if len(hashWords) == 0 {
+		return nil, fmt.Errorf("missing hash (#h line)")
+	}
+	expected := hash(append([]string{updated, expires}, data...))
+	if !hashMatches(hashWords, expected) {
+		return nil, fmt.Errorf("hash mismatch: file is corrupted or was modified")
+	}
+	return f, nil
+}
+
+// Format renders a file in the leap-seconds.list format, with its hash
+func Format(f *File) string {
+	updated := strconv.FormatUint(TimeToNTP(f.Updated), 10)
+	expires := strconv.FormatUint(TimeToNTP(f.Expires), 10)
+	fields := []string{updated, expires}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "#$\t%s\n", updated)
+	fmt.Fprintf(&b, "#@\t%s\n", expires)
+	for _, leap := range f.LeapSeconds {
+		seconds := strconv.FormatUint(TimeToNTP(leap.Time), 10)
+		offset := strconv.Itoa(leap.Offset)
+		fields = append(fields, seconds, offset)
+		fmt.Fprintf(&b, "%s\t%s\t# %s\n", seconds, offset, leap.Time.Format("2 Jan 2006"))
+	}
+	sum := hash(fields)
+	b.WriteString("#h")
+	for i := 0; i < sha1.Size; i += 4 {
+		fmt.Fprintf(&b, "\t%x", binary.BigEndian.Uint32(sum[i:]))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// hash computes the SHA-1, mandated by the file format, of the concatenated digits of the update time, expiration
+// time and leap seconds
+func hash(fields []string) [sha1.Size]byte {
+	return sha1.Sum([]byte(strings.Join(fields, "")))
+}
+
+// hashMatches compares the #h words, five 32 bit hexadecimal numbers whose leading zeros may be omitted, to a hash
+func hashMatches(words []string, sum [sha1.Size]byte) bool {
+	if len(words) != sha1.Size/4 {
+		return false
+	}
+	for i, word := range words {
+		value, err := strconv.ParseUint(word, 16, 32)
+		if err != nil || uint32(value) != binary.BigEndian.Uint32(sum[i*4:]) {
+			return false
+		}
+	}
+	return true
+}
+
+// UTCOffset returns the TAI-UTC offset in seconds at a time
+func (f *File) UTCOffset(at time.Time) int {
+	offset := 0
+	for _, leap := range f.LeapSeconds {
+		if leap.Time.After(at) {
+			break
+		}
+		offset = leap.Offset
+	}
+	return offset
+}
+
+// Announce returns the currentUtcOffset and the leap61 and leap59 flags a grandmaster using the file announces at a
+// time. The flags are set during the AnnounceWindow preceding a leap second.
+func (f *File) Announce(at time.Time) (utcOffset int, leap61, leap59 bool) {
+	utcOffset = f.UTCOffset(at)
+	for _, leap := range f.LeapSeconds {
+		if !leap.Time.After(at) {
+			continue
+		}
+		if leap.Time.Sub(at) <= AnnounceWindow {
+			leap61 = leap.Offset > utcOffset
+			leap59 = leap.Offset < utcOffset
+		}
+		break
+	}
+	return utcOffset, leap61, leap59
+}
+
+// Expired returns true when the file must not be used anymore
+func (f *File) Expired(now time.Time) bool {
+	return !now.Before(f.Expires)
+}
+
+// NewerThan returns true when the file was updated after another one, or at the same time but expires later
+func (f *File) NewerThan(other *File) bool {
+	if other == nil || f.Updated.After(other.Updated) {
+		return true
+	}
+	return f.Updated.Equal(other.Updated) && f.Expires.After(other.Expires)
+}
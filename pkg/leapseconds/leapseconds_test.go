@@ -0,0 +1,150 @@
+package leapseconds
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadFile(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read test file %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func TestParse(t *testing.T) {
+	f, err := Parse(loadFile(t, "leap-seconds-2025b.list"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, time.Date(2025, time.January, 7, 0, 0, 0, 0, time.UTC), f.Updated)
+	assert.Equal(t, time.Date(2025, time.December, 28, 0, 0, 0, 0, time.UTC), f.Expires)
+	assert.Len(t, f.LeapSeconds, 28)
+	assert.Equal(t, LeapSecond{Time: time.Date(1972, time.January, 1, 0, 0, 0, 0, time.UTC), Offset: 10}, f.LeapSeconds[0])
+	assert.Equal(t, LeapSecond{Time: time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC), Offset: 37}, f.LeapSeconds[27])
+
+	bundled, err := os.ReadFile("../../bindata/linuxptp/leap-seconds.list")
+	assert.NoError(t, err)
+	_, err = Parse(string(bundled))
+	assert.NoError(t, err)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	valid := loadFile(t, "leap-seconds-2025b.list")
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{name: "empty", content: "", errMsg: "missing last update time"},
+		{
+			name:    "modified offset",
+			content: strings.Replace(valid, "3692217600      37", "3692217600      38", 1),
+			errMsg:  "hash mismatch",
+		},
+		{
+			name:    "modified expiration",
+			content: strings.Replace(valid, "#@\t3975868800", "#@\t3991593600", 1),
+			errMsg:  "hash mismatch",
+		},
+		{
+			name:    "missing hash",
+			content: strings.Replace(valid, "#h\t", "# \t", 1),
+			errMsg:  "missing hash",
+		},
+		{
+			name:    "missing expiration",
+			content: strings.Replace(valid, "#@\t", "# \t", 1),
+			errMsg:  "missing expiration time",
+		},
+		{
+			name:    "invalid leap second line",
+			content: strings.Replace(valid, "3692217600      37", "3692217600", 1),
+			errMsg:  "expected leap second time and offset",
+		},
+		{
+			name:    "out of order",
+			content: strings.Replace(valid, "3692217600      37", "2272060800      37", 1),
+			errMsg:  "not in time order",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.content)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestUTCOffset(t *testing.T) {
+	f, err := Parse(loadFile(t, "leap-seconds-2025b.list"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, 0, f.UTCOffset(time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 36, f.UTCOffset(time.Date(2016, time.December, 31, 23, 59, 59, 0, time.UTC)))
+	assert.Equal(t, 37, f.UTCOffset(time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestExpiredAndNewerThan(t *testing.T) {
+	older, err := Parse(loadFile(t, "leap-seconds-2025b.list"))
+	assert.NoError(t, err)
+	bundled, err := os.ReadFile("../../bindata/linuxptp/leap-seconds.list")
+	assert.NoError(t, err)
+	newer, err := Parse(string(bundled))
+	assert.NoError(t, err)
+
+	assert.True(t, older.Expired(older.Expires))
+	assert.False(t, older.Expired(older.Expires.Add(-time.Second)))
+
+	assert.True(t, newer.NewerThan(older))
+	assert.False(t, older.NewerThan(newer))
+	assert.False(t, older.NewerThan(older))
+	assert.True(t, older.NewerThan(nil))
+}
+
+func TestNTPTime(t *testing.T) {
+	assert.Equal(t, time.Date(1972, time.January, 1, 0, 0, 0, 0, time.UTC), NTPToTime(2272060800))
+	assert.Equal(t, uint64(2272060800), TimeToNTP(time.Date(1972, time.January, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestFormat(t *testing.T) {
+	f, err := Parse(loadFile(t, "leap-seconds-2025b.list"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	f.LeapSeconds = append(f.LeapSeconds, LeapSecond{Time: time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC), Offset: 38})
+
+	formatted, err := Parse(Format(f))
+	if assert.NoError(t, err) {
+		assert.Equal(t, f, formatted)
+	}
+}
+
+func TestAnnounce(t *testing.T) {
+	f, err := Parse(loadFile(t, "leap-seconds-2025b.list"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	leap := time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	offset, leap61, leap59 := f.Announce(leap.Add(-AnnounceWindow - time.Second))
+	assert.Equal(t, []interface{}{36, false, false}, []interface{}{offset, leap61, leap59})
+	offset, leap61, leap59 = f.Announce(leap.Add(-time.Second))
+	assert.Equal(t, []interface{}{36, true, false}, []interface{}{offset, leap61, leap59})
+	offset, leap61, leap59 = f.Announce(leap)
+	assert.Equal(t, []interface{}{37, false, false}, []interface{}{offset, leap61, leap59})
+
+	f.LeapSeconds = append(f.LeapSeconds, LeapSecond{Time: time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC), Offset: 36})
+	offset, leap61, leap59 = f.Announce(time.Date(2026, time.December, 31, 23, 0, 0, 0, time.UTC))
+	assert.Equal(t, []interface{}{37, false, true}, []interface{}{offset, leap61, leap59})
+}